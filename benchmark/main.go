@@ -81,10 +81,10 @@ func DeployContractAndSubmitDataCommitment() error {
 	evmClient := evm.NewClient(
 		tmlog.NewTMLogger(os.Stdout),
 		nil,
-		ks,
-		&acc,
+		evm.NewKeystoreSigner(ks, acc),
 		EVMRPC,
 		2500000,
+		evm.DefaultGasPolicy(),
 	)
 
 	txOpts, err := evmClient.NewTransactionOpts(ctx)
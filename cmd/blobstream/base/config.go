@@ -14,6 +14,9 @@ import (
 	ethcmn "github.com/ethereum/go-ethereum/common"
 
 	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/pruner"
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
 
 	"github.com/spf13/cobra"
 
@@ -69,6 +72,7 @@ const (
 	FlagBootstrappers    = "p2p.bootstrappers"
 	FlagP2PListenAddress = "p2p.listen-addr"
 	FlagP2PNickname      = "p2p.nickname"
+	FlagP2PJSONRecords   = "p2p.json-records"
 	FlagGRPCInsecure     = "grpc.insecure"
 
 	FlagEVMAccAddress      = "evm.account"
@@ -77,6 +81,7 @@ const (
 	FlagEVMGasLimit        = "evm.gas-limit"
 	FlagEVMContractAddress = "evm.contract-address"
 	FlagEVMRetryTimeout    = "evm.retry-timeout"
+	FlagEVMRemoteSigner    = "evm.remote-signer"
 
 	FlagCoreGRPC = "core.grpc"
 	FlagCoreRPC  = "core.rpc"
@@ -88,11 +93,41 @@ const (
 
 	FlagBackupRelayer         = "relayer.backup"
 	FlagBackupRelayerWaitTime = "relayer.wait-time"
+	FlagMulticallAddress      = "relayer.multicall-address"
+	FlagMaxBatchSize          = "relayer.max-batch-size"
+	FlagBatchGasCap           = "relayer.batch-gas-cap"
+	FlagMaxInFlight           = "relayer.max-in-flight"
+	FlagRelayers              = "relayer.relayers"
+	FlagLowBalanceThreshold   = "relayer.low-balance-threshold"
 
 	FlagMetrics            = "metrics"
 	FlagMetricsEndpoint    = "metrics.endpoint"
 	FlagMetricsTLS         = "metrics.tls"
 	FlagMetricsP2PEndpoint = "metrics.p2p"
+
+	FlagAdmin           = "admin"
+	FlagAdminListenAddr = "admin.listen-addr"
+
+	FlagHealth           = "health"
+	FlagHealthListenAddr = "health.listen-addr"
+
+	FlagTracing         = "tracing"
+	FlagTracingExporter = "tracing.exporter"
+	FlagTracingEndpoint = "tracing.endpoint"
+	FlagTracingTLS      = "tracing.tls"
+
+	FlagConfirmsMonitor            = "confirms-monitor"
+	FlagConfirmsMonitorInterval    = "confirms-monitor.interval"
+	FlagConfirmsMonitorWindow      = "confirms-monitor.window"
+	FlagConfirmsMonitorRebroadcast = "confirms-monitor.rebroadcast"
+
+	FlagRetentionNonces   = "retention.nonces"
+	FlagRetentionDays     = "retention.days"
+	FlagRetentionInterval = "retention.interval"
+
+	FlagP2PGating      = "p2p.gating"
+	FlagP2PAllowlist   = "p2p.allowlist"
+	FlagP2PPeerScoring = "p2p.peer-scoring"
 )
 
 func AddLogLevelFlag(cmd *cobra.Command) {
@@ -175,6 +210,23 @@ func GetP2PListenAddressFlag(cmd *cobra.Command) (string, bool, error) {
 	return val, changed, nil
 }
 
+func AddP2PJSONRecordsFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(
+		FlagP2PJSONRecords,
+		false,
+		"Forces putting the DHT records using the legacy JSON format. Otherwise, the compact binary format is used whenever all the peers the records are put to advertise supporting it",
+	)
+}
+
+func GetP2PJSONRecordsFlag(cmd *cobra.Command) (bool, bool, error) {
+	changed := cmd.Flags().Changed(FlagP2PJSONRecords)
+	val, err := cmd.Flags().GetBool(FlagP2PJSONRecords)
+	if err != nil {
+		return false, changed, err
+	}
+	return val, changed, nil
+}
+
 func AddBootstrappersFlag(cmd *cobra.Command) {
 	cmd.Flags().String(FlagBootstrappers, "", "Comma-separated multiaddresses of p2p peers to connect to")
 }
@@ -313,6 +365,112 @@ func GetBackupRelayerWaitTimeFlag(cmd *cobra.Command) (uint64, bool, error) {
 	return val, changed, nil
 }
 
+func AddMulticallAddressFlag(cmd *cobra.Command) {
+	cmd.Flags().String(
+		FlagMulticallAddress,
+		"",
+		"Specify the address of the multicall contract to use for relaying multiple attestations in a single transaction. "+
+			"If not set, attestations are relayed one by one",
+	)
+}
+
+func GetMulticallAddressFlag(cmd *cobra.Command) (string, bool, error) {
+	changed := cmd.Flags().Changed(FlagMulticallAddress)
+	val, err := cmd.Flags().GetString(FlagMulticallAddress)
+	if err != nil {
+		return "", changed, err
+	}
+	return val, changed, nil
+}
+
+func AddMaxBatchSizeFlag(cmd *cobra.Command) {
+	cmd.Flags().Uint64(
+		FlagMaxBatchSize,
+		10,
+		"The maximum number of attestations to relay in a single transaction when the multicall contract address is set",
+	)
+}
+
+func GetMaxBatchSizeFlag(cmd *cobra.Command) (uint64, bool, error) {
+	changed := cmd.Flags().Changed(FlagMaxBatchSize)
+	val, err := cmd.Flags().GetUint64(FlagMaxBatchSize)
+	if err != nil {
+		return 0, changed, err
+	}
+	return val, changed, nil
+}
+
+func AddBatchGasCapFlag(cmd *cobra.Command) {
+	cmd.Flags().Uint64(
+		FlagBatchGasCap,
+		0,
+		"The maximum gas a batch transaction can use. If 0, batches are only limited by the max batch size",
+	)
+}
+
+func GetBatchGasCapFlag(cmd *cobra.Command) (uint64, bool, error) {
+	changed := cmd.Flags().Changed(FlagBatchGasCap)
+	val, err := cmd.Flags().GetUint64(FlagBatchGasCap)
+	if err != nil {
+		return 0, changed, err
+	}
+	return val, changed, nil
+}
+
+func AddMaxInFlightFlag(cmd *cobra.Command) {
+	cmd.Flags().Uint64(
+		FlagMaxInFlight,
+		1,
+		"The maximum number of transactions to keep in flight, i.e. submitted but not yet mined. "+
+			"If higher than 1, the relayer submits the next attestations without waiting for the previous transactions to be mined",
+	)
+}
+
+func GetMaxInFlightFlag(cmd *cobra.Command) (uint64, bool, error) {
+	changed := cmd.Flags().Changed(FlagMaxInFlight)
+	val, err := cmd.Flags().GetUint64(FlagMaxInFlight)
+	if err != nil {
+		return 0, changed, err
+	}
+	return val, changed, nil
+}
+
+func AddLowBalanceThresholdFlag(cmd *cobra.Command) {
+	cmd.Flags().Float64(
+		FlagLowBalanceThreshold,
+		0.1,
+		"The balance, in ether, under which the relayer warns that its account needs to be funded. If 0, the relayer doesn't warn",
+	)
+}
+
+func GetLowBalanceThresholdFlag(cmd *cobra.Command) (float64, bool, error) {
+	changed := cmd.Flags().Changed(FlagLowBalanceThreshold)
+	val, err := cmd.Flags().GetFloat64(FlagLowBalanceThreshold)
+	if err != nil {
+		return 0, changed, err
+	}
+	return val, changed, nil
+}
+
+func AddRelayersFlag(cmd *cobra.Command) {
+	cmd.Flags().String(
+		FlagRelayers,
+		"",
+		"Comma-separated EVM addresses of the relayers coordinating via the P2P network, including this one. "+
+			"If set, the relayer only relays the attestations it's the leader for, and the other ones if their leader "+
+			"didn't relay them within the `relayer.wait-time`",
+	)
+}
+
+func GetRelayersFlag(cmd *cobra.Command) (string, bool, error) {
+	changed := cmd.Flags().Changed(FlagRelayers)
+	val, err := cmd.Flags().GetString(FlagRelayers)
+	if err != nil {
+		return "", changed, err
+	}
+	return val, changed, nil
+}
+
 func AddHomeFlag(cmd *cobra.Command, serviceName string, defaultHomeDir string) {
 	cmd.Flags().String(FlagHome, defaultHomeDir, fmt.Sprintf("The Blobstream %s home directory", serviceName))
 }
@@ -350,6 +508,24 @@ func GetEVMAccAddressFlag(cmd *cobra.Command) (string, bool, error) {
 	return val, changed, nil
 }
 
+func AddEVMRemoteSignerFlag(cmd *cobra.Command) {
+	cmd.Flags().String(
+		FlagEVMRemoteSigner,
+		"",
+		"Specify the JSON-RPC endpoint of a remote signer, e.g. Web3Signer or Clef, to use for signing instead of the keystore "+
+			"(Note: the remote signer should manage the EVM account)",
+	)
+}
+
+func GetEVMRemoteSignerFlag(cmd *cobra.Command) (string, bool, error) {
+	changed := cmd.Flags().Changed(FlagEVMRemoteSigner)
+	val, err := cmd.Flags().GetString(FlagEVMRemoteSigner)
+	if err != nil {
+		return "", changed, err
+	}
+	return val, changed, nil
+}
+
 func ValidateEVMAddress(addr string) error {
 	if addr == "" {
 		return fmt.Errorf("the EVM address cannot be empty")
@@ -465,3 +641,414 @@ func GetP2PMetricsEndpointFlag(cmd *cobra.Command) (string, bool, error) {
 	}
 	return val, changed, nil
 }
+
+func AddAdminFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(
+		FlagAdmin,
+		false,
+		"Enables the local admin API exposing the live processing state",
+	)
+}
+
+func GetAdminFlag(cmd *cobra.Command) (bool, bool, error) {
+	changed := cmd.Flags().Changed(FlagAdmin)
+	val, err := cmd.Flags().GetBool(FlagAdmin)
+	if err != nil {
+		return false, changed, err
+	}
+	return val, changed, nil
+}
+
+func AddAdminListenAddrFlag(cmd *cobra.Command) {
+	cmd.Flags().String(
+		FlagAdminListenAddr,
+		"localhost:26700",
+		"Sets the address for the admin API to listen on. Depends on '--admin'. Should not be exposed publicly",
+	)
+}
+
+func GetAdminListenAddrFlag(cmd *cobra.Command) (string, bool, error) {
+	changed := cmd.Flags().Changed(FlagAdminListenAddr)
+	val, err := cmd.Flags().GetString(FlagAdminListenAddr)
+	if err != nil {
+		return "", changed, err
+	}
+	return val, changed, nil
+}
+
+func AddHealthFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(
+		FlagHealth,
+		false,
+		"Enables the /healthz and /readyz endpoints to be used as liveness and readiness probes",
+	)
+}
+
+func GetHealthFlag(cmd *cobra.Command) (bool, bool, error) {
+	changed := cmd.Flags().Changed(FlagHealth)
+	val, err := cmd.Flags().GetBool(FlagHealth)
+	if err != nil {
+		return false, changed, err
+	}
+	return val, changed, nil
+}
+
+func AddHealthListenAddrFlag(cmd *cobra.Command) {
+	cmd.Flags().String(
+		FlagHealthListenAddr,
+		"localhost:26701",
+		"Sets the address for the health endpoints to listen on. Depends on '--health'",
+	)
+}
+
+func GetHealthListenAddrFlag(cmd *cobra.Command) (string, bool, error) {
+	changed := cmd.Flags().Changed(FlagHealthListenAddr)
+	val, err := cmd.Flags().GetString(FlagHealthListenAddr)
+	if err != nil {
+		return "", changed, err
+	}
+	return val, changed, nil
+}
+
+func AddConfirmsMonitorFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(
+		FlagConfirmsMonitor,
+		false,
+		"Enables periodically verifying that the orchestrator signatures are retrievable from the P2P network peers",
+	)
+}
+
+func GetConfirmsMonitorFlag(cmd *cobra.Command) (bool, bool, error) {
+	changed := cmd.Flags().Changed(FlagConfirmsMonitor)
+	val, err := cmd.Flags().GetBool(FlagConfirmsMonitor)
+	if err != nil {
+		return false, changed, err
+	}
+	return val, changed, nil
+}
+
+func AddConfirmsMonitorIntervalFlag(cmd *cobra.Command) {
+	cmd.Flags().Uint64(
+		FlagConfirmsMonitorInterval,
+		10,
+		"The time, in minutes, between two verifications of the orchestrator signatures. Depends on '--confirms-monitor'",
+	)
+}
+
+func GetConfirmsMonitorIntervalFlag(cmd *cobra.Command) (uint64, bool, error) {
+	changed := cmd.Flags().Changed(FlagConfirmsMonitorInterval)
+	val, err := cmd.Flags().GetUint64(FlagConfirmsMonitorInterval)
+	if err != nil {
+		return 0, changed, err
+	}
+	return val, changed, nil
+}
+
+func AddConfirmsMonitorWindowFlag(cmd *cobra.Command) {
+	cmd.Flags().Uint64(
+		FlagConfirmsMonitorWindow,
+		10,
+		"The number of recent nonces whose signatures are verified. Depends on '--confirms-monitor'",
+	)
+}
+
+func GetConfirmsMonitorWindowFlag(cmd *cobra.Command) (uint64, bool, error) {
+	changed := cmd.Flags().Changed(FlagConfirmsMonitorWindow)
+	val, err := cmd.Flags().GetUint64(FlagConfirmsMonitorWindow)
+	if err != nil {
+		return 0, changed, err
+	}
+	return val, changed, nil
+}
+
+func AddConfirmsMonitorRebroadcastFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(
+		FlagConfirmsMonitorRebroadcast,
+		false,
+		"Broadcasts again the signatures that are not retrievable from the P2P network peers. Depends on '--confirms-monitor'",
+	)
+}
+
+func GetConfirmsMonitorRebroadcastFlag(cmd *cobra.Command) (bool, bool, error) {
+	changed := cmd.Flags().Changed(FlagConfirmsMonitorRebroadcast)
+	val, err := cmd.Flags().GetBool(FlagConfirmsMonitorRebroadcast)
+	if err != nil {
+		return false, changed, err
+	}
+	return val, changed, nil
+}
+
+func AddTracingFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(
+		FlagTracing,
+		false,
+		"Enables OpenTelemetry traces",
+	)
+}
+
+func GetTracingFlag(cmd *cobra.Command) (bool, bool, error) {
+	changed := cmd.Flags().Changed(FlagTracing)
+	val, err := cmd.Flags().GetBool(FlagTracing)
+	if err != nil {
+		return false, changed, err
+	}
+	return val, changed, nil
+}
+
+func AddTracingExporterFlag(cmd *cobra.Command) {
+	cmd.Flags().String(
+		FlagTracingExporter,
+		"otlp",
+		"Sets the traces exporter: 'otlp' to export them to an OTLP collector over HTTP, or 'stdout' to print them. Depends on '--tracing'",
+	)
+}
+
+func GetTracingExporterFlag(cmd *cobra.Command) (string, bool, error) {
+	changed := cmd.Flags().Changed(FlagTracingExporter)
+	val, err := cmd.Flags().GetString(FlagTracingExporter)
+	if err != nil {
+		return "", changed, err
+	}
+	return val, changed, nil
+}
+
+func AddTracingEndpointFlag(cmd *cobra.Command) {
+	cmd.Flags().String(
+		FlagTracingEndpoint,
+		"localhost:4318",
+		"Sets HTTP endpoint for OTLP traces to be exported to. Depends on '--tracing'",
+	)
+}
+
+func GetTracingEndpointFlag(cmd *cobra.Command) (string, bool, error) {
+	changed := cmd.Flags().Changed(FlagTracingEndpoint)
+	val, err := cmd.Flags().GetString(FlagTracingEndpoint)
+	if err != nil {
+		return "", changed, err
+	}
+	return val, changed, nil
+}
+
+func AddTracingTLSFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(
+		FlagTracingTLS,
+		false,
+		"Enable TLS connection to OTLP traces backend",
+	)
+}
+
+func GetTracingTLSFlag(cmd *cobra.Command) (bool, bool, error) {
+	changed := cmd.Flags().Changed(FlagTracingTLS)
+	val, err := cmd.Flags().GetBool(FlagTracingTLS)
+	if err != nil {
+		return false, changed, err
+	}
+	return val, changed, nil
+}
+
+// ParseTracingFlags updates the provided tracing configuration with the changed tracing flags.
+func ParseTracingFlags(cmd *cobra.Command, config *telemetry.TracingConfig) error {
+	tracing, changed, err := GetTracingFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if changed {
+		config.Enable = tracing
+	}
+
+	// config files created before tracing was added don't define the exporter and endpoint.
+	exporter, changed, err := GetTracingExporterFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if changed || config.Exporter == "" {
+		config.Exporter = exporter
+	}
+
+	endpoint, changed, err := GetTracingEndpointFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if changed || config.Endpoint == "" {
+		config.Endpoint = endpoint
+	}
+
+	tls, changed, err := GetTracingTLSFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if changed {
+		config.TLS = tls
+	}
+	return nil
+}
+
+func AddRetentionNoncesFlag(cmd *cobra.Command) {
+	cmd.Flags().Uint64(
+		FlagRetentionNonces,
+		0,
+		"The number of latest attestation nonces whose confirms are kept in the stores. If 0, the confirms are not pruned based on the number of nonces",
+	)
+}
+
+func GetRetentionNoncesFlag(cmd *cobra.Command) (uint64, bool, error) {
+	changed := cmd.Flags().Changed(FlagRetentionNonces)
+	val, err := cmd.Flags().GetUint64(FlagRetentionNonces)
+	if err != nil {
+		return 0, changed, err
+	}
+	return val, changed, nil
+}
+
+func AddRetentionDaysFlag(cmd *cobra.Command) {
+	cmd.Flags().Uint64(
+		FlagRetentionDays,
+		0,
+		"The number of days the confirms of an attestation are kept in the stores. If 0, the confirms are not pruned based on their age",
+	)
+}
+
+func GetRetentionDaysFlag(cmd *cobra.Command) (uint64, bool, error) {
+	changed := cmd.Flags().Changed(FlagRetentionDays)
+	val, err := cmd.Flags().GetUint64(FlagRetentionDays)
+	if err != nil {
+		return 0, changed, err
+	}
+	return val, changed, nil
+}
+
+func AddRetentionIntervalFlag(cmd *cobra.Command) {
+	cmd.Flags().Uint64(
+		FlagRetentionInterval,
+		60,
+		"The time, in minutes, between two prunings of the stores",
+	)
+}
+
+func GetRetentionIntervalFlag(cmd *cobra.Command) (uint64, bool, error) {
+	changed := cmd.Flags().Changed(FlagRetentionInterval)
+	val, err := cmd.Flags().GetUint64(FlagRetentionInterval)
+	if err != nil {
+		return 0, changed, err
+	}
+	return val, changed, nil
+}
+
+// ParseRetentionFlags updates the provided retention configuration with the changed retention flags.
+// The interval flag is only parsed if it was added to the command.
+func ParseRetentionFlags(cmd *cobra.Command, config *pruner.RetentionConfig) error {
+	nonces, changed, err := GetRetentionNoncesFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if changed {
+		config.Nonces = nonces
+	}
+
+	days, changed, err := GetRetentionDaysFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if changed {
+		config.Days = days
+	}
+
+	if cmd.Flags().Lookup(FlagRetentionInterval) == nil {
+		return nil
+	}
+	// config files created before the retention policy was added don't define the interval.
+	interval, changed, err := GetRetentionIntervalFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if changed || config.Interval == 0 {
+		config.Interval = interval
+	}
+	if config.Interval == 0 {
+		return fmt.Errorf("retention interval cannot be 0: flag --%s", FlagRetentionInterval)
+	}
+	return nil
+}
+
+func AddP2PGatingFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(
+		FlagP2PGating,
+		false,
+		"Only accepts the connections of the allowlisted peers, and of the peers proving that their P2P key is bound to an EVM address of the current or previous valset",
+	)
+}
+
+func GetP2PGatingFlag(cmd *cobra.Command) (bool, bool, error) {
+	changed := cmd.Flags().Changed(FlagP2PGating)
+	val, err := cmd.Flags().GetBool(FlagP2PGating)
+	if err != nil {
+		return false, changed, err
+	}
+	return val, changed, nil
+}
+
+func AddP2PAllowlistFlag(cmd *cobra.Command) {
+	cmd.Flags().String(
+		FlagP2PAllowlist,
+		"",
+		"Comma-separated IDs, or multiaddresses, of the peers that are always accepted when the gating is enabled, e.g. the relayers. The bootstrappers are always accepted. Depends on '--p2p.gating'",
+	)
+}
+
+func GetP2PAllowlistFlag(cmd *cobra.Command) (string, bool, error) {
+	changed := cmd.Flags().Changed(FlagP2PAllowlist)
+	val, err := cmd.Flags().GetString(FlagP2PAllowlist)
+	if err != nil {
+		return "", changed, err
+	}
+	return val, changed, nil
+}
+
+// ParseGatingFlags parses the P2P gating flags, overriding the values of the provided config if set.
+func ParseGatingFlags(cmd *cobra.Command, config *p2p.GatingConfig) error {
+	gating, changed, err := GetP2PGatingFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if changed {
+		config.Enable = gating
+	}
+
+	allowlist, changed, err := GetP2PAllowlistFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if changed {
+		config.Allowlist = allowlist
+	}
+	return nil
+}
+
+func AddP2PPeerScoringFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(
+		FlagP2PPeerScoring,
+		false,
+		"Scores the P2P peers depending on the validity of the DHT records they return, and bans the ones returning too many invalid records",
+	)
+}
+
+func GetP2PPeerScoringFlag(cmd *cobra.Command) (bool, bool, error) {
+	changed := cmd.Flags().Changed(FlagP2PPeerScoring)
+	val, err := cmd.Flags().GetBool(FlagP2PPeerScoring)
+	if err != nil {
+		return false, changed, err
+	}
+	return val, changed, nil
+}
+
+// ParseScoringFlags parses the P2P peer scoring flags, overriding the values of the provided config if set.
+func ParseScoringFlags(cmd *cobra.Command, config *p2p.ScoringConfig) error {
+	scoring, changed, err := GetP2PPeerScoringFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if changed {
+		config.Enable = scoring
+	}
+	return nil
+}
@@ -5,18 +5,28 @@ import (
 	"strings"
 	"time"
 
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
 	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/base"
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/common"
 
 	p2pcmd "github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/keys/p2p"
 	"github.com/celestiaorg/orchestrator-relayer/helpers"
 	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/pruner"
+	"github.com/celestiaorg/orchestrator-relayer/rpc"
 	"github.com/celestiaorg/orchestrator-relayer/store"
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
 	ds "github.com/ipfs/go-datastore"
 	dssync "github.com/ipfs/go-datastore/sync"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/spf13/cobra"
+	tmlog "github.com/tendermint/tendermint/libs/log"
 )
 
+// republishPeersTimeout the maximum time to wait for peers before republishing the held records.
+const republishPeersTimeout = 10 * time.Minute
+
 func Command() *cobra.Command {
 	bsCmd := &cobra.Command{
 		Use:          "bootstrapper",
@@ -57,23 +67,20 @@ func Start() *cobra.Command {
 			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
-			// checking if the provided home is already initiated
-			isInit := store.IsInit(logger, config.home, store.InitOptions{
-				NeedDataStore:   false,
-				NeedEVMKeyStore: false,
-				NeedP2PKeyStore: true,
-			})
-			if !isInit {
-				return store.ErrNotInited
-			}
-
-			// creating the data store
-			openOptions := store.OpenOptions{
-				HasDataStore:   false,
+			// opening the store. The data store is only opened if the DHT records need to be persisted.
+			s, storeStops, err := common.OpenStore(logger, config.home, store.OpenOptions{
+				HasDataStore:   config.persistentStore,
+				BadgerOptions:  store.DefaultBadgerOptions(config.home),
 				HasEVMKeyStore: false,
 				HasP2PKeyStore: true,
-			}
-			s, err := store.OpenStore(logger, config.home, openOptions)
+			})
+			defer func() {
+				for _, f := range storeStops {
+					if err := f(); err != nil {
+						logger.Error(err.Error())
+					}
+				}
+			}()
 			if err != nil {
 				return err
 			}
@@ -84,11 +91,40 @@ func Start() *cobra.Command {
 				return err
 			}
 
+			// celestia-app is only queried for the valsets when the gating is enabled, and for
+			// the attestations when the retention policy is enabled.
+			var appQuerier *rpc.AppQuerier
+			if config.gatingConfig.Enable || config.retentionConfig.Enabled() {
+				appQuerier = rpc.NewAppQuerier(logger, config.coreGRPC, encoding.MakeConfig(app.ModuleEncodingRegisters...))
+				err = appQuerier.Start(config.grpcInsecure)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					if err := appQuerier.Stop(); err != nil {
+						logger.Error(err.Error())
+					}
+				}()
+			}
+
+			// creating the connection gater. The valsets are queried from celestia-app to know which
+			// orchestrators to accept.
+			var gater *p2p.Gater
+			if config.gatingConfig.Enable {
+				gater, err = common.NewGater(ctx, logger, config.gatingConfig, config.bootstrappers, appQuerier)
+				if err != nil {
+					return err
+				}
+			}
+
 			// creating the host
-			h, err := p2p.CreateHost(config.p2pListenAddr, privKey, nil)
+			h, err := p2p.CreateHost(config.p2pListenAddr, privKey, nil, gater, nil)
 			if err != nil {
 				return err
 			}
+			if gater != nil {
+				common.StartHandshake(ctx, logger, h, gater, nil)
+			}
 			logger.Info(
 				"created host",
 				"ID",
@@ -98,7 +134,12 @@ func Start() *cobra.Command {
 			)
 
 			// creating the data store
-			dataStore := dssync.MutexWrap(ds.NewMapDatastore())
+			var dataStore ds.Batching
+			if config.persistentStore {
+				dataStore = s.DataStore
+			} else {
+				dataStore = dssync.MutexWrap(ds.NewMapDatastore())
+			}
 
 			// get the bootstrappers
 			var aIBootstrappers []peer.AddrInfo
@@ -113,16 +154,67 @@ func Start() *cobra.Command {
 			}
 
 			// creating the dht
-			dht, err := p2p.NewBlobstreamDHT(ctx, h, dataStore, aIBootstrappers, logger)
+			dht, err := p2p.NewBlobstreamDHT(ctx, h, dataStore, aIBootstrappers, gater, logger)
+			if err != nil {
+				return err
+			}
+			// the DHT is closed before the store so that it doesn't write to a closed data store.
+			defer func() {
+				if err := dht.Close(); err != nil {
+					logger.Error(err.Error())
+				}
+			}()
+
+			healthServer, healthStops, err := common.StartHealthServer(logger, config.healthConfig)
 			if err != nil {
 				return err
 			}
+			defer func() {
+				for _, f := range healthStops {
+					if err := f(); err != nil {
+						logger.Error(err.Error())
+					}
+				}
+			}()
+			// a bootstrapper that is not connected to other bootstrappers can run without peers.
+			if len(aIBootstrappers) != 0 {
+				common.AddDHTHealthCheck(healthServer, dht, common.DHTPeersThreshold)
+			}
 
 			// Listen for and trap any OS signal to graceful shutdown and exit
 			go helpers.TrapSignal(logger, cancel)
 
 			logger.Info("starting bootstrapper")
 
+			if config.persistentStore {
+				var storePruner *pruner.Pruner
+				if config.retentionConfig.Enabled() {
+					storeMeters, err := telemetry.InitStoreMeters()
+					if err != nil {
+						return err
+					}
+					// pruning the confirms outside the retention window so that they're not republished,
+					// and the data store doesn't fill the disk.
+					storePruner = pruner.New(
+						logger,
+						appQuerier,
+						config.retentionConfig,
+						storeMeters,
+						pruner.Store{Name: pruner.DataStoreName, Datastore: dataStore},
+					)
+				}
+				go func() {
+					if storePruner != nil {
+						err := storePruner.Prune(ctx)
+						if err != nil && ctx.Err() == nil {
+							logger.Error("couldn't prune the held records before republishing them", "err", err.Error())
+						}
+						go storePruner.Start(ctx)
+					}
+					republishRecords(ctx, logger, dht)
+				}()
+			}
+
 			ticker := time.NewTicker(time.Minute)
 			for {
 				select {
@@ -137,6 +229,26 @@ func Start() *cobra.Command {
 	return addStartFlags(cmd)
 }
 
+// republishRecords waits for the bootstrapper to be connected to peers, then republishes the
+// records held in its persistent data store, so that they are replicated to the peers closest to them.
+func republishRecords(ctx context.Context, logger tmlog.Logger, dht *p2p.BlobstreamDHT) {
+	err := dht.WaitForPeers(ctx, republishPeersTimeout, 10*time.Second, 1)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Error("couldn't republish the held records", "err", err.Error())
+		}
+		return
+	}
+	count, err := dht.RepublishRecords(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Error("couldn't republish the held records", "err", err.Error())
+		}
+		return
+	}
+	logger.Info("republished the held records", "count", count)
+}
+
 func Init() *cobra.Command {
 	cmd := cobra.Command{
 		Use:   "init",
@@ -152,8 +264,10 @@ func Init() *cobra.Command {
 				return err
 			}
 
+			// the data store is always initialized so that the DHT records can be persisted
+			// later without initializing the store again.
 			initOptions := store.InitOptions{
-				NeedDataStore:   false,
+				NeedDataStore:   true,
 				NeedEVMKeyStore: false,
 				NeedP2PKeyStore: true,
 			}
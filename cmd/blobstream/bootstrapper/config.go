@@ -1,12 +1,20 @@
 package bootstrapper
 
 import (
+	"fmt"
+	"net"
+
 	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/base"
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/pruner"
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
 	"github.com/spf13/cobra"
 )
 
 const (
 	ServiceNameBootstrapper = "bootstrapper"
+
+	FlagPersistentStore = "p2p.persistent-store"
 )
 
 func addStartFlags(cmd *cobra.Command) *cobra.Command {
@@ -20,6 +28,24 @@ func addStartFlags(cmd *cobra.Command) *cobra.Command {
 	base.AddBootstrappersFlag(cmd)
 	base.AddLogLevelFlag(cmd)
 	base.AddLogFormatFlag(cmd)
+	base.AddHealthFlag(cmd)
+	base.AddHealthListenAddrFlag(cmd)
+	cmd.Flags().Bool(
+		FlagPersistentStore,
+		false,
+		"If enabled, the DHT records are saved to the data store under the home directory, instead of being kept in memory. "+
+			"The held confirms and latest valset are then kept across restarts, and republished to the network on startup",
+	)
+	base.AddP2PGatingFlag(cmd)
+	base.AddP2PAllowlistFlag(cmd)
+	// the retention policy only applies to the persistent store.
+	base.AddRetentionNoncesFlag(cmd)
+	base.AddRetentionDaysFlag(cmd)
+	base.AddRetentionIntervalFlag(cmd)
+	// the celestia-app gRPC endpoint is only used to query the valsets when the gating is enabled,
+	// and the attestations when the retention policy is enabled.
+	base.AddCoreGRPCFlag(cmd)
+	base.AddGRPCInsecureFlag(cmd)
 	return cmd
 }
 
@@ -29,6 +55,12 @@ type StartConfig struct {
 	bootstrappers              string
 	logLevel                   string
 	logFormat                  string
+	healthConfig               telemetry.HealthConfig
+	persistentStore            bool
+	retentionConfig            pruner.RetentionConfig
+	gatingConfig               p2p.GatingConfig
+	coreGRPC                   string
+	grpcInsecure               bool
 }
 
 func parseStartFlags(cmd *cobra.Command) (StartConfig, error) {
@@ -66,6 +98,51 @@ func parseStartFlags(cmd *cobra.Command) (StartConfig, error) {
 		return StartConfig{}, err
 	}
 
+	health, _, err := base.GetHealthFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	healthListenAddr, _, err := base.GetHealthListenAddrFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if health {
+		if _, _, err := net.SplitHostPort(healthListenAddr); err != nil {
+			return StartConfig{}, fmt.Errorf("%s: flag --%s", err.Error(), base.FlagHealthListenAddr)
+		}
+	}
+
+	persistentStore, err := cmd.Flags().GetBool(FlagPersistentStore)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	var retentionConfig pruner.RetentionConfig
+	err = base.ParseRetentionFlags(cmd, &retentionConfig)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if retentionConfig.Enabled() && !persistentStore {
+		return StartConfig{}, fmt.Errorf("the retention policy requires the --%s flag", FlagPersistentStore)
+	}
+
+	var gatingConfig p2p.GatingConfig
+	err = base.ParseGatingFlags(cmd, &gatingConfig)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	coreGRPC, _, err := base.GetCoreGRPCFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	grpcInsecure, _, err := base.GetGRPCInsecureFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
 	return StartConfig{
 		p2pNickname:   p2pNickname,
 		p2pListenAddr: p2pListenAddress,
@@ -73,6 +150,15 @@ func parseStartFlags(cmd *cobra.Command) (StartConfig, error) {
 		bootstrappers: bootstrappers,
 		logFormat:     logFormat,
 		logLevel:      logLevel,
+		healthConfig: telemetry.HealthConfig{
+			Enable:     health,
+			ListenAddr: healthListenAddr,
+		},
+		persistentStore: persistentStore,
+		retentionConfig: retentionConfig,
+		gatingConfig:    gatingConfig,
+		coreGRPC:        coreGRPC,
+		grpcInsecure:    grpcInsecure,
 	}, nil
 }
 
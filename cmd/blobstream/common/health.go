@@ -0,0 +1,141 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/orchestrator"
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/relayer"
+	"github.com/celestiaorg/orchestrator-relayer/rpc"
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
+	"github.com/ethereum/go-ethereum/ethclient"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+// DHTPeersThreshold the minimum number of peers the DHT routing table should contain
+// for the orchestrator and relayer to start, and be considered ready.
+const DHTPeersThreshold = 1
+
+// The names of the health checks reported by the health endpoints.
+const (
+	HealthCheckCoreRPC    = "core-rpc"
+	HealthCheckCoreGRPC   = "core-grpc"
+	HealthCheckCatchingUp = "catching-up"
+	HealthCheckDHT        = "dht"
+	HealthCheckEVMRPC     = "evm-rpc"
+	HealthCheckEvents     = "events-listener"
+	HealthCheckRelayLoop  = "relay-loop"
+)
+
+// StartHealthServer helper function that starts the health server if enabled in the provided config, and
+// registers its stop function in the returned stopFuncs slice.
+// Returns a nil server if the health endpoints are disabled.
+func StartHealthServer(logger tmlog.Logger, config telemetry.HealthConfig) (*telemetry.HealthServer, []func() error, error) {
+	stopFuncs := make([]func() error, 0)
+	if !config.Enable {
+		return nil, stopFuncs, nil
+	}
+	healthServer := telemetry.NewHealthServer(logger, config.ListenAddr)
+	err := healthServer.Start()
+	if err != nil {
+		return nil, stopFuncs, err
+	}
+	stopFuncs = append(stopFuncs, healthServer.Stop)
+	return healthServer, stopFuncs, nil
+}
+
+// AddCoreHealthChecks helper function that adds the core RPC and gRPC, and the catching up, readiness checks
+// to the provided health server. These are not liveness checks, as restarting the orchestrator or relayer
+// doesn't help when the core endpoints are unreachable.
+// Does nothing if the health server is nil.
+func AddCoreHealthChecks(healthServer *telemetry.HealthServer, tmQuerier *rpc.TmQuerier, appQuerier *rpc.AppQuerier) {
+	if healthServer == nil {
+		return
+	}
+	healthServer.AddReadinessCheck(HealthCheckCoreRPC, func(ctx context.Context) error {
+		_, err := tmQuerier.QueryHeight(ctx)
+		return err
+	})
+	healthServer.AddReadinessCheck(HealthCheckCoreGRPC, func(ctx context.Context) error {
+		_, err := appQuerier.QueryLatestAttestationNonce(ctx)
+		return err
+	})
+	healthServer.AddReadinessCheck(HealthCheckCatchingUp, func(ctx context.Context) error {
+		catchingUp, err := tmQuerier.IsCatchingUp(ctx)
+		if err != nil {
+			return err
+		}
+		if catchingUp {
+			return rpc.ErrNodeCatchingUp
+		}
+		return nil
+	})
+}
+
+// AddEventsListenerHealthCheck helper function that adds a liveness check to the provided health server
+// verifying that the orchestrator events listener recorded a heartbeat recently.
+// Does nothing if the health server is nil.
+func AddEventsListenerHealthCheck(healthServer *telemetry.HealthServer, state *orchestrator.State) {
+	if healthServer == nil {
+		return
+	}
+	healthServer.AddLivenessCheck(HealthCheckEvents, func(_ context.Context) error {
+		return state.CheckEventsListener(time.Now())
+	})
+}
+
+// AddRelayerHealthCheck helper function that adds a liveness check to the provided health server
+// verifying that the relayer loop recorded a heartbeat recently. The name suffix allows distinguishing
+// the checks of multiple EVM chains.
+// Does nothing if the health server is nil.
+func AddRelayerHealthCheck(healthServer *telemetry.HealthServer, r *relayer.Relayer, nameSuffix string) {
+	if healthServer == nil {
+		return
+	}
+	name := HealthCheckRelayLoop
+	if nameSuffix != "" {
+		name = fmt.Sprintf("%s-%s", HealthCheckRelayLoop, nameSuffix)
+	}
+	healthServer.AddLivenessCheck(name, func(_ context.Context) error {
+		return r.CheckHeartbeat(time.Now())
+	})
+}
+
+// AddDHTHealthCheck helper function that adds a readiness check to the provided health server verifying that
+// the DHT routing table contains at least the specified number of peers. If the DHT is nil, i.e. not created yet,
+// the check fails until it's replaced by a subsequent call.
+// Does nothing if the health server is nil.
+func AddDHTHealthCheck(healthServer *telemetry.HealthServer, dht *p2p.BlobstreamDHT, peersThreshold int) {
+	if healthServer == nil {
+		return
+	}
+	healthServer.AddReadinessCheck(HealthCheckDHT, func(ctx context.Context) error {
+		if dht == nil {
+			return fmt.Errorf("%w: DHT not started", p2p.ErrNotEnoughPeers)
+		}
+		peersLen := len(dht.RoutingTable().ListPeers())
+		if peersLen < peersThreshold {
+			return fmt.Errorf("%w: %d peers, expected at least %d", p2p.ErrNotEnoughPeers, peersLen, peersThreshold)
+		}
+		return nil
+	})
+}
+
+// AddEVMHealthCheck helper function that adds a readiness check to the provided health server verifying that the
+// EVM RPC is reachable. The name suffix allows distinguishing the checks of multiple EVM chains.
+// Does nothing if the health server is nil.
+func AddEVMHealthCheck(healthServer *telemetry.HealthServer, ethClient *ethclient.Client, nameSuffix string) {
+	if healthServer == nil {
+		return
+	}
+	name := HealthCheckEVMRPC
+	if nameSuffix != "" {
+		name = fmt.Sprintf("%s-%s", HealthCheckEVMRPC, nameSuffix)
+	}
+	healthServer.AddReadinessCheck(name, func(ctx context.Context) error {
+		_, err := ethClient.BlockNumber(ctx)
+		return err
+	})
+}
@@ -14,10 +14,15 @@ import (
 
 	"github.com/celestiaorg/celestia-app/app"
 	"github.com/celestiaorg/celestia-app/app/encoding"
+	evmkeys "github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/keys/evm"
 	common2 "github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/keys/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/evm"
 	"github.com/celestiaorg/orchestrator-relayer/helpers"
 	"github.com/celestiaorg/orchestrator-relayer/p2p"
 	"github.com/celestiaorg/orchestrator-relayer/rpc"
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcmn "github.com/ethereum/go-ethereum/common"
 	keystore2 "github.com/ipfs/boxo/keystore"
 	ds "github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -63,6 +68,10 @@ func NewTmAndAppQuerier(logger tmlog.Logger, tendermintRPC string, celesGRPC str
 }
 
 // CreateDHTAndWaitForPeers helper function that creates a new Blobstream DHT and waits for some peers to connect to it.
+// If the gater is not nil, the host connections are gated and the handshake is started, proving the binding
+// of the host to the signer EVM address if the signer is not nil.
+// If the scorer is not nil, the peers are scored depending on the validity of the records they return,
+// and the ones returning too many invalid records are banned.
 func CreateDHTAndWaitForPeers(
 	ctx context.Context,
 	logger tmlog.Logger,
@@ -72,6 +81,9 @@ func CreateDHTAndWaitForPeers(
 	bootstrappers string,
 	dataStore ds.Batching,
 	registerer prometheus.Registerer,
+	gater *p2p.Gater,
+	scorer *p2p.PeerScorer,
+	signer evm.Signer,
 ) (*p2p.BlobstreamDHT, error) {
 	// get the p2p private key or generate a new one
 	privKey, err := common2.GetP2PKeyOrGenerateNewOne(p2pKeyStore, p2pNickname)
@@ -80,12 +92,19 @@ func CreateDHTAndWaitForPeers(
 	}
 
 	// creating the host
-	h, err := p2p.CreateHost(p2pListenAddr, privKey, registerer)
+	h, err := p2p.CreateHost(p2pListenAddr, privKey, registerer, gater, scorer)
 	if err != nil {
 		return nil, err
 	}
 	logger.Info("created P2P host")
 
+	if gater != nil {
+		StartHandshake(ctx, logger, h, gater, signer)
+	}
+	if scorer != nil {
+		go scorer.Start(ctx, h)
+	}
+
 	prettyPrintHost(h)
 
 	// get the bootstrappers
@@ -101,13 +120,14 @@ func CreateDHTAndWaitForPeers(
 	}
 
 	// creating the dht
-	dht, err := p2p.NewBlobstreamDHT(ctx, h, dataStore, aIBootstrappers, logger)
+	dht, err := p2p.NewBlobstreamDHT(ctx, h, dataStore, aIBootstrappers, gater, logger)
 	if err != nil {
 		return nil, err
 	}
+	dht.Scorer = scorer
 
 	// wait for the dht to have some peers
-	err = dht.WaitForPeers(ctx, 5*time.Minute, 10*time.Second, 1)
+	err = dht.WaitForPeers(ctx, 5*time.Minute, 10*time.Second, DHTPeersThreshold)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +158,65 @@ func OpenStore(logger tmlog.Logger, home string, openOptions store.OpenOptions)
 	return s, stopFuncs, nil
 }
 
+// gaterValsetUpdateInterval the time between two updates of the valset members accepted by the gater.
+const gaterValsetUpdateInterval = 5 * time.Minute
+
+// NewGater helper function that creates the P2P connection gater if the gating is enabled.
+// The bootstrappers are added to the allowlist. The valset members are queried before returning,
+// then updated periodically until the context is canceled.
+// Returns nil if the gating is disabled.
+func NewGater(
+	ctx context.Context,
+	logger tmlog.Logger,
+	config p2p.GatingConfig,
+	bootstrappers string,
+	appQuerier *rpc.AppQuerier,
+) (*p2p.Gater, error) {
+	if !config.Enable {
+		return nil, nil
+	}
+	allowlist, err := p2p.ParseAllowlist(config.Allowlist + "," + bootstrappers)
+	if err != nil {
+		return nil, err
+	}
+	gater := p2p.NewGater(logger, allowlist, p2p.DefaultHandshakeGracePeriod)
+	err = gater.UpdateValsetMembers(ctx, appQuerier)
+	if err != nil {
+		return nil, err
+	}
+	go gater.StartValsetUpdates(ctx, appQuerier, gaterValsetUpdateInterval)
+	logger.Info("enabled P2P connection gating", "allowlisted_peers", len(allowlist))
+	return gater, nil
+}
+
+// NewPeerScorer helper function that creates the P2P peer scorer if the peer scoring is enabled.
+// Returns nil if the peer scoring is disabled.
+func NewPeerScorer(logger tmlog.Logger, config p2p.ScoringConfig) (*p2p.PeerScorer, error) {
+	if !config.Enable {
+		return nil, nil
+	}
+	scorer := p2p.NewPeerScorer(logger, p2p.DefaultBanThreshold, p2p.DefaultBanDuration)
+	peerMeters, err := telemetry.InitPeerMeters()
+	if err != nil {
+		return nil, err
+	}
+	scorer.Meters = peerMeters
+	logger.Info("enabled P2P peer scoring")
+	return scorer, nil
+}
+
+// StartHandshake helper function that starts the P2P handshake in the background, proving the binding
+// of the host to the signer EVM address if the signer is not nil.
+func StartHandshake(ctx context.Context, logger tmlog.Logger, h host.Host, gater *p2p.Gater, signer evm.Signer) {
+	hs := p2p.NewHandshake(h, gater, signer, logger)
+	go func() {
+		err := hs.Start(ctx)
+		if err != nil {
+			logger.Error("couldn't start the P2P handshake", "err", err.Error())
+		}
+	}()
+}
+
 func prettyPrintHost(h host.Host) {
 	fmt.Printf("ID: %s\n", h.ID().String())
 	fmt.Println("Listen addresses:")
@@ -145,3 +224,38 @@ func prettyPrintHost(h host.Host) {
 		fmt.Printf("\t%s\n", addr.String())
 	}
 }
+
+// NewEVMSigner helper function that creates the signer to use for the provided EVM account.
+// If a remote signer endpoint is provided, the signing is delegated to it. Otherwise, the account
+// is loaded from the keystore and unlocked.
+// The returned stop functions should be called when the signer is not needed anymore.
+func NewEVMSigner(
+	ctx context.Context,
+	logger tmlog.Logger,
+	ks *keystore.KeyStore,
+	evmAccAddress string,
+	evmPassphrase string,
+	remoteSigner string,
+) (evm.Signer, []func() error, error) {
+	stopFuncs := make([]func() error, 0)
+	if remoteSigner != "" {
+		logger.Info("connecting to remote signer", "address", evmAccAddress, "endpoint", remoteSigner)
+		signer, err := evm.NewRemoteSigner(ctx, remoteSigner, ethcmn.HexToAddress(evmAccAddress))
+		if err != nil {
+			return nil, stopFuncs, err
+		}
+		stopFuncs = append(stopFuncs, func() error {
+			signer.Close()
+			return nil
+		})
+		return signer, stopFuncs, nil
+	}
+
+	logger.Info("loading EVM account", "address", evmAccAddress)
+	acc, err := evmkeys.GetAccountFromStoreAndUnlockIt(ks, evmAccAddress, evmPassphrase)
+	if err != nil {
+		return nil, stopFuncs, err
+	}
+	stopFuncs = append(stopFuncs, func() error { return ks.Lock(acc.Address) })
+	return evm.NewKeystoreSigner(ks, acc), stopFuncs, nil
+}
@@ -7,11 +7,9 @@ import (
 
 	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/base"
 
-	evm2 "github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/keys/evm"
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/common"
 
 	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/keys"
-	"github.com/ethereum/go-ethereum/accounts/keystore"
-	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/celestiaorg/celestia-app/app"
 	"github.com/celestiaorg/celestia-app/app/encoding"
@@ -99,27 +97,33 @@ func Command() *cobra.Command {
 				}
 			}(s, logger)
 
-			logger.Info("loading EVM account", "address", config.evmAccAddress)
-
-			acc, err := evm2.GetAccountFromStoreAndUnlockIt(s.EVMKeyStore, config.evmAccAddress, config.EVMPassphrase)
+			signer, signerStops, err := common.NewEVMSigner(
+				cmd.Context(),
+				logger,
+				s.EVMKeyStore,
+				config.evmAccAddress,
+				config.EVMPassphrase,
+				config.evmRemoteSigner,
+			)
+			defer func() {
+				for _, f := range signerStops {
+					err := f()
+					if err != nil {
+						logger.Error(err.Error())
+					}
+				}
+			}()
 			if err != nil {
 				return err
 			}
 
-			defer func(EVMKeyStore *keystore.KeyStore, addr common.Address) {
-				err := EVMKeyStore.Lock(addr)
-				if err != nil {
-					panic(err)
-				}
-			}(s.EVMKeyStore, acc.Address)
-
 			evmClient := evm.NewClient(
 				logger,
 				nil,
-				s.EVMKeyStore,
-				&acc,
+				signer,
 				config.evmRPC,
 				config.evmGasLimit,
+				evm.DefaultGasPolicy(),
 			)
 
 			txOpts, err := evmClient.NewTransactionOpts(cmd.Context())
@@ -147,7 +151,7 @@ func Command() *cobra.Command {
 			return nil
 		},
 	}
-	command.AddCommand(keys.Command(ServiceNameDeployer))
+	command.AddCommand(keys.Command(ServiceNameDeployer), MulticallCommand())
 	return addDeployFlags(command)
 }
 
@@ -16,6 +16,7 @@ const (
 
 func addDeployFlags(cmd *cobra.Command) *cobra.Command {
 	base.AddEVMAccAddressFlag(cmd)
+	base.AddEVMRemoteSignerFlag(cmd)
 	base.AddEVMChainIDFlag(cmd)
 	base.AddCoreGRPCFlag(cmd)
 	base.AddCoreRPCFlag(cmd)
@@ -40,6 +41,7 @@ type deployConfig struct {
 	coreRPC, coreGRPC string
 	evmChainID        uint64
 	evmAccAddress     string
+	evmRemoteSigner   string
 	startingNonce     string
 	evmGasLimit       uint64
 	grpcInsecure      bool
@@ -56,6 +58,11 @@ func parseDeployFlags(cmd *cobra.Command) (deployConfig, error) {
 		return deployConfig{}, errors.New("the evm account address should be specified")
 	}
 
+	remoteSigner, _, err := base.GetEVMRemoteSignerFlag(cmd)
+	if err != nil {
+		return deployConfig{}, err
+	}
+
 	evmChainID, _, err := base.GetEVMChainIDFlag(cmd)
 	if err != nil {
 		return deployConfig{}, err
@@ -119,15 +126,16 @@ func parseDeployFlags(cmd *cobra.Command) (deployConfig, error) {
 			Home:          homeDir,
 			EVMPassphrase: passphrase,
 		},
-		evmRPC:        evmRPC,
-		coreRPC:       coreRPC,
-		coreGRPC:      coreGRPC,
-		evmChainID:    evmChainID,
-		evmAccAddress: evmAccAddr,
-		startingNonce: startingNonce,
-		evmGasLimit:   evmGasLimit,
-		grpcInsecure:  grpcInsecure,
-		logFormat:     logFormat,
-		logLevel:      logLevel,
+		evmRPC:          evmRPC,
+		coreRPC:         coreRPC,
+		coreGRPC:        coreGRPC,
+		evmChainID:      evmChainID,
+		evmAccAddress:   evmAccAddr,
+		evmRemoteSigner: remoteSigner,
+		startingNonce:   startingNonce,
+		evmGasLimit:     evmGasLimit,
+		grpcInsecure:    grpcInsecure,
+		logFormat:       logFormat,
+		logLevel:        logLevel,
 	}, nil
 }
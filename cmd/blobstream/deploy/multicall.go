@@ -0,0 +1,189 @@
+package deploy
+
+import (
+	"errors"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/base"
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/common"
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/celestiaorg/orchestrator-relayer/store"
+	"github.com/spf13/cobra"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+// MulticallCommand deploys the multicall contract used by the relayer to batch attestations.
+func MulticallCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "multicall <flags>",
+		Short: "Deploys the multicall contract used by the relayer to relay multiple attestations in a single transaction",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := parseDeployMulticallFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			logger, err := base.GetLogger(config.logLevel, config.logFormat)
+			if err != nil {
+				return err
+			}
+
+			// checking if the provided home is already initiated
+			isInit := store.IsInit(logger, config.Home, store.InitOptions{NeedEVMKeyStore: true})
+			if !isInit {
+				logger.Info("please initialize the EVM keystore using the `blobstream deploy keys add/import` command")
+				return store.ErrNotInited
+			}
+
+			openOptions := store.OpenOptions{HasEVMKeyStore: true}
+			s, err := store.OpenStore(logger, config.Home, openOptions)
+			if err != nil {
+				return err
+			}
+			defer func(s *store.Store, log tmlog.Logger) {
+				err := s.Close(log, openOptions)
+				if err != nil {
+					logger.Error(err.Error())
+				}
+			}(s, logger)
+
+			signer, signerStops, err := common.NewEVMSigner(
+				cmd.Context(),
+				logger,
+				s.EVMKeyStore,
+				config.evmAccAddress,
+				config.EVMPassphrase,
+				config.evmRemoteSigner,
+			)
+			defer func() {
+				for _, f := range signerStops {
+					err := f()
+					if err != nil {
+						logger.Error(err.Error())
+					}
+				}
+			}()
+			if err != nil {
+				return err
+			}
+
+			evmClient := evm.NewClient(
+				logger,
+				nil,
+				signer,
+				config.evmRPC,
+				config.evmGasLimit,
+				evm.DefaultGasPolicy(),
+			)
+
+			txOpts, err := evmClient.NewTransactionOpts(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			backend, err := evmClient.NewEthClient()
+			if err != nil {
+				return err
+			}
+			defer backend.Close()
+
+			address, tx, err := evmClient.DeployMulticall(txOpts, backend)
+			if err != nil {
+				logger.Error("failed to deploy multicall contract")
+				return err
+			}
+
+			receipt, err := evmClient.WaitForTransaction(cmd.Context(), backend, tx, 5*time.Minute)
+			if err == nil && receipt != nil && receipt.Status == 1 {
+				logger.Info("deployed multicall contract", "address", address.Hex(), "tx_hash", tx.Hash().String())
+			}
+
+			return nil
+		},
+	}
+	return addDeployMulticallFlags(command)
+}
+
+func addDeployMulticallFlags(cmd *cobra.Command) *cobra.Command {
+	base.AddEVMAccAddressFlag(cmd)
+	base.AddEVMRemoteSignerFlag(cmd)
+	base.AddEVMRPCFlag(cmd)
+	base.AddEVMGasLimitFlag(cmd)
+	base.AddEVMPassphraseFlag(cmd)
+	homeDir, err := base.DefaultServicePath(ServiceNameDeployer)
+	if err != nil {
+		panic(err)
+	}
+	base.AddHomeFlag(cmd, ServiceNameDeployer, homeDir)
+	base.AddLogLevelFlag(cmd)
+	base.AddLogFormatFlag(cmd)
+	return cmd
+}
+
+type deployMulticallConfig struct {
+	base.Config
+	evmRPC          string
+	evmAccAddress   string
+	evmRemoteSigner string
+	evmGasLimit     uint64
+	logLevel        string
+	logFormat       string
+}
+
+func parseDeployMulticallFlags(cmd *cobra.Command) (deployMulticallConfig, error) {
+	evmAccAddr, _, err := base.GetEVMAccAddressFlag(cmd)
+	if err != nil {
+		return deployMulticallConfig{}, err
+	}
+	if evmAccAddr == "" {
+		return deployMulticallConfig{}, errors.New("the evm account address should be specified")
+	}
+
+	remoteSigner, _, err := base.GetEVMRemoteSignerFlag(cmd)
+	if err != nil {
+		return deployMulticallConfig{}, err
+	}
+
+	evmRPC, _, err := base.GetEVMRPCFlag(cmd)
+	if err != nil {
+		return deployMulticallConfig{}, err
+	}
+
+	evmGasLimit, _, err := base.GetEVMGasLimitFlag(cmd)
+	if err != nil {
+		return deployMulticallConfig{}, err
+	}
+
+	homeDir, _, err := base.GetHomeFlag(cmd)
+	if err != nil {
+		return deployMulticallConfig{}, err
+	}
+
+	passphrase, _, err := base.GetEVMPassphraseFlag(cmd)
+	if err != nil {
+		return deployMulticallConfig{}, err
+	}
+
+	logLevel, _, err := base.GetLogLevelFlag(cmd)
+	if err != nil {
+		return deployMulticallConfig{}, err
+	}
+
+	logFormat, _, err := base.GetLogFormatFlag(cmd)
+	if err != nil {
+		return deployMulticallConfig{}, err
+	}
+
+	return deployMulticallConfig{
+		Config: base.Config{
+			Home:          homeDir,
+			EVMPassphrase: passphrase,
+		},
+		evmRPC:          evmRPC,
+		evmAccAddress:   evmAccAddr,
+		evmRemoteSigner: remoteSigner,
+		evmGasLimit:     evmGasLimit,
+		logFormat:       logFormat,
+		logLevel:        logLevel,
+	}, nil
+}
@@ -0,0 +1,118 @@
+package monitor
+
+import (
+	"context"
+	"crypto/rand"
+	"strings"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/base"
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/common"
+	"github.com/celestiaorg/orchestrator-relayer/helpers"
+	"github.com/celestiaorg/orchestrator-relayer/monitor"
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+)
+
+// Command the signing liveness monitor command. It follows the attestations and exports
+// which validators signed them as Prometheus metrics.
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "monitor",
+		Short: "Monitors the validators signing liveness and exports it as Prometheus metrics",
+		Long: "Follows the new attestations and queries the signatures of every valset member from the P2P network." +
+			" The last signed nonce, the missed nonces count and the participation rates of every validator are" +
+			" exported as Prometheus metrics, along with the voting power that signed the last checked nonce.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := parseStartFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			logger, err := base.GetLogger(config.logLevel, config.logFormat)
+			if err != nil {
+				return err
+			}
+			logger.Info("initializing monitor")
+
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			stopFuncs := make([]func() error, 0)
+			defer func() {
+				for _, f := range stopFuncs {
+					err := f()
+					if err != nil {
+						logger.Error(err.Error())
+					}
+				}
+			}()
+
+			tmQuerier, appQuerier, stops, err := common.NewTmAndAppQuerier(logger, config.coreRPC, config.coreGRPC, config.grpcInsecure)
+			stopFuncs = append(stopFuncs, stops...)
+			if err != nil {
+				return err
+			}
+
+			registry := prometheus.NewRegistry()
+			metrics, err := telemetry.NewMonitorMetrics(registry)
+			if err != nil {
+				return err
+			}
+			shutdown, err := telemetry.PrometheusMetrics(ctx, logger, registry, config.listenAddr)
+			if shutdown != nil {
+				stopFuncs = append(stopFuncs, shutdown)
+			}
+			if err != nil {
+				return err
+			}
+
+			// the monitor doesn't need a persistent identity, so an ephemeral key is used.
+			privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+			if err != nil {
+				return err
+			}
+			h, err := p2p.CreateHost(config.p2pListenAddr, privKey, nil, nil, nil)
+			if err != nil {
+				return err
+			}
+			aIBootstrappers, err := helpers.ParseAddrInfos(logger, strings.Split(config.bootstrappers, ","))
+			if err != nil {
+				return err
+			}
+			dht, err := p2p.NewBlobstreamDHT(ctx, h, dssync.MutexWrap(ds.NewMapDatastore()), aIBootstrappers, nil, logger)
+			if err != nil {
+				return err
+			}
+			stopFuncs = append(stopFuncs, func() error { return dht.Close() })
+			err = dht.WaitForPeers(ctx, 5*time.Minute, 10*time.Second, common.DHTPeersThreshold)
+			if err != nil {
+				return err
+			}
+
+			m := monitor.New(
+				logger,
+				appQuerier,
+				tmQuerier,
+				p2p.NewQuerier(dht, logger),
+				helpers.NewRetrier(logger, 5, 15*time.Second),
+				metrics,
+				monitor.NewTracker(config.windows),
+				time.Duration(config.signingDelay)*time.Minute,
+			)
+
+			// Listen for and trap any OS signal to graceful shutdown and exit
+			go helpers.TrapSignal(logger, cancel)
+
+			logger.Info("starting monitor")
+			return m.Start(ctx)
+		},
+	}
+	return addStartFlags(cmd)
+}
@@ -0,0 +1,139 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/base"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagMonitorListenAddr   = "monitor.listen-addr"
+	FlagMonitorWindows      = "monitor.windows"
+	FlagMonitorSigningDelay = "monitor.signing-delay"
+)
+
+func addStartFlags(cmd *cobra.Command) *cobra.Command {
+	base.AddCoreRPCFlag(cmd)
+	base.AddCoreGRPCFlag(cmd)
+	base.AddGRPCInsecureFlag(cmd)
+	base.AddBootstrappersFlag(cmd)
+	cmd.Flags().String(base.FlagP2PListenAddress, "/ip4/0.0.0.0/tcp/30002", "MultiAddr for the p2p peer to listen on")
+	base.AddLogLevelFlag(cmd)
+	base.AddLogFormatFlag(cmd)
+	cmd.Flags().String(FlagMonitorListenAddr, "localhost:26702", "The address the Prometheus metrics are served on, under the '/metrics' path")
+	cmd.Flags().String(FlagMonitorWindows, "10,100", "Comma-separated numbers of recent nonces over which the validators participation rates are computed")
+	cmd.Flags().Uint64(FlagMonitorSigningDelay, 10, "The time, in minutes, to wait after an attestation is created before checking its signatures")
+	return cmd
+}
+
+type StartConfig struct {
+	coreRPC, coreGRPC string
+	grpcInsecure      bool
+	bootstrappers     string
+	p2pListenAddr     string
+	logLevel          string
+	logFormat         string
+	listenAddr        string
+	windows           []uint64
+	// signingDelay in minutes
+	signingDelay uint64
+}
+
+func parseStartFlags(cmd *cobra.Command) (StartConfig, error) {
+	coreRPC, _, err := base.GetCoreRPCFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if !strings.HasPrefix(coreRPC, "tcp://") {
+		coreRPC = fmt.Sprintf("tcp://%s", coreRPC)
+	}
+
+	coreGRPC, _, err := base.GetCoreGRPCFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	grpcInsecure, _, err := base.GetGRPCInsecureFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	bootstrappers, _, err := base.GetBootstrappersFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if bootstrappers == "" {
+		return StartConfig{}, fmt.Errorf("at least one bootstrapper is required to query the signatures: flag --%s", base.FlagBootstrappers)
+	}
+
+	p2pListenAddr, _, err := base.GetP2PListenAddressFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	logLevel, _, err := base.GetLogLevelFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	logFormat, _, err := base.GetLogFormatFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	listenAddr, err := cmd.Flags().GetString(FlagMonitorListenAddr)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if _, _, err := net.SplitHostPort(listenAddr); err != nil {
+		return StartConfig{}, fmt.Errorf("%s: flag --%s", err.Error(), FlagMonitorListenAddr)
+	}
+
+	rawWindows, err := cmd.Flags().GetString(FlagMonitorWindows)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	windows, err := parseWindows(rawWindows)
+	if err != nil {
+		return StartConfig{}, fmt.Errorf("%s: flag --%s", err.Error(), FlagMonitorWindows)
+	}
+
+	signingDelay, err := cmd.Flags().GetUint64(FlagMonitorSigningDelay)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	return StartConfig{
+		coreRPC:       coreRPC,
+		coreGRPC:      coreGRPC,
+		grpcInsecure:  grpcInsecure,
+		bootstrappers: bootstrappers,
+		p2pListenAddr: p2pListenAddr,
+		logLevel:      logLevel,
+		logFormat:     logFormat,
+		listenAddr:    listenAddr,
+		windows:       windows,
+		signingDelay:  signingDelay,
+	}, nil
+}
+
+// parseWindows parses a comma-separated list of windows.
+func parseWindows(rawWindows string) ([]uint64, error) {
+	windows := make([]uint64, 0)
+	for _, rawWindow := range strings.Split(rawWindows, ",") {
+		window, err := strconv.ParseUint(strings.TrimSpace(rawWindow), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if window == 0 {
+			return nil, errors.New("window cannot be 0")
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
@@ -14,7 +14,6 @@ import (
 	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/base"
 
 	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/common"
-	evm2 "github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/keys/evm"
 	"github.com/celestiaorg/orchestrator-relayer/p2p"
 	dssync "github.com/ipfs/go-datastore/sync"
 
@@ -23,6 +22,7 @@ import (
 
 	"github.com/celestiaorg/orchestrator-relayer/helpers"
 	"github.com/celestiaorg/orchestrator-relayer/orchestrator"
+	"github.com/celestiaorg/orchestrator-relayer/pruner"
 	"github.com/spf13/cobra"
 )
 
@@ -38,6 +38,7 @@ func Command() *cobra.Command {
 		Start(),
 		Init(),
 		keys.Command(ServiceNameOrchestrator),
+		SlashingProtectionCommand(),
 	)
 
 	orchCmd.SetHelpCommand(&cobra.Command{})
@@ -94,6 +95,15 @@ func Start() *cobra.Command {
 				return err
 			}
 
+			// starting the health server early so that the probes get answered while waiting for the DHT peers.
+			healthServer, healthStops, err := common.StartHealthServer(logger, config.HealthConfig)
+			stopFuncs = append(stopFuncs, healthStops...)
+			if err != nil {
+				return err
+			}
+			common.AddCoreHealthChecks(healthServer, tmQuerier, appQuerier)
+			common.AddDHTHealthCheck(healthServer, nil, common.DHTPeersThreshold)
+
 			s, storeStops, err := common.OpenStore(logger, config.Home, store.OpenOptions{
 				HasDataStore:      true,
 				BadgerOptions:     store.DefaultBadgerOptions(config.Home),
@@ -106,10 +116,15 @@ func Start() *cobra.Command {
 				return err
 			}
 
-			logger.Info("loading EVM account", "address", config.EvmAccAddress)
-
-			acc, err := evm2.GetAccountFromStoreAndUnlockIt(s.EVMKeyStore, config.EvmAccAddress, config.EVMPassphrase)
-			stopFuncs = append(stopFuncs, func() error { return s.EVMKeyStore.Lock(acc.Address) })
+			signer, signerStops, err := common.NewEVMSigner(
+				ctx,
+				logger,
+				s.EVMKeyStore,
+				config.EvmAccAddress,
+				config.EVMPassphrase,
+				config.EvmRemoteSigner,
+			)
+			stopFuncs = append(stopFuncs, signerStops...)
 			if err != nil {
 				return err
 			}
@@ -129,7 +144,7 @@ func Start() *cobra.Command {
 					opts = append(opts, otlpmetrichttp.WithInsecure())
 				}
 				var shutdown func() error
-				registerer, shutdown, err = telemetry.Start(ctx, logger, ServiceNameOrchestrator, acc.Address.Hex(), opts)
+				registerer, shutdown, err = telemetry.Start(ctx, logger, ServiceNameOrchestrator, signer.Address().Hex(), opts)
 				if shutdown != nil {
 					stopFuncs = append(stopFuncs, shutdown)
 				}
@@ -145,9 +160,29 @@ func Start() *cobra.Command {
 				}
 			}
 
+			if config.TracingConfig.Enable {
+				shutdown, err := telemetry.StartTracing(ctx, logger, ServiceNameOrchestrator, signer.Address().Hex(), config.TracingConfig)
+				if shutdown != nil {
+					stopFuncs = append(stopFuncs, shutdown)
+				}
+				if err != nil {
+					return err
+				}
+			}
+
 			// creating the data store
 			dataStore := dssync.MutexWrap(s.DataStore)
 
+			gater, err := common.NewGater(ctx, logger, config.GatingConfig, config.Bootstrappers, appQuerier)
+			if err != nil {
+				return err
+			}
+
+			scorer, err := common.NewPeerScorer(logger, config.ScoringConfig)
+			if err != nil {
+				return err
+			}
+
 			dht, err := common.CreateDHTAndWaitForPeers(
 				ctx,
 				logger,
@@ -157,19 +192,47 @@ func Start() *cobra.Command {
 				config.Bootstrappers,
 				dataStore,
 				registerer,
+				gater,
+				scorer,
+				signer,
 			)
 			if err != nil {
 				return err
 			}
+			dht.JSONRecords = config.JSONRecords
+			go dht.StartValsetUpdates(ctx, appQuerier, p2p.DefaultValsetMembersUpdateInterval)
 			stopFuncs = append(stopFuncs, func() error { return dht.Close() })
 			stopFuncs = append(stopFuncs, storeStops...)
+			common.AddDHTHealthCheck(healthServer, dht, common.DHTPeersThreshold)
 
 			// creating the p2p querier
 			p2pQuerier := p2p.NewQuerier(dht, logger)
 			retrier := helpers.NewRetrier(logger, 5, 30*time.Second)
 
+			// joining the confirms gossip topic to publish the confirms to the relayers.
+			confirmsPubSub, err := p2p.NewConfirmsPubSub(ctx, dht.Host(), gater, logger)
+			if err != nil {
+				return err
+			}
+			stopFuncs = append(stopFuncs, confirmsPubSub.Close)
+			go func() {
+				// the received confirms are not used by the orchestrator, but subscribing
+				// allows forwarding them to the relayers.
+				err := confirmsPubSub.Start(ctx, nil)
+				if err != nil {
+					logger.Error("stopped receiving confirms from the gossip topic", "err", err.Error())
+				}
+			}()
+
 			// creating the broadcaster
-			broadcaster := orchestrator.NewBroadcaster(p2pQuerier.BlobstreamDHT)
+			broadcaster := orchestrator.NewBroadcaster(p2pQuerier.BlobstreamDHT, confirmsPubSub, logger)
+
+			// loading the orchestrator progress from the data store
+			checkpoint, err := orchestrator.NewCheckpoint(ctx, dataStore)
+			if err != nil {
+				return err
+			}
+			logger.Info("loaded orchestrator checkpoint", "last_processed_nonce", checkpoint.LastProcessedNonce())
 
 			// creating the orchestrator
 			orch := orchestrator.New(
@@ -179,13 +242,46 @@ func Start() *cobra.Command {
 				p2pQuerier,
 				broadcaster,
 				retrier,
-				s.EVMKeyStore,
-				&acc,
+				signer,
 				orchestratorMeters,
+				checkpoint,
+				orchestrator.NewSlashingProtection(dataStore),
 			)
 			if err != nil {
 				return err
 			}
+			common.AddEventsListenerHealthCheck(healthServer, orch.State)
+
+			if config.ConfirmsMonitorConfig.Enable {
+				orch.ConfirmsMonitor = &orchestrator.ConfirmsMonitorConfig{
+					Interval:    time.Duration(config.ConfirmsMonitorConfig.Interval) * time.Minute,
+					Window:      config.ConfirmsMonitorConfig.Window,
+					Rebroadcast: config.ConfirmsMonitorConfig.Rebroadcast,
+				}
+			}
+
+			if config.AdminConfig.Enable {
+				adminServer := orchestrator.NewAdminServer(orch, config.AdminConfig.ListenAddr)
+				err = adminServer.Start()
+				if err != nil {
+					return err
+				}
+				stopFuncs = append(stopFuncs, adminServer.Stop)
+			}
+
+			storeMeters, err := telemetry.InitStoreMeters()
+			if err != nil {
+				return err
+			}
+			// pruning the confirms outside the retention window so that the data store doesn't fill the disk.
+			storePruner := pruner.New(
+				logger,
+				appQuerier,
+				config.RetentionConfig,
+				storeMeters,
+				pruner.Store{Name: pruner.DataStoreName, Datastore: dataStore},
+			)
+			go storePruner.Start(ctx)
 
 			logger.Info("starting orchestrator")
 
@@ -3,11 +3,14 @@ package orchestrator
 import (
 	"bytes"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
 
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/pruner"
 	"github.com/celestiaorg/orchestrator-relayer/telemetry"
 
 	"github.com/spf13/viper"
@@ -48,6 +51,10 @@ bootstrappers = "{{ .Bootstrappers }}"
 # MultiAddr for the p2p peer to listen on.
 listen-addr = "{{ .P2PListenAddr }}"
 
+# Forces putting the DHT records using the legacy JSON format. Otherwise, the compact binary format
+# is used whenever all the peers the records are put to advertise supporting it.
+json-records = "{{ .JSONRecords }}"
+
 ###############################################################################
 ###                         Telemetry Configuration                         ###
 ###############################################################################
@@ -63,6 +70,99 @@ tls = "{{ .MetricsConfig.TLS }}"
 
 # Sets the HTTP endpoint for LibP2P metrics to listen on.
 p2p-endpoint = "{{ .MetricsConfig.P2PEndpoint }}"
+
+###############################################################################
+###                         Admin API Configuration                         ###
+###############################################################################
+[admin]
+# Enables the local admin API exposing the orchestrator processing state.
+enable = "{{ .AdminConfig.Enable }}"
+
+# Sets the address for the admin API to listen on. It should not be exposed publicly
+# as it allows forcing the re-processing of nonces.
+listen-addr = "{{ .AdminConfig.ListenAddr }}"
+
+###############################################################################
+###                         Health Configuration                            ###
+###############################################################################
+[health]
+# Enables the /healthz and /readyz endpoints to be used as liveness and readiness probes.
+enable = "{{ .HealthConfig.Enable }}"
+
+# Sets the address for the health endpoints to listen on.
+listen-addr = "{{ .HealthConfig.ListenAddr }}"
+
+###############################################################################
+###                         Tracing Configuration                           ###
+###############################################################################
+[tracing]
+# Enables OpenTelemetry traces.
+enable = "{{ .TracingConfig.Enable }}"
+
+# Sets the traces exporter: "otlp" to export them to an OTLP collector over HTTP,
+# or "stdout" to print them.
+exporter = "{{ .TracingConfig.Exporter }}"
+
+# Sets HTTP endpoint for OTLP traces to be exported to.
+endpoint = "{{ .TracingConfig.Endpoint }}"
+
+# Enable TLS connection to OTLP traces backend.
+tls = "{{ .TracingConfig.TLS }}"
+
+###############################################################################
+###                         Confirms Monitor Configuration                  ###
+###############################################################################
+[confirms-monitor]
+# Enables periodically verifying that the orchestrator signatures are retrievable
+# from the P2P network peers. A metric is incremented, and an error is logged, for
+# every signature that is not.
+enable = "{{ .ConfirmsMonitorConfig.Enable }}"
+
+# The time, in minutes, between two verifications.
+interval = "{{ .ConfirmsMonitorConfig.Interval }}"
+
+# The number of recent nonces whose signatures are verified.
+window = "{{ .ConfirmsMonitorConfig.Window }}"
+
+# Broadcasts again the signatures that are not retrievable from the P2P network peers.
+rebroadcast = "{{ .ConfirmsMonitorConfig.Rebroadcast }}"
+
+###############################################################################
+###                         Retention Configuration                         ###
+###############################################################################
+[retention]
+# The number of latest attestation nonces whose confirms are kept in the stores.
+# If 0, the confirms are not pruned based on the number of nonces.
+nonces = "{{ .RetentionConfig.Nonces }}"
+
+# The number of days the confirms of an attestation are kept in the stores.
+# If 0, the confirms are not pruned based on their age.
+# If both the nonces and the days are set, the confirms are kept as long as
+# either of them retains them.
+days = "{{ .RetentionConfig.Days }}"
+
+# The time, in minutes, between two prunings of the stores.
+interval = "{{ .RetentionConfig.Interval }}"
+
+###############################################################################
+###                         P2P Gating Configuration                        ###
+###############################################################################
+[gating]
+# Only accepts the connections of the allowlisted peers, and of the peers proving
+# that their P2P key is bound to an EVM address of the current or previous valset.
+enable = "{{ .GatingConfig.Enable }}"
+
+# Comma-separated IDs, or multiaddresses, of the peers that are always accepted,
+# e.g. the relayers. The bootstrappers are always accepted.
+allowlist = "{{ .GatingConfig.Allowlist }}"
+
+###############################################################################
+###                         P2P Peer Scoring Configuration                  ###
+###############################################################################
+[peer-scoring]
+# Scores the P2P peers depending on the validity of the DHT records they return,
+# and bans the ones returning too many invalid records.
+enable = "{{ .ScoringConfig.Enable }}"
 `
 
 func addOrchestratorFlags(cmd *cobra.Command) *cobra.Command {
@@ -70,6 +170,7 @@ func addOrchestratorFlags(cmd *cobra.Command) *cobra.Command {
 	base.AddCoreGRPCFlag(cmd)
 	base.AddEVMAccAddressFlag(cmd)
 	base.AddEVMPassphraseFlag(cmd)
+	base.AddEVMRemoteSignerFlag(cmd)
 	homeDir, err := base.DefaultServicePath(ServiceNameOrchestrator)
 	if err != nil {
 		panic(err)
@@ -77,6 +178,7 @@ func addOrchestratorFlags(cmd *cobra.Command) *cobra.Command {
 	base.AddHomeFlag(cmd, ServiceNameOrchestrator, homeDir)
 	base.AddP2PNicknameFlag(cmd)
 	base.AddP2PListenAddressFlag(cmd)
+	base.AddP2PJSONRecordsFlag(cmd)
 	base.AddBootstrappersFlag(cmd)
 	base.AddGRPCInsecureFlag(cmd)
 	base.AddLogLevelFlag(cmd)
@@ -85,22 +187,65 @@ func addOrchestratorFlags(cmd *cobra.Command) *cobra.Command {
 	base.AddMetricsEndpointFlag(cmd)
 	base.AddMetricsTLSFlag(cmd)
 	base.AddP2PMetricsEndpoint(cmd)
+	base.AddAdminFlag(cmd)
+	base.AddAdminListenAddrFlag(cmd)
+	base.AddHealthFlag(cmd)
+	base.AddHealthListenAddrFlag(cmd)
+	base.AddTracingFlag(cmd)
+	base.AddTracingExporterFlag(cmd)
+	base.AddTracingEndpointFlag(cmd)
+	base.AddTracingTLSFlag(cmd)
+	base.AddConfirmsMonitorFlag(cmd)
+	base.AddConfirmsMonitorIntervalFlag(cmd)
+	base.AddConfirmsMonitorWindowFlag(cmd)
+	base.AddConfirmsMonitorRebroadcastFlag(cmd)
+	base.AddRetentionNoncesFlag(cmd)
+	base.AddRetentionDaysFlag(cmd)
+	base.AddRetentionIntervalFlag(cmd)
+	base.AddP2PGatingFlag(cmd)
+	base.AddP2PAllowlistFlag(cmd)
+	base.AddP2PPeerScoringFlag(cmd)
 
 	return cmd
 }
 
 type StartConfig struct {
 	base.Config
-	CoreGRPC      string `mapstructure:"core-grpc" json:"core-grpc"`
-	CoreRPC       string `mapstructure:"core-rpc" json:"core-rpc"`
-	EvmAccAddress string
-	Bootstrappers string `mapstructure:"bootstrappers" json:"bootstrappers"`
-	P2PListenAddr string `mapstructure:"listen-addr" json:"listen-addr"`
-	P2pNickname   string
-	GRPCInsecure  bool `mapstructure:"grpc-insecure" json:"grpc-insecure"`
-	LogLevel      string
-	LogFormat     string
-	MetricsConfig telemetry.Config `mapstructure:"telemetry" json:"telemetry"`
+	CoreGRPC        string `mapstructure:"core-grpc" json:"core-grpc"`
+	CoreRPC         string `mapstructure:"core-rpc" json:"core-rpc"`
+	EvmAccAddress   string
+	EvmRemoteSigner string
+	Bootstrappers   string `mapstructure:"bootstrappers" json:"bootstrappers"`
+	P2PListenAddr   string `mapstructure:"listen-addr" json:"listen-addr"`
+	JSONRecords     bool   `mapstructure:"json-records" json:"json-records"`
+	P2pNickname     string
+	GRPCInsecure    bool `mapstructure:"grpc-insecure" json:"grpc-insecure"`
+	LogLevel        string
+	LogFormat       string
+	MetricsConfig   telemetry.Config        `mapstructure:"telemetry" json:"telemetry"`
+	AdminConfig     AdminConfig             `mapstructure:"admin" json:"admin"`
+	HealthConfig    telemetry.HealthConfig  `mapstructure:"health" json:"health"`
+	TracingConfig   telemetry.TracingConfig `mapstructure:"tracing" json:"tracing"`
+
+	ConfirmsMonitorConfig ConfirmsMonitorConfig  `mapstructure:"confirms-monitor" json:"confirms-monitor"`
+	RetentionConfig       pruner.RetentionConfig `mapstructure:"retention" json:"retention"`
+	GatingConfig          p2p.GatingConfig       `mapstructure:"gating" json:"gating"`
+	ScoringConfig         p2p.ScoringConfig      `mapstructure:"peer-scoring" json:"peer-scoring"`
+}
+
+// ConfirmsMonitorConfig the configuration of the orchestrator confirms monitor.
+type ConfirmsMonitorConfig struct {
+	Enable bool `mapstructure:"enable" json:"enable"`
+	// Interval in minutes.
+	Interval    uint64 `mapstructure:"interval" json:"interval"`
+	Window      uint64 `mapstructure:"window" json:"window"`
+	Rebroadcast bool   `mapstructure:"rebroadcast" json:"rebroadcast"`
+}
+
+// AdminConfig the configuration of the orchestrator admin API.
+type AdminConfig struct {
+	Enable     bool   `mapstructure:"enable" json:"enable"`
+	ListenAddr string `mapstructure:"listen-addr" json:"listen-addr"`
 }
 
 func DefaultStartConfig() *StartConfig {
@@ -116,6 +261,38 @@ func DefaultStartConfig() *StartConfig {
 			TLS:         false,
 			P2PEndpoint: "localhost:30001",
 		},
+		AdminConfig: AdminConfig{
+			Enable:     false,
+			ListenAddr: "localhost:26700",
+		},
+		HealthConfig: telemetry.HealthConfig{
+			Enable:     false,
+			ListenAddr: "localhost:26701",
+		},
+		TracingConfig: telemetry.TracingConfig{
+			Enable:   false,
+			Exporter: telemetry.OTLPTracesExporter,
+			Endpoint: "localhost:4318",
+			TLS:      false,
+		},
+		ConfirmsMonitorConfig: ConfirmsMonitorConfig{
+			Enable:      false,
+			Interval:    10,
+			Window:      10,
+			Rebroadcast: false,
+		},
+		RetentionConfig: pruner.RetentionConfig{
+			Nonces:   0,
+			Days:     0,
+			Interval: 60,
+		},
+		GatingConfig: p2p.GatingConfig{
+			Enable:    false,
+			Allowlist: "",
+		},
+		ScoringConfig: p2p.ScoringConfig{
+			Enable: false,
+		},
 	}
 }
 
@@ -123,6 +300,29 @@ func (cfg StartConfig) ValidateBasics() error {
 	if err := base.ValidateEVMAddress(cfg.EvmAccAddress); err != nil {
 		return fmt.Errorf("%s: flag --%s", err.Error(), base.FlagEVMAccAddress)
 	}
+	if cfg.AdminConfig.Enable {
+		if _, _, err := net.SplitHostPort(cfg.AdminConfig.ListenAddr); err != nil {
+			return fmt.Errorf("%s: flag --%s", err.Error(), base.FlagAdminListenAddr)
+		}
+	}
+	if cfg.HealthConfig.Enable {
+		if _, _, err := net.SplitHostPort(cfg.HealthConfig.ListenAddr); err != nil {
+			return fmt.Errorf("%s: flag --%s", err.Error(), base.FlagHealthListenAddr)
+		}
+	}
+	if cfg.TracingConfig.Enable {
+		if err := telemetry.ValidateTracesExporter(cfg.TracingConfig.Exporter); err != nil {
+			return fmt.Errorf("%s: flag --%s", err.Error(), base.FlagTracingExporter)
+		}
+	}
+	if cfg.ConfirmsMonitorConfig.Enable {
+		if cfg.ConfirmsMonitorConfig.Interval == 0 {
+			return fmt.Errorf("confirms monitor interval cannot be 0: flag --%s", base.FlagConfirmsMonitorInterval)
+		}
+		if cfg.ConfirmsMonitorConfig.Window == 0 {
+			return fmt.Errorf("confirms monitor window cannot be 0: flag --%s", base.FlagConfirmsMonitorWindow)
+		}
+	}
 	return nil
 }
 
@@ -133,6 +333,12 @@ func parseOrchestratorFlags(cmd *cobra.Command, startConf *StartConfig) (StartCo
 	}
 	startConf.EvmAccAddress = evmAccAddr
 
+	remoteSigner, _, err := base.GetEVMRemoteSignerFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	startConf.EvmRemoteSigner = remoteSigner
+
 	coreRPC, changed, err := base.GetCoreRPCFlag(cmd)
 	if err != nil {
 		return StartConfig{}, err
@@ -168,6 +374,14 @@ func parseOrchestratorFlags(cmd *cobra.Command, startConf *StartConfig) (StartCo
 		startConf.P2PListenAddr = p2pListenAddress
 	}
 
+	jsonRecords, changed, err := base.GetP2PJSONRecordsFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed {
+		startConf.JSONRecords = jsonRecords
+	}
+
 	p2pNickname, changed, err := base.GetP2PNicknameFlag(cmd)
 	if err != nil {
 		return StartConfig{}, err
@@ -228,6 +442,93 @@ func parseOrchestratorFlags(cmd *cobra.Command, startConf *StartConfig) (StartCo
 		startConf.MetricsConfig.P2PEndpoint = p2p
 	}
 
+	admin, changed, err := base.GetAdminFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed {
+		startConf.AdminConfig.Enable = admin
+	}
+
+	adminListenAddr, changed, err := base.GetAdminListenAddrFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	// config files created before the admin API was added don't define its listen address.
+	if changed || startConf.AdminConfig.ListenAddr == "" {
+		startConf.AdminConfig.ListenAddr = adminListenAddr
+	}
+
+	health, changed, err := base.GetHealthFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed {
+		startConf.HealthConfig.Enable = health
+	}
+
+	healthListenAddr, changed, err := base.GetHealthListenAddrFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	// config files created before the health endpoints were added don't define their listen address.
+	if changed || startConf.HealthConfig.ListenAddr == "" {
+		startConf.HealthConfig.ListenAddr = healthListenAddr
+	}
+
+	err = base.ParseTracingFlags(cmd, &startConf.TracingConfig)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	confirmsMonitor, changed, err := base.GetConfirmsMonitorFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed {
+		startConf.ConfirmsMonitorConfig.Enable = confirmsMonitor
+	}
+
+	// config files created before the confirms monitor was added don't define its interval and window.
+	confirmsMonitorInterval, changed, err := base.GetConfirmsMonitorIntervalFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed || startConf.ConfirmsMonitorConfig.Interval == 0 {
+		startConf.ConfirmsMonitorConfig.Interval = confirmsMonitorInterval
+	}
+
+	confirmsMonitorWindow, changed, err := base.GetConfirmsMonitorWindowFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed || startConf.ConfirmsMonitorConfig.Window == 0 {
+		startConf.ConfirmsMonitorConfig.Window = confirmsMonitorWindow
+	}
+
+	confirmsMonitorRebroadcast, changed, err := base.GetConfirmsMonitorRebroadcastFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed {
+		startConf.ConfirmsMonitorConfig.Rebroadcast = confirmsMonitorRebroadcast
+	}
+
+	err = base.ParseRetentionFlags(cmd, &startConf.RetentionConfig)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	err = base.ParseGatingFlags(cmd, &startConf.GatingConfig)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	err = base.ParseScoringFlags(cmd, &startConf.ScoringConfig)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
 	logLevel, _, err := base.GetLogLevelFlag(cmd)
 	if err != nil {
 		return StartConfig{}, err
@@ -0,0 +1,139 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/base"
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/common"
+	"github.com/celestiaorg/orchestrator-relayer/orchestrator"
+	"github.com/celestiaorg/orchestrator-relayer/store"
+	"github.com/spf13/cobra"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+// SlashingProtectionCommand manages the orchestrator slashing protection records.
+func SlashingProtectionCommand() *cobra.Command {
+	spCmd := &cobra.Command{
+		Use:          "slashing-protection",
+		Short:        "Manage the records of the digests signed by the orchestrator",
+		SilenceUsage: true,
+	}
+
+	spCmd.AddCommand(
+		SlashingProtectionExport(),
+		SlashingProtectionImport(),
+	)
+
+	spCmd.SetHelpCommand(&cobra.Command{})
+
+	return spCmd
+}
+
+// SlashingProtectionExport exports the slashing protection records to a JSON file.
+func SlashingProtectionExport() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "export <path to file>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Export the digests signed by the orchestrator to a JSON file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := parseInitFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			logger, err := base.GetLogger(config.logLevel, config.logFormat)
+			if err != nil {
+				return err
+			}
+
+			s, stopFuncs, err := openDataStore(logger, config.home)
+			defer runStopFuncs(logger, stopFuncs)
+			if err != nil {
+				return err
+			}
+
+			history, err := orchestrator.NewSlashingProtection(s.DataStore).Export(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			bz, err := json.MarshalIndent(history, "", "  ")
+			if err != nil {
+				return err
+			}
+			err = os.WriteFile(args[0], bz, 0o600)
+			if err != nil {
+				return err
+			}
+
+			logger.Info("exported slashing protection records", "path", args[0], "count", len(history.SignedDigests))
+			return nil
+		},
+	}
+	return addInitFlags(&cmd)
+}
+
+// SlashingProtectionImport imports slashing protection records from a JSON file.
+func SlashingProtectionImport() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "import <path to file>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Import the digests signed by the orchestrator from a JSON file",
+		Long: "Import the digests signed by the orchestrator from a JSON file, generated using the export command. " +
+			"If any of the imported digests conflicts with an existing one, nothing is imported.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := parseInitFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			logger, err := base.GetLogger(config.logLevel, config.logFormat)
+			if err != nil {
+				return err
+			}
+
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var history orchestrator.SlashingProtectionHistory
+			err = json.Unmarshal(bz, &history)
+			if err != nil {
+				return err
+			}
+
+			s, stopFuncs, err := openDataStore(logger, config.home)
+			defer runStopFuncs(logger, stopFuncs)
+			if err != nil {
+				return err
+			}
+
+			err = orchestrator.NewSlashingProtection(s.DataStore).Import(cmd.Context(), history)
+			if err != nil {
+				return err
+			}
+
+			logger.Info("imported slashing protection records", "path", args[0], "count", len(history.SignedDigests))
+			return nil
+		},
+	}
+	return addInitFlags(&cmd)
+}
+
+// openDataStore opens the orchestrator data store.
+func openDataStore(logger tmlog.Logger, home string) (*store.Store, []func() error, error) {
+	return common.OpenStore(logger, home, store.OpenOptions{
+		HasDataStore:  true,
+		BadgerOptions: store.DefaultBadgerOptions(home),
+	})
+}
+
+func runStopFuncs(logger tmlog.Logger, stopFuncs []func() error) {
+	for _, f := range stopFuncs {
+		err := f()
+		if err != nil {
+			logger.Error(err.Error())
+		}
+	}
+}
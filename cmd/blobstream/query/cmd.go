@@ -174,7 +174,7 @@ func startResources(ctx context.Context, config Config, logger tmlog.Logger) (*r
 	dataStore := dssync.MutexWrap(ds.NewMapDatastore())
 
 	// creating the dht
-	dht, err := p2p.NewBlobstreamDHT(ctx, h, dataStore, []peer.AddrInfo{}, logger)
+	dht, err := p2p.NewBlobstreamDHT(ctx, h, dataStore, []peer.AddrInfo{}, nil, logger)
 	if err != nil {
 		return nil, nil, nil, stopFuncs, err
 	}
@@ -634,7 +634,7 @@ func Signature() *cobra.Command {
 			dataStore := dssync.MutexWrap(ds.NewMapDatastore())
 
 			// creating the dht
-			dht, err := p2p.NewBlobstreamDHT(cmd.Context(), h, dataStore, []peer.AddrInfo{}, logger)
+			dht, err := p2p.NewBlobstreamDHT(cmd.Context(), h, dataStore, []peer.AddrInfo{}, nil, logger)
 			if err != nil {
 				return err
 			}
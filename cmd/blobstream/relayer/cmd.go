@@ -2,11 +2,17 @@ package relayer
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"path/filepath"
+	"strconv"
+	"sync"
 	"time"
 
+	tmlog "github.com/tendermint/tendermint/libs/log"
+
 	"github.com/celestiaorg/orchestrator-relayer/telemetry"
+	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 
@@ -18,7 +24,6 @@ import (
 
 	blobstreamwrapper "github.com/celestiaorg/blobstream-contracts/v4/wrappers/Blobstream.sol"
 
-	evm2 "github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/keys/evm"
 	"github.com/celestiaorg/orchestrator-relayer/p2p"
 	dssync "github.com/ipfs/go-datastore/sync"
 
@@ -26,6 +31,7 @@ import (
 	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/keys"
 	"github.com/celestiaorg/orchestrator-relayer/evm"
 	"github.com/celestiaorg/orchestrator-relayer/helpers"
+	"github.com/celestiaorg/orchestrator-relayer/pruner"
 	"github.com/celestiaorg/orchestrator-relayer/store"
 
 	"github.com/celestiaorg/orchestrator-relayer/relayer"
@@ -45,6 +51,8 @@ func Command() *cobra.Command {
 		Start(),
 		Init(),
 		keys.Command(ServiceNameRelayer),
+		SignaturesCommand(),
+		StoreCommand(),
 	)
 
 	relCmd.SetHelpCommand(&cobra.Command{})
@@ -148,6 +156,15 @@ func Start() *cobra.Command {
 				return err
 			}
 
+			// starting the health server early so that the probes get answered while waiting for the DHT peers.
+			healthServer, healthStops, err := common.StartHealthServer(logger, config.HealthConfig)
+			stopFuncs = append(stopFuncs, healthStops...)
+			if err != nil {
+				return err
+			}
+			common.AddCoreHealthChecks(healthServer, tmQuerier, appQuerier)
+			common.AddDHTHealthCheck(healthServer, nil, common.DHTPeersThreshold)
+
 			s, storeStops, err := common.OpenStore(logger, config.Home, store.OpenOptions{
 				HasDataStore:      true,
 				BadgerOptions:     store.DefaultBadgerOptions(config.Home),
@@ -159,12 +176,22 @@ func Start() *cobra.Command {
 				return err
 			}
 
-			logger.Info("loading EVM account", "address", config.evmAccAddress)
-
-			acc, err := evm2.GetAccountFromStoreAndUnlockIt(s.EVMKeyStore, config.evmAccAddress, config.EVMPassphrase)
-			stopFuncs = append(stopFuncs, func() error { return s.EVMKeyStore.Lock(acc.Address) })
-			if err != nil {
-				return err
+			targets := config.Targets()
+			signers := make([]evm.Signer, len(targets))
+			for i, target := range targets {
+				signer, signerStops, err := common.NewEVMSigner(
+					ctx,
+					logger,
+					s.EVMKeyStore,
+					target.EvmAccAddress,
+					config.EVMPassphrase,
+					target.EvmRemoteSigner,
+				)
+				stopFuncs = append(stopFuncs, signerStops...)
+				if err != nil {
+					return err
+				}
+				signers[i] = signer
 			}
 
 			// creating the data store
@@ -175,6 +202,11 @@ func Start() *cobra.Command {
 				return err
 			}
 
+			// a relayer process can relay to multiple targets using different signers, so its telemetry
+			// is identified by a random instance ID instead of a signer address.
+			instanceID := uuid.NewString()
+			logger.Info("relayer instance", "instance_id", instanceID)
+
 			var registerer prometheus.Registerer
 			if config.MetricsConfig.Metrics {
 				opts := []otlpmetrichttp.Option{
@@ -184,12 +216,16 @@ func Start() *cobra.Command {
 				if !config.MetricsConfig.TLS {
 					opts = append(opts, otlpmetrichttp.WithInsecure())
 				}
+				serviceName := ServiceNameRelayer
+				if len(targets) == 1 {
+					serviceName = fmt.Sprintf("%s:%s", ServiceNameRelayer, targets[0].ContractAddr)
+				}
 				var shutdown func() error
 				registerer, shutdown, err = telemetry.Start(
 					ctx,
 					logger,
-					fmt.Sprintf("%s:%s", ServiceNameRelayer, config.ContractAddr),
-					acc.Address.Hex(),
+					serviceName,
+					instanceID,
 					opts,
 				)
 				if shutdown != nil {
@@ -207,61 +243,186 @@ func Start() *cobra.Command {
 				}
 			}
 
-			dht, err := common.CreateDHTAndWaitForPeers(ctx, logger, s.P2PKeyStore, config.p2pNickname, config.P2PListenAddr, config.Bootstrappers, dataStore, registerer)
+			if config.TracingConfig.Enable {
+				serviceName := ServiceNameRelayer
+				if len(targets) == 1 {
+					serviceName = fmt.Sprintf("%s:%s", ServiceNameRelayer, targets[0].ContractAddr)
+				}
+				shutdown, err := telemetry.StartTracing(ctx, logger, serviceName, instanceID, config.TracingConfig)
+				if shutdown != nil {
+					stopFuncs = append(stopFuncs, shutdown)
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			// the relayer doesn't prove its binding to an EVM address, it's expected to be allowlisted
+			// by the gated peers.
+			gater, err := common.NewGater(ctx, logger, config.GatingConfig, config.Bootstrappers, appQuerier)
+			if err != nil {
+				return err
+			}
+
+			scorer, err := common.NewPeerScorer(logger, config.ScoringConfig)
 			if err != nil {
 				return err
 			}
+
+			dht, err := common.CreateDHTAndWaitForPeers(ctx, logger, s.P2PKeyStore, config.p2pNickname, config.P2PListenAddr, config.Bootstrappers, dataStore, registerer, gater, scorer, nil)
+			if err != nil {
+				return err
+			}
+			dht.JSONRecords = config.JSONRecords
+			go dht.StartValsetUpdates(ctx, appQuerier, p2p.DefaultValsetMembersUpdateInterval)
 			stopFuncs = append(stopFuncs, func() error { return dht.Close() })
 			stopFuncs = append(stopFuncs, storeStops...)
+			common.AddDHTHealthCheck(healthServer, dht, common.DHTPeersThreshold)
 
 			// creating the p2p querier
 			p2pQuerier := p2p.NewQuerier(dht, logger)
-			retrier := helpers.NewRetrier(logger, 6, time.Minute)
+			// looking up the previously collected confirms first, so that the attestations whose
+			// confirms were already collected can be relayed even if the DHT is unavailable.
+			p2pQuerier.SignatureStore = s.SignatureStore
 
-			// connecting to a Blobstream contract
-			ethClient, err := ethclient.Dial(config.EvmRPC)
+			// collecting the confirms published on the confirms gossip topic so that the DHT
+			// is only queried for the missing ones.
+			confirmsPubSub, err := p2p.NewConfirmsPubSub(ctx, dht.Host(), gater, logger)
 			if err != nil {
 				return err
 			}
-			defer ethClient.Close()
-			blobstreamWrapper, err := blobstreamwrapper.NewWrappers(ethcmn.HexToAddress(config.ContractAddr), ethClient)
+			stopFuncs = append(stopFuncs, confirmsPubSub.Close)
+			p2pQuerier.ConfirmPool = p2p.NewConfirmPool(logger)
+			// only accepting the confirms of the valset members for the known attestations,
+			// so that the pool can't be filled by arbitrary peers.
+			go p2pQuerier.ConfirmPool.StartUpdates(ctx, appQuerier, p2p.DefaultConfirmPoolUpdateInterval)
+			go func() {
+				err := confirmsPubSub.Start(ctx, p2pQuerier.ConfirmPool)
+				if err != nil {
+					logger.Error("stopped receiving confirms from the gossip topic", "err", err.Error())
+				}
+			}()
+
+			storeMeters, err := telemetry.InitStoreMeters()
 			if err != nil {
 				return err
 			}
-
-			evmClient := evm.NewClient(
+			// pruning the confirms outside the retention window so that the stores don't fill the disk.
+			storePruner := pruner.New(
 				logger,
-				blobstreamWrapper,
-				s.EVMKeyStore,
-				&acc,
-				config.EvmRPC,
-				config.EvmGasLimit,
-			)
-
-			relay := relayer.NewRelayer(
-				tmQuerier,
 				appQuerier,
-				p2pQuerier,
-				evmClient,
-				logger,
-				retrier,
-				s.SignatureStore,
-				time.Duration(config.EVMRetryTimeout)*time.Minute,
-				config.isBackupRelayer,
-				time.Duration(config.backupRelayerWaitTime)*time.Minute,
-				relayerMeters,
+				config.RetentionConfig,
+				storeMeters,
+				pruner.Store{Name: pruner.DataStoreName, Datastore: dataStore},
+				pruner.Store{Name: pruner.SignatureStoreName, Datastore: s.SignatureStore},
 			)
+			go storePruner.Start(ctx)
+
+			relayers := make([]*relayer.Relayer, len(targets))
+			for i, target := range targets {
+				targetLogger := logger
+				healthCheckSuffix := ""
+				if len(targets) > 1 {
+					targetLogger = logger.With("evm_chain_id", target.EvmChainID)
+					healthCheckSuffix = strconv.FormatUint(target.EvmChainID, 10)
+				}
+
+				// connecting to a Blobstream contract
+				ethClient, err := ethclient.Dial(target.EvmRPC)
+				if err != nil {
+					return err
+				}
+				defer ethClient.Close()
+				// making sure the target is not misconfigured, as relaying to the wrong chain would go unnoticed.
+				chainID, err := ethClient.ChainID(ctx)
+				if err != nil {
+					return err
+				}
+				if !chainID.IsUint64() || chainID.Uint64() != target.EvmChainID {
+					return fmt.Errorf("evm target %d: configured evm chain id %d not matching the rpc one %s", i, target.EvmChainID, chainID.String())
+				}
+				common.AddEVMHealthCheck(healthServer, ethClient, healthCheckSuffix)
+				blobstreamWrapper, err := blobstreamwrapper.NewWrappers(ethcmn.HexToAddress(target.ContractAddr), ethClient)
+				if err != nil {
+					return err
+				}
+
+				evmClient := evm.NewClient(
+					targetLogger,
+					blobstreamWrapper,
+					signers[i],
+					target.EvmRPC,
+					target.EvmGasLimit,
+					target.GasPolicy,
+				)
+
+				var coordinator *relayer.Coordinator
+				if config.Relayers != "" {
+					coordinator, err = relayer.NewCoordinator(
+						config.RelayersList(),
+						signers[i].Address(),
+						target.EvmChainID,
+						time.Duration(config.backupRelayerWaitTime)*time.Minute,
+					)
+					if err != nil {
+						return fmt.Errorf("%s: %s", signers[i].Address().Hex(), err.Error())
+					}
+				}
+
+				relayers[i] = relayer.NewRelayer(
+					tmQuerier,
+					appQuerier,
+					p2pQuerier,
+					evmClient,
+					targetLogger,
+					helpers.NewRetrier(targetLogger, 6, time.Minute),
+					s.SignatureStore,
+					time.Duration(config.EVMRetryTimeout)*time.Minute,
+					config.isBackupRelayer,
+					time.Duration(config.backupRelayerWaitTime)*time.Minute,
+					relayerMeters.WithChainID(target.EvmChainID),
+					relayer.BatchConfig{
+						MulticallAddress: ethcmn.HexToAddress(target.MulticallAddr),
+						MaxBatchSize:     config.MaxBatchSize,
+						GasCap:           config.BatchGasCap,
+					},
+					config.MaxInFlight,
+					coordinator,
+				)
+				relayers[i].LowBalanceThreshold = config.LowBalanceThreshold
+				common.AddRelayerHealthCheck(healthServer, relayers[i], healthCheckSuffix)
+			}
 
 			// Listen for and trap any OS signal to graceful shutdown and exit
 			go helpers.TrapSignal(logger, cancel)
 
-			logger.Info("starting relayer")
-			err = relay.Start(ctx)
-			if err != nil {
-				return err
-			}
-			return nil
+			logger.Info("starting relayer", "targets", len(relayers))
+			return startRelayers(ctx, logger, targets, relayers)
 		},
 	}
 	return addRelayerStartFlags(command)
 }
+
+// startRelayers runs the relayers concurrently, and waits for all of them to stop.
+// A relayer failing doesn't stop the other ones.
+func startRelayers(ctx context.Context, logger tmlog.Logger, targets []EVMTargetConfig, relayers []*relayer.Relayer) error {
+	if len(relayers) == 1 {
+		return relayers[0].Start(ctx)
+	}
+
+	errs := make([]error, len(relayers))
+	wg := sync.WaitGroup{}
+	for i, relay := range relayers {
+		wg.Add(1)
+		go func(i int, relay *relayer.Relayer) {
+			defer wg.Done()
+			err := relay.Start(ctx)
+			if err != nil && ctx.Err() == nil {
+				logger.Error("relayer stopped", "evm_chain_id", targets[i].EvmChainID, "err", err.Error())
+				errs[i] = fmt.Errorf("evm chain %d: %w", targets[i].EvmChainID, err)
+			}
+		}(i, relay)
+	}
+	wg.Wait()
+	return stderrors.Join(errs...)
+}
@@ -3,13 +3,18 @@ package relayer
 import (
 	"bytes"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
 
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/pruner"
 	"github.com/celestiaorg/orchestrator-relayer/telemetry"
 
+	ethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -50,6 +55,10 @@ bootstrappers = "{{ .Bootstrappers }}"
 # MultiAddr for the p2p peer to listen on.
 listen-addr = "{{ .P2PListenAddr }}"
 
+# Forces putting the DHT records using the legacy JSON format. Otherwise, the compact binary format
+# is used whenever all the peers the records are put to advertise supporting it.
+json-records = "{{ .JSONRecords }}"
+
 ###############################################################################
 ###                         EVM Configuration                               ###
 ###############################################################################
@@ -70,6 +79,67 @@ gas-limit = "{{ .EvmGasLimit }}"
 # on the target EVM chain before recreating them with a different gas price.
 retry-timeout = "{{ .EVMRetryTimeout }}"
 
+# Address of the multicall contract used to relay multiple attestations
+# in a single transaction. If empty, attestations are relayed one by one.
+multicall-address = "{{ .MulticallAddr }}"
+
+# The maximum number of attestations to relay in a single transaction.
+max-batch-size = "{{ .MaxBatchSize }}"
+
+# The maximum gas a batch transaction can use. If 0, batches are only
+# limited by the max batch size.
+batch-gas-cap = "{{ .BatchGasCap }}"
+
+# The maximum number of transactions to keep in flight, i.e. submitted but
+# not yet mined. If higher than 1, the relayer submits the next attestations
+# without waiting for the previous transactions to be mined.
+# Cannot be used along with the multicall batching.
+max-in-flight = "{{ .MaxInFlight }}"
+
+# The balance, in ether, under which the relayer warns that its account needs
+# to be funded. If 0, the relayer doesn't warn.
+low-balance-threshold = "{{ .LowBalanceThreshold }}"
+
+###############################################################################
+###                         Coordination Configuration                      ###
+###############################################################################
+
+# Comma-separated EVM addresses of the relayers coordinating via the P2P network,
+# including this one. For every nonce, a leader is chosen from these relayers, and
+# the other ones only relay the attestation if the leader didn't relay it within
+# the --relayer.wait-time. If empty, the relayer doesn't coordinate with others.
+# Example: "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488,0x91DEd26b5f38B065FC0204c7929Da1b2A21877Ad"
+relayers = "{{ .Relayers }}"
+
+###############################################################################
+###                         Gas Policy Configuration                        ###
+###############################################################################
+[gas-policy]
+# The maximum gas price, in gwei, for legacy transactions, or the maximum fee
+# per gas for dynamic fee transactions. If 0, the fee is not capped.
+max-fee-cap = "{{ .GasPolicy.MaxFeeCap }}"
+
+# The maximum priority fee per gas, in gwei, for dynamic fee transactions.
+# If 0, the tip is not capped.
+max-tip-cap = "{{ .GasPolicy.MaxTipCap }}"
+
+# The minimum percentage by which the gas price is increased when speeding up
+# a transaction. Should be at least 10.
+bump-percent = "{{ .GasPolicy.BumpPercent }}"
+
+# The additional time, in seconds, to wait for a transaction to be mined after
+# each speed up.
+backoff = "{{ .GasPolicy.Backoff }}"
+
+# The base fee, in gwei, above which attestations are not relayed.
+# If 0, attestations are relayed regardless of the base fee.
+max-base-fee = "{{ .GasPolicy.MaxBaseFee }}"
+
+# The number of nonces the contract can be behind the Celestia chain before
+# attestations are relayed even if the base fee is above the max base fee.
+# If 0, the max base fee is always enforced.
+max-nonces-behind = "{{ .GasPolicy.MaxNoncesBehind }}"
+
 ###############################################################################
 ###                         Telemetry Configuration                         ###
 ###############################################################################
@@ -85,6 +155,96 @@ tls = "{{ .MetricsConfig.TLS }}"
 
 # Sets the HTTP endpoint for LibP2P metrics to listen on.
 p2p-endpoint = "{{ .MetricsConfig.P2PEndpoint }}"
+
+###############################################################################
+###                         Health Configuration                            ###
+###############################################################################
+[health]
+# Enables the /healthz and /readyz endpoints to be used as liveness and readiness probes.
+enable = "{{ .HealthConfig.Enable }}"
+
+# Sets the address for the health endpoints to listen on.
+listen-addr = "{{ .HealthConfig.ListenAddr }}"
+
+###############################################################################
+###                         Tracing Configuration                           ###
+###############################################################################
+[tracing]
+# Enables OpenTelemetry traces.
+enable = "{{ .TracingConfig.Enable }}"
+
+# Sets the traces exporter: "otlp" to export them to an OTLP collector over HTTP,
+# or "stdout" to print them.
+exporter = "{{ .TracingConfig.Exporter }}"
+
+# Sets HTTP endpoint for OTLP traces to be exported to.
+endpoint = "{{ .TracingConfig.Endpoint }}"
+
+# Enable TLS connection to OTLP traces backend.
+tls = "{{ .TracingConfig.TLS }}"
+
+###############################################################################
+###                         Retention Configuration                         ###
+###############################################################################
+[retention]
+# The number of latest attestation nonces whose confirms are kept in the stores.
+# If 0, the confirms are not pruned based on the number of nonces.
+nonces = "{{ .RetentionConfig.Nonces }}"
+
+# The number of days the confirms of an attestation are kept in the stores.
+# If 0, the confirms are not pruned based on their age.
+# If both the nonces and the days are set, the confirms are kept as long as
+# either of them retains them.
+days = "{{ .RetentionConfig.Days }}"
+
+# The time, in minutes, between two prunings of the stores.
+interval = "{{ .RetentionConfig.Interval }}"
+
+###############################################################################
+###                         P2P Gating Configuration                        ###
+###############################################################################
+[gating]
+# Only accepts the connections of the allowlisted peers, and of the peers proving
+# that their P2P key is bound to an EVM address of the current or previous valset.
+enable = "{{ .GatingConfig.Enable }}"
+
+# Comma-separated IDs, or multiaddresses, of the peers that are always accepted,
+# e.g. the relayers. The bootstrappers are always accepted.
+allowlist = "{{ .GatingConfig.Allowlist }}"
+
+###############################################################################
+###                         P2P Peer Scoring Configuration                  ###
+###############################################################################
+[peer-scoring]
+# Scores the P2P peers depending on the validity of the DHT records they return,
+# and bans the ones returning too many invalid records.
+enable = "{{ .ScoringConfig.Enable }}"
+
+###############################################################################
+###                         EVM Targets Configuration                       ###
+###############################################################################
+# The relayer can relay attestations to multiple Blobstream contracts, deployed
+# on different EVM chains, from a single process. To do so, define an
+# [[evm-targets]] table per target chain. If any target is defined, the above
+# EVM and gas policy configurations, along with the --evm.* flags, are ignored.
+# Example:
+#
+# [[evm-targets]]
+# evm-rpc = "http://localhost:8545"
+# evm-chain-id = "5"
+# contract-address = "0x..."
+# account = "0x..."
+# remote-signer = ""
+# gas-limit = "2500000"
+# multicall-address = ""
+#
+# [evm-targets.gas-policy]
+# max-fee-cap = "0"
+# max-tip-cap = "0"
+# bump-percent = "10"
+# backoff = "0"
+# max-base-fee = "0"
+# max-nonces-behind = "0"
 `
 
 func addRelayerStartFlags(cmd *cobra.Command) *cobra.Command {
@@ -94,6 +254,7 @@ func addRelayerStartFlags(cmd *cobra.Command) *cobra.Command {
 	}
 	base.AddHomeFlag(cmd, ServiceNameRelayer, homeDir)
 	base.AddEVMAccAddressFlag(cmd)
+	base.AddEVMRemoteSignerFlag(cmd)
 	base.AddEVMChainIDFlag(cmd)
 	base.AddCoreGRPCFlag(cmd)
 	base.AddCoreRPCFlag(cmd)
@@ -103,6 +264,7 @@ func addRelayerStartFlags(cmd *cobra.Command) *cobra.Command {
 	base.AddEVMPassphraseFlag(cmd)
 	base.AddP2PNicknameFlag(cmd)
 	base.AddP2PListenAddressFlag(cmd)
+	base.AddP2PJSONRecordsFlag(cmd)
 	base.AddBootstrappersFlag(cmd)
 	base.AddGRPCInsecureFlag(cmd)
 	base.AddLogLevelFlag(cmd)
@@ -110,10 +272,28 @@ func addRelayerStartFlags(cmd *cobra.Command) *cobra.Command {
 	base.AddEVMRetryTimeoutFlag(cmd)
 	base.AddBackupRelayerFlag(cmd)
 	base.AddBackupRelayerWaitTimeFlag(cmd)
+	base.AddMulticallAddressFlag(cmd)
+	base.AddMaxBatchSizeFlag(cmd)
+	base.AddBatchGasCapFlag(cmd)
+	base.AddMaxInFlightFlag(cmd)
+	base.AddLowBalanceThresholdFlag(cmd)
+	base.AddRelayersFlag(cmd)
 	base.AddMetricsFlag(cmd)
 	base.AddMetricsEndpointFlag(cmd)
 	base.AddMetricsTLSFlag(cmd)
 	base.AddP2PMetricsEndpoint(cmd)
+	base.AddHealthFlag(cmd)
+	base.AddHealthListenAddrFlag(cmd)
+	base.AddTracingFlag(cmd)
+	base.AddTracingExporterFlag(cmd)
+	base.AddTracingEndpointFlag(cmd)
+	base.AddTracingTLSFlag(cmd)
+	base.AddRetentionNoncesFlag(cmd)
+	base.AddRetentionDaysFlag(cmd)
+	base.AddRetentionIntervalFlag(cmd)
+	base.AddP2PGatingFlag(cmd)
+	base.AddP2PAllowlistFlag(cmd)
+	base.AddP2PPeerScoringFlag(cmd)
 
 	return cmd
 }
@@ -125,10 +305,12 @@ type StartConfig struct {
 	CoreGRPC              string `mapstructure:"core-grpc" json:"core-grpc"`
 	CoreRPC               string `mapstructure:"core-rpc" json:"core-rpc"`
 	evmAccAddress         string
+	evmRemoteSigner       string
 	ContractAddr          string `mapstructure:"contract-address" json:"contract-address"`
 	EvmGasLimit           uint64 `mapstructure:"gas-limit" json:"gas-limit"`
 	Bootstrappers         string `mapstructure:"bootstrappers" json:"bootstrappers"`
 	P2PListenAddr         string `mapstructure:"listen-addr" json:"listen-addr"`
+	JSONRecords           bool   `mapstructure:"json-records" json:"json-records"`
 	p2pNickname           string
 	GrpcInsecure          bool `mapstructure:"grpc-insecure" json:"grpc-insecure"`
 	LogLevel              string
@@ -136,42 +318,203 @@ type StartConfig struct {
 	EVMRetryTimeout       uint64 `mapstructure:"retry-timeout" json:"retry-timeout"`
 	isBackupRelayer       bool
 	backupRelayerWaitTime uint64
-	MetricsConfig         telemetry.Config `mapstructure:"telemetry" json:"telemetry"`
+	MulticallAddr         string                  `mapstructure:"multicall-address" json:"multicall-address"`
+	MaxBatchSize          uint64                  `mapstructure:"max-batch-size" json:"max-batch-size"`
+	BatchGasCap           uint64                  `mapstructure:"batch-gas-cap" json:"batch-gas-cap"`
+	MaxInFlight           uint64                  `mapstructure:"max-in-flight" json:"max-in-flight"`
+	LowBalanceThreshold   float64                 `mapstructure:"low-balance-threshold" json:"low-balance-threshold"`
+	Relayers              string                  `mapstructure:"relayers" json:"relayers"`
+	GasPolicy             evm.GasPolicy           `mapstructure:"gas-policy" json:"gas-policy"`
+	MetricsConfig         telemetry.Config        `mapstructure:"telemetry" json:"telemetry"`
+	HealthConfig          telemetry.HealthConfig  `mapstructure:"health" json:"health"`
+	TracingConfig         telemetry.TracingConfig `mapstructure:"tracing" json:"tracing"`
+	RetentionConfig       pruner.RetentionConfig  `mapstructure:"retention" json:"retention"`
+	GatingConfig          p2p.GatingConfig        `mapstructure:"gating" json:"gating"`
+	ScoringConfig         p2p.ScoringConfig       `mapstructure:"peer-scoring" json:"peer-scoring"`
+	EVMTargets            []EVMTargetConfig       `mapstructure:"evm-targets" json:"evm-targets"`
+}
+
+// EVMTargetConfig the configuration of an EVM chain, and the Blobstream contract deployed
+// on it, to relay attestations to.
+type EVMTargetConfig struct {
+	EvmChainID      uint64        `mapstructure:"evm-chain-id" json:"evm-chain-id"`
+	EvmRPC          string        `mapstructure:"evm-rpc" json:"evm-rpc"`
+	ContractAddr    string        `mapstructure:"contract-address" json:"contract-address"`
+	EvmAccAddress   string        `mapstructure:"account" json:"account"`
+	EvmRemoteSigner string        `mapstructure:"remote-signer" json:"remote-signer"`
+	EvmGasLimit     uint64        `mapstructure:"gas-limit" json:"gas-limit"`
+	MulticallAddr   string        `mapstructure:"multicall-address" json:"multicall-address"`
+	GasPolicy       evm.GasPolicy `mapstructure:"gas-policy" json:"gas-policy"`
+}
+
+// ValidateBasics validates the target configuration.
+func (cfg EVMTargetConfig) ValidateBasics() error {
+	if err := base.ValidateEVMAddress(cfg.EvmAccAddress); err != nil {
+		return fmt.Errorf("%s: account", err.Error())
+	}
+	if err := base.ValidateEVMAddress(cfg.ContractAddr); err != nil {
+		return fmt.Errorf("%s: contract address", err.Error())
+	}
+	if cfg.MulticallAddr != "" {
+		if err := base.ValidateEVMAddress(cfg.MulticallAddr); err != nil {
+			return fmt.Errorf("%s: multicall address", err.Error())
+		}
+	}
+	return cfg.GasPolicy.ValidateBasics()
+}
+
+// Targets returns the EVM targets to relay attestations to. If no target is defined
+// in the config file, the single target defined by the EVM configuration is returned.
+func (cfg StartConfig) Targets() []EVMTargetConfig {
+	if len(cfg.EVMTargets) == 0 {
+		return []EVMTargetConfig{{
+			EvmChainID:      cfg.EvmChainID,
+			EvmRPC:          cfg.EvmRPC,
+			ContractAddr:    cfg.ContractAddr,
+			EvmAccAddress:   cfg.evmAccAddress,
+			EvmRemoteSigner: cfg.evmRemoteSigner,
+			EvmGasLimit:     cfg.EvmGasLimit,
+			MulticallAddr:   cfg.MulticallAddr,
+			GasPolicy:       cfg.GasPolicy,
+		}}
+	}
+	targets := make([]EVMTargetConfig, len(cfg.EVMTargets))
+	for i, target := range cfg.EVMTargets {
+		if target.EvmGasLimit == 0 {
+			target.EvmGasLimit = cfg.EvmGasLimit
+		}
+		targets[i] = target
+	}
+	return targets
 }
 
 func DefaultStartConfig() *StartConfig {
 	return &StartConfig{
-		CoreRPC:         "tcp://localhost:26657",
-		CoreGRPC:        "localhost:9090",
-		Bootstrappers:   "",
-		P2PListenAddr:   "/ip4/0.0.0.0/tcp/30000",
-		GrpcInsecure:    true,
-		EvmChainID:      5,
-		EvmRPC:          "http://localhost:8545",
-		EvmGasLimit:     2500000,
-		EVMRetryTimeout: 15,
+		CoreRPC:             "tcp://localhost:26657",
+		CoreGRPC:            "localhost:9090",
+		Bootstrappers:       "",
+		P2PListenAddr:       "/ip4/0.0.0.0/tcp/30000",
+		GrpcInsecure:        true,
+		EvmChainID:          5,
+		EvmRPC:              "http://localhost:8545",
+		EvmGasLimit:         2500000,
+		EVMRetryTimeout:     15,
+		MaxBatchSize:        10,
+		MaxInFlight:         1,
+		LowBalanceThreshold: 0.1,
+		GasPolicy:           evm.DefaultGasPolicy(),
 		MetricsConfig: telemetry.Config{
 			Metrics:     false,
 			Endpoint:    "localhost:4318",
 			TLS:         false,
 			P2PEndpoint: "localhost:30001",
 		},
+		HealthConfig: telemetry.HealthConfig{
+			Enable:     false,
+			ListenAddr: "localhost:26701",
+		},
+		TracingConfig: telemetry.TracingConfig{
+			Enable:   false,
+			Exporter: telemetry.OTLPTracesExporter,
+			Endpoint: "localhost:4318",
+			TLS:      false,
+		},
+		RetentionConfig: pruner.RetentionConfig{
+			Nonces:   0,
+			Days:     0,
+			Interval: 60,
+		},
+		GatingConfig: p2p.GatingConfig{
+			Enable:    false,
+			Allowlist: "",
+		},
+		ScoringConfig: p2p.ScoringConfig{
+			Enable: false,
+		},
 	}
 }
 
 func (cfg StartConfig) ValidateBasics() error {
-	if err := base.ValidateEVMAddress(cfg.evmAccAddress); err != nil {
-		return fmt.Errorf("%s: flag --%s", err.Error(), base.FlagEVMAccAddress)
-	}
-	if err := base.ValidateEVMAddress(cfg.ContractAddr); err != nil {
-		return fmt.Errorf("%s: flag --%s", err.Error(), base.FlagEVMContractAddress)
+	if len(cfg.EVMTargets) == 0 {
+		if err := base.ValidateEVMAddress(cfg.evmAccAddress); err != nil {
+			return fmt.Errorf("%s: flag --%s", err.Error(), base.FlagEVMAccAddress)
+		}
+		if err := base.ValidateEVMAddress(cfg.ContractAddr); err != nil {
+			return fmt.Errorf("%s: flag --%s", err.Error(), base.FlagEVMContractAddress)
+		}
+		if cfg.MulticallAddr != "" {
+			if err := base.ValidateEVMAddress(cfg.MulticallAddr); err != nil {
+				return fmt.Errorf("%s: flag --%s", err.Error(), base.FlagMulticallAddress)
+			}
+		}
+		if err := cfg.GasPolicy.ValidateBasics(); err != nil {
+			return err
+		}
+	} else {
+		if cfg.evmAccAddress != "" {
+			return fmt.Errorf("flag --%s cannot be used when evm targets are defined in the config file", base.FlagEVMAccAddress)
+		}
+		chainIDs := make(map[uint64]struct{})
+		for i, target := range cfg.EVMTargets {
+			if err := target.ValidateBasics(); err != nil {
+				return fmt.Errorf("evm target %d: %s", i, err.Error())
+			}
+			if _, has := chainIDs[target.EvmChainID]; has {
+				return fmt.Errorf("evm target %d: duplicate evm chain id %d", i, target.EvmChainID)
+			}
+			chainIDs[target.EvmChainID] = struct{}{}
+			if cfg.MaxInFlight > 1 && target.MulticallAddr != "" {
+				return fmt.Errorf("evm target %d: cannot keep multiple transactions in flight while batching", i)
+			}
+		}
 	}
 	if cfg.isBackupRelayer && cfg.backupRelayerWaitTime == 0 {
 		return fmt.Errorf("backup relayer wait time cannot be 0 if backup relayer flag is set")
 	}
+	if cfg.LowBalanceThreshold < 0 {
+		return fmt.Errorf("low balance threshold cannot be negative: flag --%s", base.FlagLowBalanceThreshold)
+	}
+	if cfg.MaxInFlight > 1 && cfg.MulticallAddr != "" {
+		return fmt.Errorf("cannot keep multiple transactions in flight while batching: flags --%s and --%s", base.FlagMaxInFlight, base.FlagMulticallAddress)
+	}
+	if cfg.Relayers != "" {
+		for _, relayer := range strings.Split(cfg.Relayers, ",") {
+			if err := base.ValidateEVMAddress(strings.TrimSpace(relayer)); err != nil {
+				return fmt.Errorf("%s: flag --%s", err.Error(), base.FlagRelayers)
+			}
+		}
+		if cfg.isBackupRelayer {
+			return fmt.Errorf("the backup relayer flag --%s cannot be used along with the relayers coordination --%s", base.FlagBackupRelayer, base.FlagRelayers)
+		}
+		if cfg.backupRelayerWaitTime == 0 {
+			return fmt.Errorf("the wait time --%s cannot be 0 when coordinating with other relayers", base.FlagBackupRelayerWaitTime)
+		}
+	}
+	if cfg.HealthConfig.Enable {
+		if _, _, err := net.SplitHostPort(cfg.HealthConfig.ListenAddr); err != nil {
+			return fmt.Errorf("%s: flag --%s", err.Error(), base.FlagHealthListenAddr)
+		}
+	}
+	if cfg.TracingConfig.Enable {
+		if err := telemetry.ValidateTracesExporter(cfg.TracingConfig.Exporter); err != nil {
+			return fmt.Errorf("%s: flag --%s", err.Error(), base.FlagTracingExporter)
+		}
+	}
 	return nil
 }
 
+// RelayersList returns the EVM addresses of the relayers coordinating via the P2P network.
+func (cfg StartConfig) RelayersList() []ethcmn.Address {
+	if cfg.Relayers == "" {
+		return nil
+	}
+	relayers := make([]ethcmn.Address, 0)
+	for _, relayer := range strings.Split(cfg.Relayers, ",") {
+		relayers = append(relayers, ethcmn.HexToAddress(strings.TrimSpace(relayer)))
+	}
+	return relayers
+}
+
 func parseRelayerStartFlags(cmd *cobra.Command, fileConfig *StartConfig) (StartConfig, error) {
 	evmAccAddr, _, err := base.GetEVMAccAddressFlag(cmd)
 	if err != nil {
@@ -179,6 +522,12 @@ func parseRelayerStartFlags(cmd *cobra.Command, fileConfig *StartConfig) (StartC
 	}
 	fileConfig.evmAccAddress = evmAccAddr
 
+	remoteSigner, _, err := base.GetEVMRemoteSignerFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	fileConfig.evmRemoteSigner = remoteSigner
+
 	evmChainID, changed, err := base.GetEVMChainIDFlag(cmd)
 	if err != nil {
 		return StartConfig{}, err
@@ -246,6 +595,14 @@ func parseRelayerStartFlags(cmd *cobra.Command, fileConfig *StartConfig) (StartC
 		fileConfig.P2PListenAddr = p2pListenAddress
 	}
 
+	jsonRecords, changed, err := base.GetP2PJSONRecordsFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed {
+		fileConfig.JSONRecords = jsonRecords
+	}
+
 	p2pNickname, _, err := base.GetP2PNicknameFlag(cmd)
 	if err != nil {
 		return StartConfig{}, err
@@ -304,6 +661,54 @@ func parseRelayerStartFlags(cmd *cobra.Command, fileConfig *StartConfig) (StartC
 	}
 	fileConfig.backupRelayerWaitTime = backupRelayerWaitTime
 
+	multicallAddr, changed, err := base.GetMulticallAddressFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed {
+		fileConfig.MulticallAddr = multicallAddr
+	}
+
+	maxBatchSize, changed, err := base.GetMaxBatchSizeFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed {
+		fileConfig.MaxBatchSize = maxBatchSize
+	}
+
+	batchGasCap, changed, err := base.GetBatchGasCapFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed {
+		fileConfig.BatchGasCap = batchGasCap
+	}
+
+	maxInFlight, changed, err := base.GetMaxInFlightFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed {
+		fileConfig.MaxInFlight = maxInFlight
+	}
+
+	lowBalanceThreshold, changed, err := base.GetLowBalanceThresholdFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed {
+		fileConfig.LowBalanceThreshold = lowBalanceThreshold
+	}
+
+	relayers, changed, err := base.GetRelayersFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed {
+		fileConfig.Relayers = relayers
+	}
+
 	metrics, changed, err := base.GetMetricsFlag(cmd)
 	if err != nil {
 		return StartConfig{}, err
@@ -336,6 +741,43 @@ func parseRelayerStartFlags(cmd *cobra.Command, fileConfig *StartConfig) (StartC
 		fileConfig.MetricsConfig.P2PEndpoint = p2p
 	}
 
+	health, changed, err := base.GetHealthFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	if changed {
+		fileConfig.HealthConfig.Enable = health
+	}
+
+	healthListenAddr, changed, err := base.GetHealthListenAddrFlag(cmd)
+	if err != nil {
+		return StartConfig{}, err
+	}
+	// config files created before the health endpoints were added don't define their listen address.
+	if changed || fileConfig.HealthConfig.ListenAddr == "" {
+		fileConfig.HealthConfig.ListenAddr = healthListenAddr
+	}
+
+	err = base.ParseTracingFlags(cmd, &fileConfig.TracingConfig)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	err = base.ParseRetentionFlags(cmd, &fileConfig.RetentionConfig)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	err = base.ParseGatingFlags(cmd, &fileConfig.GatingConfig)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
+	err = base.ParseScoringFlags(cmd, &fileConfig.ScoringConfig)
+	if err != nil {
+		return StartConfig{}, err
+	}
+
 	return *fileConfig, nil
 }
 
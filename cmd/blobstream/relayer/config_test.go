@@ -0,0 +1,101 @@
+package relayer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/relayer"
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/stretchr/testify/assert"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartConfigTargets(t *testing.T) {
+	cfg := relayer.DefaultStartConfig()
+	cfg.EVMTargets = []relayer.EVMTargetConfig{
+		{
+			EvmChainID:    1,
+			EvmRPC:        "http://localhost:8545",
+			ContractAddr:  "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488",
+			EvmAccAddress: "0x95359c3348e189ef7781546e6E13c80230fC9fB5",
+		},
+		{
+			EvmChainID:    2,
+			EvmRPC:        "http://localhost:8546",
+			ContractAddr:  "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488",
+			EvmAccAddress: "0x95359c3348e189ef7781546e6E13c80230fC9fB5",
+			EvmGasLimit:   100,
+			GasPolicy:     evm.GasPolicy{MaxFeeCap: 10},
+		},
+	}
+	require.NoError(t, cfg.ValidateBasics())
+
+	targets := cfg.Targets()
+	require.Len(t, targets, 2)
+	// the gas limit defaults to the global one
+	assert.Equal(t, cfg.EvmGasLimit, targets[0].EvmGasLimit)
+	assert.Equal(t, uint64(100), targets[1].EvmGasLimit)
+	assert.Equal(t, uint64(10), targets[1].GasPolicy.MaxFeeCap)
+
+	cfg.EVMTargets[1].EvmChainID = 1
+	assert.Error(t, cfg.ValidateBasics())
+
+	cfg.EVMTargets[1].EvmChainID = 2
+	cfg.EVMTargets[1].ContractAddr = "invalid"
+	assert.Error(t, cfg.ValidateBasics())
+}
+
+func TestGetStartConfigWithTargets(t *testing.T) {
+	configPath := t.TempDir()
+	config := `
+evm-rpc = "http://localhost:8545"
+gas-limit = "2500000"
+
+[[evm-targets]]
+evm-rpc = "http://localhost:8546"
+evm-chain-id = "1"
+contract-address = "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488"
+account = "0x95359c3348e189ef7781546e6E13c80230fC9fB5"
+
+[evm-targets.gas-policy]
+max-fee-cap = "100"
+
+[[evm-targets]]
+evm-rpc = "http://localhost:8547"
+evm-chain-id = "2"
+contract-address = "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488"
+account = "0x95359c3348e189ef7781546e6E13c80230fC9fB5"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(configPath, "config.toml"), []byte(config), 0o600))
+
+	cfg, err := relayer.GetStartConfig(viper.New(), configPath)
+	require.NoError(t, err)
+	require.Len(t, cfg.EVMTargets, 2)
+	assert.Equal(t, "http://localhost:8546", cfg.EVMTargets[0].EvmRPC)
+	assert.Equal(t, uint64(1), cfg.EVMTargets[0].EvmChainID)
+	assert.Equal(t, uint64(100), cfg.EVMTargets[0].GasPolicy.MaxFeeCap)
+	assert.Equal(t, uint64(2), cfg.EVMTargets[1].EvmChainID)
+	assert.Equal(t, uint64(2500000), cfg.Targets()[1].EvmGasLimit)
+}
+
+func TestStartConfigRelayers(t *testing.T) {
+	cfg := relayer.DefaultStartConfig()
+	assert.Empty(t, cfg.RelayersList())
+
+	cfg.Relayers = "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488, 0x95359c3348e189ef7781546e6E13c80230fC9fB5"
+	relayers := cfg.RelayersList()
+	require.Len(t, relayers, 2)
+	assert.Equal(t, "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488", relayers[0].Hex())
+	assert.Equal(t, "0x95359c3348e189ef7781546e6E13c80230fC9fB5", relayers[1].Hex())
+
+	cfg.EVMTargets = []relayer.EVMTargetConfig{{
+		EvmChainID:    1,
+		EvmRPC:        "http://localhost:8545",
+		ContractAddr:  "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488",
+		EvmAccAddress: "0x95359c3348e189ef7781546e6E13c80230fC9fB5",
+	}}
+	cfg.Relayers = "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488,invalid"
+	assert.ErrorContains(t, cfg.ValidateBasics(), "valid EVM address is required")
+}
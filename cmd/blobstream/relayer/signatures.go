@@ -0,0 +1,260 @@
+package relayer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/base"
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/common"
+	"github.com/celestiaorg/orchestrator-relayer/relayer"
+	"github.com/celestiaorg/orchestrator-relayer/store"
+	"github.com/spf13/cobra"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	FlagSignaturesFrom       = "from"
+	FlagSignaturesTo         = "to"
+	FlagSignaturesEVMAddress = "evm-address"
+	FlagSignaturesOutput     = "output"
+
+	JSONOutput = "json"
+	CSVOutput  = "csv"
+)
+
+// SignaturesCommand reads the signatures saved to the relayer signature store.
+func SignaturesCommand() *cobra.Command {
+	sigsCmd := &cobra.Command{
+		Use:   "signatures",
+		Short: "Query and export the signatures relayed by the relayer",
+		Long: "Query and export the signatures saved to the relayer signature store when relaying attestations. " +
+			"The store is locked while the relayer is running, so the relayer needs to be stopped first.",
+		SilenceUsage: true,
+	}
+
+	sigsCmd.AddCommand(
+		SignaturesList(),
+		SignaturesGet(),
+		SignaturesExport(),
+	)
+
+	sigsCmd.SetHelpCommand(&cobra.Command{})
+
+	return sigsCmd
+}
+
+// SignaturesList prints the signatures in the provided nonces range.
+func SignaturesList() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "list",
+		Args:  cobra.NoArgs,
+		Short: "Print the signatures in the provided nonces range",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := parseSignaturesFlags(cmd)
+			if err != nil {
+				return err
+			}
+			signatures, err := querySignatures(cmd, config)
+			if err != nil {
+				return err
+			}
+			return writeSignatures(cmd.OutOrStdout(), config.output, signatures)
+		},
+	}
+	return addSignaturesFlags(&cmd, true)
+}
+
+// SignaturesGet prints the signatures of the provided nonce.
+func SignaturesGet() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "get <nonce>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Print the signatures of the provided nonce",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nonce, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			// a 0 nonce would be interpreted as an open range by the filter.
+			if nonce == 0 {
+				return fmt.Errorf("invalid nonce 0: nonces start at 1")
+			}
+			config, err := parseSignaturesFlags(cmd)
+			if err != nil {
+				return err
+			}
+			config.filter.FromNonce = nonce
+			config.filter.ToNonce = nonce
+			signatures, err := querySignatures(cmd, config)
+			if err != nil {
+				return err
+			}
+			return writeSignatures(cmd.OutOrStdout(), config.output, signatures)
+		},
+	}
+	return addSignaturesFlags(&cmd, false)
+}
+
+// SignaturesExport writes the signatures in the provided nonces range to a file.
+func SignaturesExport() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "export <path to file>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Export the signatures in the provided nonces range to a JSON or CSV file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := parseSignaturesFlags(cmd)
+			if err != nil {
+				return err
+			}
+			// querying the signatures first, so that the target file is left untouched if the
+			// store can't be opened, e.g. when the relayer is running.
+			signatures, err := querySignatures(cmd, config)
+			if err != nil {
+				return err
+			}
+			file, err := os.OpenFile(args[0], os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+			if err != nil {
+				return err
+			}
+			err = writeSignatures(file, config.output, signatures)
+			if err != nil {
+				_ = file.Close()
+				return err
+			}
+			return file.Close()
+		},
+	}
+	return addSignaturesFlags(&cmd, true)
+}
+
+// querySignatures reads the signatures selected by the config filter from the signature store.
+func querySignatures(cmd *cobra.Command, config signaturesConfig) ([]relayer.StoredSignature, error) {
+	logger, err := base.GetLogger(config.logLevel, config.logFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	openOptions := store.OpenOptions{
+		HasSignatureStore: true,
+		BadgerOptions:     store.DefaultBadgerOptions(config.home),
+	}
+	s, stopFuncs, err := common.OpenStore(logger, config.home, openOptions)
+	defer runStopFuncs(logger, stopFuncs)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures, err := relayer.QuerySignatures(cmd.Context(), s.SignatureStore, config.filter)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("queried signatures", "count", len(signatures))
+	return signatures, nil
+}
+
+// writeSignatures writes the signatures to the provided writer using the output format.
+func writeSignatures(w io.Writer, output string, signatures []relayer.StoredSignature) error {
+	switch output {
+	case JSONOutput:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(signatures)
+	case CSVOutput:
+		writer := csv.NewWriter(w)
+		err := writer.Write([]string{"type", "nonce", "evm_address", "digest", "signature"})
+		if err != nil {
+			return err
+		}
+		for _, signature := range signatures {
+			err := writer.Write([]string{
+				signature.Type,
+				strconv.FormatUint(signature.Nonce, 10),
+				signature.EvmAddress,
+				signature.Digest,
+				signature.Signature,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		return fmt.Errorf("unsupported output %q, expected %q or %q", output, JSONOutput, CSVOutput)
+	}
+}
+
+func runStopFuncs(logger tmlog.Logger, stopFuncs []func() error) {
+	for _, f := range stopFuncs {
+		err := f()
+		if err != nil {
+			logger.Error(err.Error())
+		}
+	}
+}
+
+func addSignaturesFlags(cmd *cobra.Command, withRange bool) *cobra.Command {
+	if withRange {
+		cmd.Flags().Uint64(FlagSignaturesFrom, 0, "The first nonce to include. If 0, starts from the first stored nonce")
+		cmd.Flags().Uint64(FlagSignaturesTo, 0, "The last nonce to include. If 0, ends at the last stored nonce")
+	}
+	cmd.Flags().String(FlagSignaturesEVMAddress, "", "If set, only the signatures of this orchestrator EVM address are included")
+	cmd.Flags().String(FlagSignaturesOutput, JSONOutput, "The output format: 'json' or 'csv'")
+	return addInitFlags(cmd)
+}
+
+type signaturesConfig struct {
+	InitConfig
+	filter relayer.SignaturesFilter
+	output string
+}
+
+func parseSignaturesFlags(cmd *cobra.Command) (signaturesConfig, error) {
+	initConfig, err := parseInitFlags(cmd)
+	if err != nil {
+		return signaturesConfig{}, err
+	}
+
+	var filter relayer.SignaturesFilter
+	if cmd.Flags().Lookup(FlagSignaturesFrom) != nil {
+		filter.FromNonce, err = cmd.Flags().GetUint64(FlagSignaturesFrom)
+		if err != nil {
+			return signaturesConfig{}, err
+		}
+		filter.ToNonce, err = cmd.Flags().GetUint64(FlagSignaturesTo)
+		if err != nil {
+			return signaturesConfig{}, err
+		}
+		if filter.ToNonce != 0 && filter.FromNonce > filter.ToNonce {
+			return signaturesConfig{}, fmt.Errorf("--%s cannot be higher than --%s", FlagSignaturesFrom, FlagSignaturesTo)
+		}
+	}
+
+	filter.EvmAddress, err = cmd.Flags().GetString(FlagSignaturesEVMAddress)
+	if err != nil {
+		return signaturesConfig{}, err
+	}
+	if filter.EvmAddress != "" {
+		if err := base.ValidateEVMAddress(filter.EvmAddress); err != nil {
+			return signaturesConfig{}, fmt.Errorf("%s: flag --%s", err.Error(), FlagSignaturesEVMAddress)
+		}
+	}
+
+	output, err := cmd.Flags().GetString(FlagSignaturesOutput)
+	if err != nil {
+		return signaturesConfig{}, err
+	}
+	if output != JSONOutput && output != CSVOutput {
+		return signaturesConfig{}, fmt.Errorf("unsupported output %q, expected %q or %q: flag --%s", output, JSONOutput, CSVOutput, FlagSignaturesOutput)
+	}
+
+	return signaturesConfig{
+		InitConfig: initConfig,
+		filter:     filter,
+		output:     output,
+	}, nil
+}
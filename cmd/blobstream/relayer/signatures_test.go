@@ -0,0 +1,83 @@
+package relayer_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/relayer"
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	relayerpkg "github.com/celestiaorg/orchestrator-relayer/relayer"
+	"github.com/celestiaorg/orchestrator-relayer/store"
+	blobstreamtypes "github.com/celestiaorg/orchestrator-relayer/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+func TestSignaturesCommand(t *testing.T) {
+	home := t.TempDir()
+	logger := tmlog.NewNopLogger()
+	require.NoError(t, store.Init(logger, home, store.InitOptions{NeedSignatureStore: true}))
+
+	openOptions := store.OpenOptions{
+		HasSignatureStore: true,
+		BadgerOptions:     store.DefaultBadgerOptions(home),
+	}
+	s, err := store.OpenStore(logger, home, openOptions)
+	require.NoError(t, err)
+	ctx := context.Background()
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		value, err := blobstreamtypes.MarshalDataCommitmentConfirm(blobstreamtypes.DataCommitmentConfirm{
+			Signature:  "0xsignature",
+			EthAddress: "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488",
+		})
+		require.NoError(t, err)
+		key := datastore.NewKey(p2p.GetDataCommitmentConfirmKey(nonce, "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488", "0xroot"))
+		require.NoError(t, s.SignatureStore.Put(ctx, key, value))
+	}
+	require.NoError(t, s.Close(logger, openOptions))
+
+	run := func(args ...string) string {
+		cmd := relayer.SignaturesCommand()
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetArgs(append(args, "--home", home))
+		require.NoError(t, cmd.Execute())
+		return out.String()
+	}
+
+	var signatures []relayerpkg.StoredSignature
+	require.NoError(t, json.Unmarshal([]byte(run("list", "--from", "2")), &signatures))
+	require.Len(t, signatures, 2)
+	assert.Equal(t, uint64(2), signatures[0].Nonce)
+	assert.Equal(t, relayerpkg.DataCommitmentSignatureType, signatures[0].Type)
+	assert.Equal(t, "0xroot", signatures[0].Digest)
+
+	assert.Equal(
+		t,
+		"type,nonce,evm_address,digest,signature\n"+
+			"data_commitment,1,0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488,0xroot,0xsignature\n",
+		run("get", "1", "--output", "csv"),
+	)
+
+	// the nonce 0 is rejected instead of listing all the signatures
+	cmd := relayer.SignaturesCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"get", "0", "--home", home})
+	assert.Error(t, cmd.Execute())
+
+	// the export target file is left untouched if the store can't be opened
+	target := filepath.Join(t.TempDir(), "signatures.json")
+	require.NoError(t, os.WriteFile(target, []byte("previous export"), 0o600))
+	cmd = relayer.SignaturesCommand()
+	cmd.SetArgs([]string{"export", target, "--home", filepath.Join(t.TempDir(), "missing")})
+	assert.Error(t, cmd.Execute())
+	content, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "previous export", string(content))
+}
@@ -0,0 +1,167 @@
+package relayer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/base"
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/common"
+	"github.com/celestiaorg/orchestrator-relayer/pruner"
+	"github.com/celestiaorg/orchestrator-relayer/store"
+	"github.com/spf13/cobra"
+)
+
+const FlagPruneBefore = "before"
+
+// StoreCommand manages the relayer stores.
+func StoreCommand() *cobra.Command {
+	storeCmd := &cobra.Command{
+		Use:          "store",
+		Short:        "Manage the relayer data and signature stores",
+		SilenceUsage: true,
+	}
+
+	storeCmd.AddCommand(
+		StorePrune(),
+	)
+
+	storeCmd.SetHelpCommand(&cobra.Command{})
+
+	return storeCmd
+}
+
+// StorePrune deletes the confirms outside the retention window from the relayer stores.
+func StorePrune() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "prune",
+		Args:  cobra.NoArgs,
+		Short: "Delete the confirms outside the retention window from the relayer stores, and reclaim their disk space",
+		Long: "Delete the confirms outside the retention window from the relayer data and signature stores, and reclaim their disk space. " +
+			"The retention policy is read from the config file, and can be overridden using the --retention.* flags. " +
+			"Celestia is queried to find the nonces outside the retention window, unless --before is set. " +
+			"The store is locked while the relayer is running, so the relayer needs to be stopped first.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := parseStorePruneFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			logger, err := base.GetLogger(config.logLevel, config.logFormat)
+			if err != nil {
+				return err
+			}
+
+			s, stopFuncs, err := common.OpenStore(logger, config.home, store.OpenOptions{
+				HasDataStore:      true,
+				BadgerOptions:     store.DefaultBadgerOptions(config.home),
+				HasSignatureStore: true,
+			})
+			defer func() { runStopFuncs(logger, stopFuncs) }()
+			if err != nil {
+				return err
+			}
+
+			storePruner := pruner.New(
+				logger,
+				nil,
+				config.retention,
+				nil,
+				pruner.Store{Name: pruner.DataStoreName, Datastore: s.DataStore},
+				pruner.Store{Name: pruner.SignatureStoreName, Datastore: s.SignatureStore},
+			)
+			if config.before != 0 {
+				return storePruner.PruneBefore(cmd.Context(), config.before)
+			}
+
+			_, appQuerier, stops, err := common.NewTmAndAppQuerier(logger, config.coreRPC, config.coreGRPC, config.grpcInsecure)
+			stopFuncs = append(stopFuncs, stops...)
+			if err != nil {
+				return err
+			}
+			storePruner.AppQuerier = appQuerier
+			return storePruner.Prune(cmd.Context())
+		},
+	}
+	cmd.Flags().Uint64(FlagPruneBefore, 0, "If set, deletes the confirms whose nonce is lower than this one regardless of the retention policy, without querying Celestia")
+	base.AddCoreGRPCFlag(&cmd)
+	base.AddCoreRPCFlag(&cmd)
+	base.AddGRPCInsecureFlag(&cmd)
+	base.AddRetentionNoncesFlag(&cmd)
+	base.AddRetentionDaysFlag(&cmd)
+	return addInitFlags(&cmd)
+}
+
+type storePruneConfig struct {
+	InitConfig
+	coreRPC      string
+	coreGRPC     string
+	grpcInsecure bool
+	retention    pruner.RetentionConfig
+	before       uint64
+}
+
+func parseStorePruneFlags(cmd *cobra.Command) (storePruneConfig, error) {
+	initConfig, err := parseInitFlags(cmd)
+	if err != nil {
+		return storePruneConfig{}, err
+	}
+
+	fileConfig, err := LoadFileConfiguration(initConfig.home)
+	if err != nil {
+		return storePruneConfig{}, err
+	}
+
+	coreRPC, changed, err := base.GetCoreRPCFlag(cmd)
+	if err != nil {
+		return storePruneConfig{}, err
+	}
+	if changed {
+		if !strings.HasPrefix(coreRPC, "tcp://") {
+			coreRPC = fmt.Sprintf("tcp://%s", coreRPC)
+		}
+		fileConfig.CoreRPC = coreRPC
+	}
+
+	coreGRPC, changed, err := base.GetCoreGRPCFlag(cmd)
+	if err != nil {
+		return storePruneConfig{}, err
+	}
+	if changed {
+		fileConfig.CoreGRPC = coreGRPC
+	}
+
+	grpcInsecure, changed, err := base.GetGRPCInsecureFlag(cmd)
+	if err != nil {
+		return storePruneConfig{}, err
+	}
+	if changed {
+		fileConfig.GrpcInsecure = grpcInsecure
+	}
+
+	err = base.ParseRetentionFlags(cmd, &fileConfig.RetentionConfig)
+	if err != nil {
+		return storePruneConfig{}, err
+	}
+
+	before, err := cmd.Flags().GetUint64(FlagPruneBefore)
+	if err != nil {
+		return storePruneConfig{}, err
+	}
+	if before == 0 && !fileConfig.RetentionConfig.Enabled() {
+		return storePruneConfig{}, fmt.Errorf(
+			"no retention policy defined: set the --%s or --%s flags, or --%s",
+			base.FlagRetentionNonces,
+			base.FlagRetentionDays,
+			FlagPruneBefore,
+		)
+	}
+
+	return storePruneConfig{
+		InitConfig:   initConfig,
+		coreRPC:      fileConfig.CoreRPC,
+		coreGRPC:     fileConfig.CoreGRPC,
+		grpcInsecure: fileConfig.GrpcInsecure,
+		retention:    fileConfig.RetentionConfig,
+		before:       before,
+	}, nil
+}
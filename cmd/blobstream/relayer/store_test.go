@@ -0,0 +1,64 @@
+package relayer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/relayer"
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/store"
+	"github.com/ipfs/go-datastore"
+	"github.com/multiformats/go-base32"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+func TestStorePruneCommand(t *testing.T) {
+	home := t.TempDir()
+	logger := tmlog.NewNopLogger()
+	require.NoError(t, store.Init(logger, home, store.InitOptions{NeedDataStore: true, NeedSignatureStore: true}))
+
+	openOptions := store.OpenOptions{
+		HasDataStore:      true,
+		HasSignatureStore: true,
+		BadgerOptions:     store.DefaultBadgerOptions(home),
+	}
+	confirmKey := func(nonce uint64) string {
+		return p2p.GetDataCommitmentConfirmKey(nonce, "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488", "0xroot")
+	}
+	// the DHT saves the records under the base32 encoding of their keys.
+	dhtKey := func(nonce uint64) datastore.Key {
+		return datastore.NewKey(base32.RawStdEncoding.EncodeToString([]byte(confirmKey(nonce))))
+	}
+
+	s, err := store.OpenStore(logger, home, openOptions)
+	require.NoError(t, err)
+	ctx := context.Background()
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		require.NoError(t, s.SignatureStore.Put(ctx, datastore.NewKey(confirmKey(nonce)), []byte("value")))
+		require.NoError(t, s.DataStore.Put(ctx, dhtKey(nonce), []byte("value")))
+	}
+	require.NoError(t, s.Close(logger, openOptions))
+
+	// a retention policy is required
+	cmd := relayer.StoreCommand()
+	cmd.SetArgs([]string{"prune", "--home", home})
+	assert.Error(t, cmd.Execute())
+
+	cmd = relayer.StoreCommand()
+	cmd.SetArgs([]string{"prune", "--before", "3", "--home", home})
+	require.NoError(t, cmd.Execute())
+
+	s, err = store.OpenStore(logger, home, openOptions)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, s.Close(logger, openOptions)) }()
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		has, err := s.SignatureStore.Has(ctx, datastore.NewKey(confirmKey(nonce)))
+		require.NoError(t, err)
+		assert.Equal(t, nonce == 3, has)
+		has, err = s.DataStore.Has(ctx, dhtKey(nonce))
+		require.NoError(t, err)
+		assert.Equal(t, nonce == 3, has)
+	}
+}
@@ -3,7 +3,9 @@ package root
 import (
 	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/bootstrapper"
 	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/generate"
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/monitor"
 	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/query"
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/verify"
 	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/version"
 
 	"github.com/celestiaorg/celestia-app/x/qgb/client"
@@ -23,14 +25,20 @@ func Cmd() *cobra.Command {
 		SilenceUsage: true,
 	}
 
+	// the contract verification is added to the Celestia verify command, so that all the
+	// verifications are under the same command.
+	verifyCmd := client.VerifyCmd()
+	verifyCmd.AddCommand(verify.ContractCommand())
+
 	rootCmd.AddCommand(
 		orchestrator.Command(),
 		relayer.Command(),
 		deploy.Command(),
-		client.VerifyCmd(),
+		verifyCmd,
 		generate.Command(),
 		query.Command(),
 		bootstrapper.Command(),
+		monitor.Command(),
 		version.Cmd,
 	)
 
@@ -0,0 +1,97 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+
+	blobstreamwrapper "github.com/celestiaorg/blobstream-contracts/v4/wrappers/Blobstream.sol"
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/base"
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/common"
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/celestiaorg/orchestrator-relayer/verifier"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// ContractCommand verifies the events emitted by a deployed Blobstream contract against Celestia.
+func ContractCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contract",
+		Args:  cobra.NoArgs,
+		Short: "Verifies the data root tuple roots and valset checkpoints committed to a deployed Blobstream contract",
+		Long: "Walks every DataRootTupleRootEvent and ValidatorSetUpdatedEvent emitted by the Blobstream contract" +
+			" between the provided EVM blocks, and recomputes the expected data root tuple roots and valset checkpoints" +
+			" from Celestia. A JSON report is written to stdout, and the command fails if any event doesn't match." +
+			" The events whose attestations are not in the Celestia state anymore are reported as unverified.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := parseContractFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			logger, err := base.GetLogger(config.logLevel, config.logFormat)
+			if err != nil {
+				return err
+			}
+
+			stopFuncs := make([]func() error, 0)
+			defer func() {
+				for _, f := range stopFuncs {
+					err := f()
+					if err != nil {
+						logger.Error(err.Error())
+					}
+				}
+			}()
+
+			tmQuerier, appQuerier, stops, err := common.NewTmAndAppQuerier(logger, config.coreRPC, config.coreGRPC, config.grpcInsecure)
+			stopFuncs = append(stopFuncs, stops...)
+			if err != nil {
+				return err
+			}
+
+			ethClient, err := ethclient.Dial(config.evmRPC)
+			if err != nil {
+				return err
+			}
+			defer ethClient.Close()
+			blobstreamWrapper, err := blobstreamwrapper.NewWrappers(ethcmn.HexToAddress(config.contractAddr), ethClient)
+			if err != nil {
+				return err
+			}
+
+			toBlock := config.toBlock
+			if toBlock == 0 {
+				toBlock, err = ethClient.BlockNumber(cmd.Context())
+				if err != nil {
+					return err
+				}
+			}
+
+			v := verifier.New(
+				logger,
+				appQuerier,
+				tmQuerier,
+				evm.NewClient(logger, blobstreamWrapper, nil, config.evmRPC, evm.DefaultEVMGasLimit, evm.DefaultGasPolicy()),
+			)
+			report, err := v.VerifyContract(cmd.Context(), config.fromBlock, toBlock, config.blockRange)
+			if err != nil {
+				return err
+			}
+
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			err = encoder.Encode(report)
+			if err != nil {
+				return err
+			}
+			if len(report.Mismatches) != 0 {
+				return fmt.Errorf("%d events don't match the attestations on Celestia", len(report.Mismatches))
+			}
+			return nil
+		},
+	}
+	return addContractFlags(cmd)
+}
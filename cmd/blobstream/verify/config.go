@@ -0,0 +1,123 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/celestiaorg/orchestrator-relayer/cmd/blobstream/base"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagFromBlock  = "from-block"
+	FlagToBlock    = "to-block"
+	FlagBlockRange = "block-range"
+)
+
+func addContractFlags(cmd *cobra.Command) *cobra.Command {
+	base.AddCoreRPCFlag(cmd)
+	base.AddCoreGRPCFlag(cmd)
+	base.AddGRPCInsecureFlag(cmd)
+	base.AddEVMRPCFlag(cmd)
+	base.AddEVMContractAddressFlag(cmd)
+	base.AddLogLevelFlag(cmd)
+	base.AddLogFormatFlag(cmd)
+	cmd.Flags().Uint64(FlagFromBlock, 0, "The EVM block from which the contract events are verified. Should be set to the contract deployment block to avoid scanning the whole chain")
+	cmd.Flags().Uint64(FlagToBlock, 0, "The EVM block up to which the contract events are verified. If 0, the events are verified up to the latest block")
+	cmd.Flags().Uint64(FlagBlockRange, 5000, "The number of EVM blocks whose events are queried at once")
+	return cmd
+}
+
+type contractConfig struct {
+	coreRPC, coreGRPC string
+	grpcInsecure      bool
+	evmRPC            string
+	contractAddr      string
+	logLevel          string
+	logFormat         string
+	fromBlock         uint64
+	toBlock           uint64
+	blockRange        uint64
+}
+
+func parseContractFlags(cmd *cobra.Command) (contractConfig, error) {
+	coreRPC, _, err := base.GetCoreRPCFlag(cmd)
+	if err != nil {
+		return contractConfig{}, err
+	}
+	if !strings.HasPrefix(coreRPC, "tcp://") {
+		coreRPC = fmt.Sprintf("tcp://%s", coreRPC)
+	}
+
+	coreGRPC, _, err := base.GetCoreGRPCFlag(cmd)
+	if err != nil {
+		return contractConfig{}, err
+	}
+
+	grpcInsecure, _, err := base.GetGRPCInsecureFlag(cmd)
+	if err != nil {
+		return contractConfig{}, err
+	}
+
+	evmRPC, _, err := base.GetEVMRPCFlag(cmd)
+	if err != nil {
+		return contractConfig{}, err
+	}
+
+	contractAddr, _, err := base.GetEVMContractAddressFlag(cmd)
+	if err != nil {
+		return contractConfig{}, err
+	}
+	if contractAddr == "" {
+		return contractConfig{}, fmt.Errorf("the contract address should be specified: flag --%s", base.FlagEVMContractAddress)
+	}
+	if !ethcmn.IsHexAddress(contractAddr) {
+		return contractConfig{}, fmt.Errorf("valid contract address flag is required: %s", base.FlagEVMContractAddress)
+	}
+
+	logLevel, _, err := base.GetLogLevelFlag(cmd)
+	if err != nil {
+		return contractConfig{}, err
+	}
+
+	logFormat, _, err := base.GetLogFormatFlag(cmd)
+	if err != nil {
+		return contractConfig{}, err
+	}
+
+	fromBlock, err := cmd.Flags().GetUint64(FlagFromBlock)
+	if err != nil {
+		return contractConfig{}, err
+	}
+
+	toBlock, err := cmd.Flags().GetUint64(FlagToBlock)
+	if err != nil {
+		return contractConfig{}, err
+	}
+	if toBlock != 0 && toBlock < fromBlock {
+		return contractConfig{}, fmt.Errorf("the --%s should be higher than the --%s", FlagToBlock, FlagFromBlock)
+	}
+
+	blockRange, err := cmd.Flags().GetUint64(FlagBlockRange)
+	if err != nil {
+		return contractConfig{}, err
+	}
+	if blockRange == 0 {
+		return contractConfig{}, errors.New("the block range should be strictly positive")
+	}
+
+	return contractConfig{
+		coreRPC:      coreRPC,
+		coreGRPC:     coreGRPC,
+		grpcInsecure: grpcInsecure,
+		evmRPC:       evmRPC,
+		contractAddr: contractAddr,
+		logLevel:     logLevel,
+		logFormat:    logFormat,
+		fromBlock:    fromBlock,
+		toBlock:      toBlock,
+		blockRange:   blockRange,
+	}, nil
+}
@@ -40,7 +40,7 @@ func TestDeployer(t *testing.T) {
 	bridge, err := network.GetLatestDeployedBlobstreamContract(ctx)
 	HandleNetworkError(t, network, err, false)
 
-	evmClient := evm.NewClient(nil, bridge, nil, nil, network.EVMRPC, evm.DefaultEVMGasLimit)
+	evmClient := evm.NewClient(nil, bridge, nil, network.EVMRPC, evm.DefaultEVMGasLimit, evm.DefaultGasPolicy())
 
 	eventNonce, err := evmClient.StateLastEventNonce(&bind.CallOpts{Context: ctx})
 	assert.NoError(t, err)
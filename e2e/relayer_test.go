@@ -59,7 +59,7 @@ func TestRelayerWithOneValidator(t *testing.T) {
 	err = network.WaitForRelayerToStart(ctx, bridge)
 	HandleNetworkError(t, network, err, false)
 
-	evmClient := evm.NewClient(nil, bridge, nil, nil, network.EVMRPC, evm.DefaultEVMGasLimit)
+	evmClient := evm.NewClient(nil, bridge, nil, network.EVMRPC, evm.DefaultEVMGasLimit, evm.DefaultGasPolicy())
 
 	err = network.WaitForEventNonce(ctx, bridge, latestNonce)
 	HandleNetworkError(t, network, err, false)
@@ -122,7 +122,7 @@ func TestRelayerWithTwoValidators(t *testing.T) {
 	err = network.WaitForRelayerToStart(ctx, bridge)
 	HandleNetworkError(t, network, err, false)
 
-	evmClient := evm.NewClient(nil, bridge, nil, nil, network.EVMRPC, evm.DefaultEVMGasLimit)
+	evmClient := evm.NewClient(nil, bridge, nil, network.EVMRPC, evm.DefaultEVMGasLimit, evm.DefaultGasPolicy())
 
 	latestNonce, err := network.GetLatestAttestationNonce(ctx)
 	require.NoError(t, err)
@@ -197,7 +197,7 @@ func TestRelayerWithMultipleValidators(t *testing.T) {
 	err = network.WaitForRelayerToStart(ctx, bridge)
 	HandleNetworkError(t, network, err, false)
 
-	evmClient := evm.NewClient(nil, bridge, nil, nil, network.EVMRPC, evm.DefaultEVMGasLimit)
+	evmClient := evm.NewClient(nil, bridge, nil, network.EVMRPC, evm.DefaultEVMGasLimit, evm.DefaultGasPolicy())
 
 	err = network.WaitForEventNonce(ctx, bridge, latestValset.Nonce)
 	HandleNetworkError(t, network, err, false)
@@ -284,7 +284,7 @@ func TestUpdatingTheDataCommitmentWindow(t *testing.T) {
 	err = network.WaitForRelayerToStart(ctx, bridge)
 	HandleNetworkError(t, network, err, false)
 
-	evmClient := evm.NewClient(nil, bridge, nil, nil, network.EVMRPC, evm.DefaultEVMGasLimit)
+	evmClient := evm.NewClient(nil, bridge, nil, network.EVMRPC, evm.DefaultEVMGasLimit, evm.DefaultGasPolicy())
 
 	err = network.WaitForEventNonce(ctx, bridge, nonceAfterTheWindowChanges)
 	HandleNetworkError(t, network, err, false)
@@ -4,4 +4,27 @@ import (
 	"errors"
 )
 
-var ErrInvalid = errors.New("invalid")
+var (
+	ErrInvalid = errors.New("invalid")
+
+	// ErrContractReverted is returned when a call to the Blobstream contract reverts with an
+	// error that doesn't have a typed equivalent.
+	ErrContractReverted = errors.New("contract reverted")
+	// ErrInvalidDataRootTupleRootNonce is returned when the data commitment nonce is not the one
+	// following the contract nonce, e.g. another relayer already relayed it.
+	ErrInvalidDataRootTupleRootNonce = errors.New("invalid data root tuple root nonce")
+	// ErrInvalidValidatorSetNonce is returned when the valset nonce is not the one
+	// following the contract nonce, e.g. another relayer already relayed it.
+	ErrInvalidValidatorSetNonce = errors.New("invalid validator set nonce")
+	// ErrInsufficientVotingPower is returned when the provided signatures don't reach the
+	// voting power threshold.
+	ErrInsufficientVotingPower = errors.New("insufficient voting power")
+	// ErrInvalidSignature is returned when one of the provided signatures is invalid.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrMalformedCurrentValidatorSet is returned when the provided validators and signatures
+	// lengths mismatch.
+	ErrMalformedCurrentValidatorSet = errors.New("malformed current validator set")
+	// ErrSuppliedValidatorSetInvalid is returned when the provided current validator set doesn't
+	// match the contract checkpoint.
+	ErrSuppliedValidatorSetInvalid = errors.New("supplied validator set invalid")
+)
@@ -6,9 +6,6 @@ import (
 	"math/big"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts"
-	"github.com/ethereum/go-ethereum/accounts/keystore"
-
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	coregethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -17,19 +14,21 @@ import (
 	blobstreamwrapper "github.com/celestiaorg/blobstream-contracts/v4/wrappers/Blobstream.sol"
 	proxywrapper "github.com/celestiaorg/blobstream-contracts/v4/wrappers/ERC1967Proxy.sol"
 	"github.com/celestiaorg/celestia-app/x/qgb/types"
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // DefaultEVMGasLimit the default gas limit to use when sending transactions to the EVM chain.
 const DefaultEVMGasLimit = uint64(2500000)
 
 type Client struct {
-	logger   tmlog.Logger
-	Wrapper  *blobstreamwrapper.Wrappers
-	Ks       *keystore.KeyStore
-	Acc      *accounts.Account
-	EvmRPC   string
-	GasLimit uint64
+	logger    tmlog.Logger
+	Wrapper   *blobstreamwrapper.Wrappers
+	Signer    Signer
+	EvmRPC    string
+	GasLimit  uint64
+	GasPolicy GasPolicy
 }
 
 // NewClient Creates a new EVM Client that can be used to deploy the Blobstream contract and
@@ -38,18 +37,18 @@ type Client struct {
 func NewClient(
 	logger tmlog.Logger,
 	wrapper *blobstreamwrapper.Wrappers,
-	ks *keystore.KeyStore,
-	acc *accounts.Account,
+	signer Signer,
 	evmRPC string,
 	gasLimit uint64,
+	gasPolicy GasPolicy,
 ) *Client {
 	return &Client{
-		logger:   logger,
-		Wrapper:  wrapper,
-		Ks:       ks,
-		Acc:      acc,
-		EvmRPC:   evmRPC,
-		GasLimit: gasLimit,
+		logger:    logger,
+		Wrapper:   wrapper,
+		Signer:    signer,
+		EvmRPC:    evmRPC,
+		GasLimit:  gasLimit,
+		GasPolicy: gasPolicy,
 	}
 }
 
@@ -130,7 +129,10 @@ func (ec *Client) UpdateValidatorSet(
 	newNonce, newThreshHold uint64,
 	currentValset, newValset types.Valset,
 	sigs []blobstreamwrapper.Signature,
-) (*coregethtypes.Transaction, error) {
+) (_ *coregethtypes.Transaction, err error) {
+	_, span := telemetry.StartSpan(optsContext(opts), "Client.UpdateValidatorSet", telemetry.Nonce(newNonce))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	// TODO in addition to the nonce, log more interesting information
 	ec.logger.Info("relaying valset", "nonce", newNonce)
 
@@ -144,17 +146,10 @@ func (ec *Client) UpdateValidatorSet(
 		return nil, err
 	}
 
-	var currentNonce uint64
-	if newValset.Nonce == 1 {
-		currentNonce = 0
-	} else {
-		currentNonce = currentValset.Nonce
-	}
-
 	tx, err := ec.Wrapper.UpdateValidatorSet(
 		opts,
 		big.NewInt(int64(newNonce)),
-		big.NewInt(int64(currentNonce)),
+		big.NewInt(int64(currentValsetNonce(currentValset, newValset))),
 		big.NewInt(int64(newThreshHold)),
 		ethVsHash,
 		ethVals,
@@ -173,7 +168,10 @@ func (ec *Client) SubmitDataRootTupleRoot(
 	newNonce uint64,
 	currentValset types.Valset,
 	sigs []blobstreamwrapper.Signature,
-) (*coregethtypes.Transaction, error) {
+) (_ *coregethtypes.Transaction, err error) {
+	_, span := telemetry.StartSpan(optsContext(opts), "Client.SubmitDataRootTupleRoot", telemetry.Nonce(newNonce))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	ethVals, err := ethValset(currentValset)
 	if err != nil {
 		return nil, err
@@ -193,9 +191,91 @@ func (ec *Client) SubmitDataRootTupleRoot(
 	return tx, nil
 }
 
+// SimulateUpdateValidatorSet simulates the validator set update against the pending block.
+// Returns the decoded contract error if the call would revert.
+func (ec *Client) SimulateUpdateValidatorSet(
+	ctx context.Context,
+	from gethcommon.Address,
+	newNonce, newThreshHold uint64,
+	currentValset, newValset types.Valset,
+	sigs []blobstreamwrapper.Signature,
+) error {
+	ethVals, err := ethValset(currentValset)
+	if err != nil {
+		return err
+	}
+
+	ethVsHash, err := newValset.Hash()
+	if err != nil {
+		return err
+	}
+
+	return ec.simulate(
+		ctx,
+		from,
+		"updateValidatorSet",
+		big.NewInt(int64(newNonce)),
+		big.NewInt(int64(currentValsetNonce(currentValset, newValset))),
+		big.NewInt(int64(newThreshHold)),
+		ethVsHash,
+		ethVals,
+		sigs,
+	)
+}
+
+// SimulateSubmitDataRootTupleRoot simulates the data root tuple root submission against the pending block.
+// Returns the decoded contract error if the call would revert.
+func (ec *Client) SimulateSubmitDataRootTupleRoot(
+	ctx context.Context,
+	from gethcommon.Address,
+	tupleRoot gethcommon.Hash,
+	newNonce uint64,
+	currentValset types.Valset,
+	sigs []blobstreamwrapper.Signature,
+) error {
+	ethVals, err := ethValset(currentValset)
+	if err != nil {
+		return err
+	}
+
+	return ec.simulate(
+		ctx,
+		from,
+		"submitDataRootTupleRoot",
+		big.NewInt(int64(newNonce)),
+		big.NewInt(int64(currentValset.Nonce)),
+		tupleRoot,
+		ethVals,
+		sigs,
+	)
+}
+
+// simulate calls the provided Blobstream contract method against the pending block.
+func (ec *Client) simulate(ctx context.Context, from gethcommon.Address, method string, params ...interface{}) error {
+	caller := blobstreamwrapper.WrappersCallerRaw{Contract: &ec.Wrapper.WrappersCaller}
+	err := caller.Call(&bind.CallOpts{Pending: true, From: from, Context: ctx}, nil, method, params...)
+	return DecodeRevertError(err)
+}
+
+// optsContext returns the context of the transaction options, or a background context if it's not set.
+func optsContext(opts *bind.TransactOpts) context.Context {
+	if opts.Context == nil {
+		return context.Background()
+	}
+	return opts.Context
+}
+
+// currentValsetNonce returns the nonce of the current valset as expected by the contract.
+func currentValsetNonce(currentValset, newValset types.Valset) uint64 {
+	if newValset.Nonce == 1 {
+		return 0
+	}
+	return currentValset.Nonce
+}
+
 // NewTransactionOpts creates a new transaction Opts to be used when submitting transactions.
 func (ec *Client) NewTransactionOpts(ctx context.Context) (*bind.TransactOpts, error) {
-	builder := newTransactOptsBuilder(ec.Ks, ec.Acc)
+	builder := newTransactOptsBuilder(ec.Signer, ec.GasPolicy)
 
 	ethClient, err := ethclient.Dial(ec.EvmRPC)
 	if err != nil {
@@ -225,12 +305,55 @@ func (ec *Client) StateLastValidatorSetCheckpoint(opts *bind.CallOpts) ([32]byte
 	return checkpoint, nil
 }
 
+// FilterDataRootTupleRootEvents returns the data root tuple root events emitted by the Blobstream
+// contract between the provided EVM blocks, both inclusive.
+// If the end block is nil, the events are queried up to the latest block.
+func (ec *Client) FilterDataRootTupleRootEvents(
+	ctx context.Context,
+	start uint64,
+	end *uint64,
+) ([]*blobstreamwrapper.WrappersDataRootTupleRootEvent, error) {
+	it, err := ec.Wrapper.FilterDataRootTupleRootEvent(&bind.FilterOpts{Start: start, End: end, Context: ctx}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	events := make([]*blobstreamwrapper.WrappersDataRootTupleRootEvent, 0)
+	for it.Next() {
+		events = append(events, it.Event)
+	}
+	return events, it.Error()
+}
+
+// FilterValidatorSetUpdatedEvents returns the validator set updated events emitted by the Blobstream
+// contract between the provided EVM blocks, both inclusive.
+// If the end block is nil, the events are queried up to the latest block.
+func (ec *Client) FilterValidatorSetUpdatedEvents(
+	ctx context.Context,
+	start uint64,
+	end *uint64,
+) ([]*blobstreamwrapper.WrappersValidatorSetUpdatedEvent, error) {
+	it, err := ec.Wrapper.FilterValidatorSetUpdatedEvent(&bind.FilterOpts{Start: start, End: end, Context: ctx}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	events := make([]*blobstreamwrapper.WrappersValidatorSetUpdatedEvent, 0)
+	for it.Next() {
+		events = append(events, it.Event)
+	}
+	return events, it.Error()
+}
+
 func (ec *Client) WaitForTransaction(
 	ctx context.Context,
 	backend bind.DeployBackend,
 	tx *coregethtypes.Transaction,
 	timeout time.Duration,
-) (*coregethtypes.Receipt, error) {
+) (_ *coregethtypes.Receipt, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "Client.WaitForTransaction", attribute.String("hash", tx.Hash().String()))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	ec.logger.Debug("waiting for transaction to be confirmed", "hash", tx.Hash().String())
 
 	ctx, cancel := context.WithTimeout(ctx, timeout)
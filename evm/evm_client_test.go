@@ -143,3 +143,55 @@ func (s *EVMTestSuite) TestUpdateValset() {
 	// check that the validator set was changed.
 	s.Equal(uint64(2), nonce)
 }
+
+func (s *EVMTestSuite) TestFilterEvents() {
+	// deploy a new bridge contract
+	_, _, _, err := s.Client.DeployBlobstreamContract(s.Chain.Auth, s.Chain.Backend, *s.InitVs, 1, true)
+	s.NoError(err)
+	s.Chain.Backend.Commit()
+
+	// the contract initialization doesn't emit any event
+	dcEvents, err := s.Client.FilterDataRootTupleRootEvents(context.TODO(), 0, nil)
+	s.NoError(err)
+	s.Empty(dcEvents)
+	vsEvents, err := s.Client.FilterValidatorSetUpdatedEvents(context.TODO(), 0, nil)
+	s.NoError(err)
+	s.Empty(vsEvents)
+
+	commitment := ethcmn.HexToHash("0x12345")
+	signBytes := types.DataCommitmentTupleRootSignBytes(big.NewInt(2), commitment[:])
+	ks := keystore.NewKeyStore(s.T().TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.ImportECDSA(s.VsPrivateKey, "123")
+	s.NoError(err)
+	err = ks.Unlock(acc, "123")
+	s.NoError(err)
+	signature, err := evm.NewEthereumSignature(signBytes.Bytes(), ks, acc)
+	s.NoError(err)
+	v, r, ss, err := evm.SigToVRS(ethcmn.Bytes2Hex(signature))
+	s.NoError(err)
+	tx, err := s.Client.SubmitDataRootTupleRoot(
+		s.Chain.Auth,
+		commitment,
+		2,
+		*s.InitVs,
+		[]wrapper.Signature{{V: v, R: r, S: ss}},
+	)
+	s.NoError(err)
+	s.Chain.Backend.Commit()
+	recp, err := s.Chain.Backend.TransactionReceipt(context.TODO(), tx.Hash())
+	s.NoError(err)
+	s.Equal(uint64(1), recp.Status)
+
+	dcEvents, err = s.Client.FilterDataRootTupleRootEvents(context.TODO(), 0, nil)
+	s.NoError(err)
+	s.Require().Len(dcEvents, 1)
+	s.Equal(uint64(2), dcEvents[0].Nonce.Uint64())
+	s.Equal([32]byte(commitment), dcEvents[0].DataRootTupleRoot)
+	s.Equal(tx.Hash(), dcEvents[0].Raw.TxHash)
+
+	// the events are filtered by block range
+	end := recp.BlockNumber.Uint64() - 1
+	dcEvents, err = s.Client.FilterDataRootTupleRootEvents(context.TODO(), 0, &end)
+	s.NoError(err)
+	s.Empty(dcEvents)
+}
@@ -6,22 +6,19 @@ import (
 	"math/big"
 	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts"
-	"github.com/ethereum/go-ethereum/accounts/keystore"
-
 	"github.com/pkg/errors"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	coregethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-// TODO: make gas price configurable.
 type transactOpsBuilder func(ctx context.Context, client *ethclient.Client, gasLim uint64) (*bind.TransactOpts, error)
 
-func newTransactOptsBuilder(ks *keystore.KeyStore, acc *accounts.Account) transactOpsBuilder {
+func newTransactOptsBuilder(signer Signer, gasPolicy GasPolicy) transactOpsBuilder {
 	return func(ctx context.Context, client *ethclient.Client, gasLim uint64) (*bind.TransactOpts, error) {
-		nonce, err := client.PendingNonceAt(ctx, acc.Address)
+		nonce, err := client.PendingNonceAt(ctx, signer.Address())
 		if err != nil {
 			return nil, err
 		}
@@ -31,19 +28,51 @@ func newTransactOptsBuilder(ks *keystore.KeyStore, acc *accounts.Account) transa
 			return nil, fmt.Errorf("failed to get Ethereum chain ID: %w", err)
 		}
 
-		auth, err := bind.NewKeyStoreTransactorWithChainID(ks, *acc, ethChainID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create Ethereum transactor: %w", err)
-		}
-
+		auth := NewSignerTransactor(ctx, signer, ethChainID)
 		auth.Nonce = new(big.Int).SetUint64(nonce)
 		auth.Value = big.NewInt(0) // in wei
 		auth.GasLimit = gasLim     // in units
 
+		// if the fees are not capped, they're set by the contract bindings using the suggested ones
+		if gasPolicy.IsCapped() {
+			head, err := client.HeaderByNumber(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+			if head.BaseFee != nil {
+				gasTipCap, err := client.SuggestGasTipCap(ctx)
+				if err != nil {
+					return nil, err
+				}
+				auth.GasTipCap, auth.GasFeeCap = gasPolicy.DynamicFees(gasTipCap, head.BaseFee)
+			} else {
+				// Chain is not London ready -> use legacy transaction
+				gasPrice, err := client.SuggestGasPrice(ctx)
+				if err != nil {
+					return nil, err
+				}
+				auth.GasPrice = gasPolicy.GasPrice(gasPrice)
+			}
+		}
+
 		return auth, nil
 	}
 }
 
+// NewSignerTransactor creates transaction options that use the provided signer
+// to sign transactions for the provided chain ID.
+func NewSignerTransactor(ctx context.Context, signer Signer, chainID *big.Int) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From: signer.Address(),
+		Signer: func(address ethcmn.Address, tx *coregethtypes.Transaction) (*coregethtypes.Transaction, error) {
+			if address != signer.Address() {
+				return nil, bind.ErrNotAuthorized
+			}
+			return signer.SignTx(ctx, tx, chainID)
+		},
+	}
+}
+
 const (
 	MalleabilityThreshold = "0x7fffffffffffffffffffffffffffffff5d576e7357a4501ddfe92f46681b20a0"
 	ZeroSValue            = "0x0000000000000000000000000000000000000000000000000000000000000000"
@@ -0,0 +1,152 @@
+package evm
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// DefaultGasBumpPercent the default minimum percentage by which the gas price is increased when
+// speeding up a transaction. It corresponds to the minimum increase required by Geth to replace
+// a pending transaction.
+const DefaultGasBumpPercent = uint64(10)
+
+// GasPolicy the policy used to price the transactions submitted to the EVM chain, and to speed them
+// up when they're not mined in time.
+// The gas prices are expressed in gwei.
+type GasPolicy struct {
+	// MaxFeeCap the maximum gas price for legacy transactions, or the maximum fee per gas for
+	// dynamic fee transactions. If 0, the fee is not capped.
+	MaxFeeCap uint64 `mapstructure:"max-fee-cap" json:"max-fee-cap"`
+	// MaxTipCap the maximum priority fee per gas for dynamic fee transactions. If 0, the tip is not capped.
+	MaxTipCap uint64 `mapstructure:"max-tip-cap" json:"max-tip-cap"`
+	// BumpPercent the minimum percentage by which the gas price is increased when speeding up a transaction.
+	// If 0, the DefaultGasBumpPercent is used.
+	BumpPercent uint64 `mapstructure:"bump-percent" json:"bump-percent"`
+	// Backoff the additional time, in seconds, to wait for a transaction to be mined after each speed up.
+	Backoff uint64 `mapstructure:"backoff" json:"backoff"`
+	// MaxBaseFee the base fee above which attestations are not relayed. If 0, attestations are relayed
+	// regardless of the base fee.
+	MaxBaseFee uint64 `mapstructure:"max-base-fee" json:"max-base-fee"`
+	// MaxNoncesBehind the number of nonces the contract can be behind the Celestia chain before
+	// attestations are relayed even if the base fee is above the max base fee.
+	// If 0, the max base fee is always enforced.
+	MaxNoncesBehind uint64 `mapstructure:"max-nonces-behind" json:"max-nonces-behind"`
+}
+
+// DefaultGasPolicy returns a gas policy that follows the gas prices suggested by the EVM node.
+func DefaultGasPolicy() GasPolicy {
+	return GasPolicy{
+		BumpPercent: DefaultGasBumpPercent,
+	}
+}
+
+// ValidateBasics validates the gas policy parameters.
+func (p GasPolicy) ValidateBasics() error {
+	if p.BumpPercent != 0 && p.BumpPercent < DefaultGasBumpPercent {
+		return fmt.Errorf("gas bump percent should be at least %d", DefaultGasBumpPercent)
+	}
+	if p.MaxFeeCap != 0 && p.MaxTipCap > p.MaxFeeCap {
+		return fmt.Errorf("max tip cap %d gwei cannot be higher than the max fee cap %d gwei", p.MaxTipCap, p.MaxFeeCap)
+	}
+	return nil
+}
+
+// IsCapped returns true if the policy caps the fees.
+func (p GasPolicy) IsCapped() bool {
+	return p.MaxFeeCap != 0 || p.MaxTipCap != 0
+}
+
+// GasPrice returns the gas price to use for a new legacy transaction.
+func (p GasPolicy) GasPrice(suggested *big.Int) *big.Int {
+	return capGwei(suggested, p.MaxFeeCap)
+}
+
+// DynamicFees returns the tip and fee cap to use for a new dynamic fee transaction.
+func (p GasPolicy) DynamicFees(suggestedTip *big.Int, baseFee *big.Int) (*big.Int, *big.Int) {
+	tip := capGwei(suggestedTip, p.MaxTipCap)
+	feeCap := capGwei(
+		// the DefaultElasticityMultiplier is used to define the wiggle room for the gas
+		// in EIP1559
+		new(big.Int).Add(tip, new(big.Int).Mul(baseFee, big.NewInt(params.DefaultElasticityMultiplier))),
+		p.MaxFeeCap,
+	)
+	return minBigInt(tip, feeCap), feeCap
+}
+
+// SpeedUpGasPrice returns the gas price to use when speeding up a legacy transaction.
+// The result can be lower than or equal to the current gas price if the cap is reached.
+func (p GasPolicy) SpeedUpGasPrice(current *big.Int, suggested *big.Int) *big.Int {
+	return p.GasPrice(maxBigInt(suggested, p.bump(current)))
+}
+
+// SpeedUpDynamicFees returns the tip and fee cap to use when speeding up a dynamic fee transaction.
+// The resulting fee cap can be lower than or equal to the current fee cap if the cap is reached.
+func (p GasPolicy) SpeedUpDynamicFees(
+	currentTip *big.Int,
+	currentFeeCap *big.Int,
+	suggestedTip *big.Int,
+	baseFee *big.Int,
+) (*big.Int, *big.Int) {
+	tip, feeCap := p.DynamicFees(maxBigInt(suggestedTip, p.bump(currentTip)), baseFee)
+	feeCap = capGwei(maxBigInt(feeCap, p.bump(currentFeeCap)), p.MaxFeeCap)
+	return minBigInt(tip, feeCap), feeCap
+}
+
+// RetryTimeout returns the time to wait for a transaction to be mined after the provided
+// number of speed up attempts.
+func (p GasPolicy) RetryTimeout(base time.Duration, attempt int) time.Duration {
+	return base + time.Duration(attempt)*time.Duration(p.Backoff)*time.Second
+}
+
+// ShouldRelay returns true if attestations can be relayed given the current base fee, and
+// the number of nonces the contract is behind the Celestia chain.
+// A nil base fee, i.e. a chain that doesn't support EIP-1559, always allows relaying.
+func (p GasPolicy) ShouldRelay(baseFee *big.Int, noncesBehind uint64) bool {
+	if p.MaxBaseFee == 0 || baseFee == nil {
+		return true
+	}
+	if p.MaxNoncesBehind != 0 && noncesBehind > p.MaxNoncesBehind {
+		return true
+	}
+	return baseFee.Cmp(gweiToWei(p.MaxBaseFee)) <= 0
+}
+
+// bump increases the provided gas price by the bump percentage, rounded up.
+func (p GasPolicy) bump(price *big.Int) *big.Int {
+	percent := p.BumpPercent
+	if percent == 0 {
+		percent = DefaultGasBumpPercent
+	}
+	bumped := new(big.Int).Mul(price, new(big.Int).SetUint64(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// capGwei caps the provided wei value to the provided gwei value. A zero cap means no cap.
+func capGwei(value *big.Int, capGwei uint64) *big.Int {
+	if capGwei == 0 {
+		return value
+	}
+	return minBigInt(value, gweiToWei(capGwei))
+}
+
+func gweiToWei(gwei uint64) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(gwei), big.NewInt(params.GWei))
+}
+
+func maxBigInt(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
+
+func minBigInt(a, b *big.Int) *big.Int {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,95 @@
+package evm_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(params.GWei))
+}
+
+func TestGasPolicySpeedUpGasPrice(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    evm.GasPolicy
+		current   *big.Int
+		suggested *big.Int
+		expected  *big.Int
+	}{
+		{
+			name:      "suggested gas price higher than the bumped one",
+			policy:    evm.DefaultGasPolicy(),
+			current:   gwei(10),
+			suggested: gwei(20),
+			expected:  gwei(20),
+		},
+		{
+			name:      "bumped gas price higher than the suggested one",
+			policy:    evm.GasPolicy{BumpPercent: 25},
+			current:   gwei(10),
+			suggested: gwei(10),
+			expected:  new(big.Int).Add(gwei(12), big.NewInt(5e8)),
+		},
+		{
+			name:      "default bump percent",
+			policy:    evm.GasPolicy{},
+			current:   gwei(10),
+			suggested: gwei(5),
+			expected:  gwei(11),
+		},
+		{
+			name:      "capped gas price",
+			policy:    evm.GasPolicy{MaxFeeCap: 15},
+			current:   gwei(10),
+			suggested: gwei(20),
+			expected:  gwei(15),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.policy.SpeedUpGasPrice(tt.current, tt.suggested))
+		})
+	}
+}
+
+func TestGasPolicySpeedUpDynamicFees(t *testing.T) {
+	policy := evm.GasPolicy{MaxFeeCap: 100, MaxTipCap: 5}
+
+	tip, feeCap := policy.SpeedUpDynamicFees(gwei(2), gwei(30), gwei(3), gwei(20))
+	assert.Equal(t, gwei(3), tip)
+	assert.Equal(t, gwei(43), feeCap)
+
+	tip, feeCap = policy.SpeedUpDynamicFees(gwei(5), gwei(95), gwei(10), gwei(60))
+	assert.Equal(t, gwei(5), tip)
+	assert.Equal(t, gwei(100), feeCap)
+}
+
+func TestGasPolicyShouldRelay(t *testing.T) {
+	policy := evm.GasPolicy{MaxBaseFee: 50, MaxNoncesBehind: 10}
+
+	assert.True(t, policy.ShouldRelay(gwei(40), 1))
+	assert.False(t, policy.ShouldRelay(gwei(60), 1))
+	assert.True(t, policy.ShouldRelay(gwei(60), 11))
+	assert.True(t, policy.ShouldRelay(nil, 1))
+	assert.True(t, evm.DefaultGasPolicy().ShouldRelay(gwei(1000), 1))
+	assert.False(t, evm.GasPolicy{MaxBaseFee: 50}.ShouldRelay(gwei(60), 100))
+}
+
+func TestGasPolicyRetryTimeout(t *testing.T) {
+	policy := evm.GasPolicy{Backoff: 30}
+	assert.Equal(t, time.Minute, policy.RetryTimeout(time.Minute, 0))
+	assert.Equal(t, 2*time.Minute, policy.RetryTimeout(time.Minute, 2))
+}
+
+func TestGasPolicyValidateBasics(t *testing.T) {
+	assert.NoError(t, evm.DefaultGasPolicy().ValidateBasics())
+	assert.NoError(t, evm.GasPolicy{}.ValidateBasics())
+	assert.Error(t, evm.GasPolicy{BumpPercent: 5}.ValidateBasics())
+	assert.Error(t, evm.GasPolicy{MaxFeeCap: 10, MaxTipCap: 20}.ValidateBasics())
+}
@@ -0,0 +1,94 @@
+package evm
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	coregethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// MulticallBytecode the creation bytecode of the multicall contract used to batch
+// multiple calls to the Blobstream contract in a single transaction.
+//
+// The contract expects the call data to be: the 32 bytes left-padded target address,
+// followed by a sequence of calls, each encoded as a 32 bytes big-endian length
+// followed by the call data.
+// It executes the calls in order, and reverts with the revert data of the first
+// failing call, if any.
+//
+// The runtime code is the following:
+//
+//	0x00 PUSH1 0x00 CALLDATALOAD           ; target
+//	0x03 PUSH1 0x20                        ; offset
+//	0x05 JUMPDEST                          ; loop
+//	0x06 CALLDATASIZE DUP2 LT PUSH1 0x0d JUMPI
+//	0x0c STOP                              ; all calls executed
+//	0x0d JUMPDEST                          ; body
+//	0x0e DUP1 CALLDATALOAD                 ; length
+//	0x10 DUP1 PUSH1 0x20 DUP4 ADD PUSH1 0x00 CALLDATACOPY
+//	0x18 PUSH1 0x00 PUSH1 0x00 DUP3 PUSH1 0x00 PUSH1 0x00 DUP8 GAS CALL
+//	0x24 PUSH1 0x30 JUMPI
+//	0x27 RETURNDATASIZE PUSH1 0x00 DUP1 RETURNDATACOPY RETURNDATASIZE PUSH1 0x00 REVERT
+//	0x30 JUMPDEST                          ; call succeeded
+//	0x31 ADD PUSH1 0x20 ADD                ; offset += length + 32
+//	0x35 PUSH1 0x05 JUMP
+const MulticallBytecode = "0x603880600b6000396000f3" +
+	"60003560205b368110600d57005b80358060208301600037" +
+	"600060008260006000875af16030573d6000803e3d6000fd" +
+	"5b01602001600556"
+
+// EncodeMulticall encodes the provided calls to the target contract in the format
+// expected by the multicall contract.
+func EncodeMulticall(target gethcommon.Address, calls [][]byte) []byte {
+	encoded := gethcommon.LeftPadBytes(target.Bytes(), 32)
+	for _, call := range calls {
+		encoded = append(encoded, gethcommon.LeftPadBytes(big.NewInt(int64(len(call))).Bytes(), 32)...)
+		encoded = append(encoded, call...)
+	}
+	return encoded
+}
+
+// DeployMulticall deploys the multicall contract.
+func (ec *Client) DeployMulticall(opts *bind.TransactOpts, backend bind.ContractBackend) (
+	gethcommon.Address,
+	*coregethtypes.Transaction,
+	error,
+) {
+	address, tx, _, err := bind.DeployContract(opts, abi.ABI{}, gethcommon.FromHex(MulticallBytecode), backend)
+	if err != nil {
+		return gethcommon.Address{}, nil, err
+	}
+	ec.logger.Info("deploying multicall contract...", "address", address.Hex(), "tx_hash", tx.Hash().Hex())
+	return address, tx, nil
+}
+
+// SubmitMulticall submits the provided calls to the target contract in a single transaction
+// through the multicall contract deployed at the multicall address.
+func (ec *Client) SubmitMulticall(
+	opts *bind.TransactOpts,
+	backend bind.ContractBackend,
+	multicall gethcommon.Address,
+	target gethcommon.Address,
+	calls [][]byte,
+) (*coregethtypes.Transaction, error) {
+	contract := bind.NewBoundContract(multicall, abi.ABI{}, backend, backend, backend)
+	return contract.RawTransact(opts, EncodeMulticall(target, calls))
+}
+
+// NewCallDataTransactOpts creates transaction options that can be used with the contract
+// bindings to build the transactions, and get their call data, without signing nor sending them.
+func NewCallDataTransactOpts(from gethcommon.Address, gasLimit uint64) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From:     from,
+		Nonce:    big.NewInt(0),
+		Value:    big.NewInt(0),
+		GasPrice: big.NewInt(0),
+		GasLimit: gasLimit,
+		NoSend:   true,
+		Signer: func(_ gethcommon.Address, tx *coregethtypes.Transaction) (*coregethtypes.Transaction, error) {
+			return tx, nil
+		},
+	}
+}
@@ -0,0 +1,85 @@
+package evm_test
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+
+	wrapper "github.com/celestiaorg/blobstream-contracts/v4/wrappers/Blobstream.sol"
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func (s *EVMTestSuite) TestSubmitMulticall() {
+	// deploy a new bridge contract and the multicall contract
+	bridgeAddr, _, _, err := s.Client.DeployBlobstreamContract(s.Chain.Auth, s.Chain.Backend, *s.InitVs, 1, true)
+	s.NoError(err)
+	multicallAddr, _, err := s.Client.DeployMulticall(s.Chain.Auth, s.Chain.Backend)
+	s.NoError(err)
+	s.Chain.Backend.Commit()
+
+	ks := keystore.NewKeyStore(s.T().TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.ImportECDSA(s.VsPrivateKey, "123")
+	s.NoError(err)
+	err = ks.Unlock(acc, "123")
+	s.NoError(err)
+
+	// build the call data of consecutive data commitments
+	callData := func(nonce uint64) []byte {
+		commitment := ethcmn.BigToHash(big.NewInt(int64(nonce)))
+		signBytes := types.DataCommitmentTupleRootSignBytes(big.NewInt(int64(nonce)), commitment[:])
+		signature, err := evm.NewEthereumSignature(signBytes.Bytes(), ks, acc)
+		s.NoError(err)
+		v, r, ss, err := evm.SigToVRS(ethcmn.Bytes2Hex(signature))
+		s.NoError(err)
+		tx, err := s.Client.SubmitDataRootTupleRoot(
+			evm.NewCallDataTransactOpts(acc.Address, s.Client.GasLimit),
+			commitment,
+			nonce,
+			*s.InitVs,
+			[]wrapper.Signature{{V: v, R: r, S: ss}},
+		)
+		s.NoError(err)
+		s.Equal(bridgeAddr, *tx.To())
+		return tx.Data()
+	}
+
+	tx, err := s.Client.SubmitMulticall(
+		s.Chain.Auth,
+		s.Chain.Backend,
+		multicallAddr,
+		bridgeAddr,
+		[][]byte{callData(2), callData(3), callData(4)},
+	)
+	s.NoError(err)
+	s.Chain.Backend.Commit()
+
+	recp, err := s.Chain.Backend.TransactionReceipt(context.TODO(), tx.Hash())
+	s.NoError(err)
+	s.Equal(uint64(1), recp.Status)
+
+	nonce, err := s.Client.StateLastEventNonce(nil)
+	s.NoError(err)
+	s.Equal(uint64(4), nonce)
+
+	// a batch with a failing call is reverted entirely
+	tx, err = s.Client.SubmitMulticall(
+		s.Chain.Auth,
+		s.Chain.Backend,
+		multicallAddr,
+		bridgeAddr,
+		[][]byte{callData(5), callData(7)},
+	)
+	s.NoError(err)
+	s.Chain.Backend.Commit()
+
+	recp, err = s.Chain.Backend.TransactionReceipt(context.TODO(), tx.Hash())
+	s.NoError(err)
+	s.Equal(uint64(0), recp.Status)
+
+	nonce, err = s.Client.StateLastEventNonce(nil)
+	s.NoError(err)
+	s.Equal(uint64(4), nonce)
+}
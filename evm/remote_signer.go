@@ -0,0 +1,152 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	coregethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+var _ Signer = &RemoteSigner{}
+
+// RemoteSigner a Signer that delegates the signing to an external signer exposing
+// the Ethereum JSON-RPC signing methods, i.e. `eth_sign` and `eth_signTransaction`.
+// This is compatible with signers like Web3Signer and Clef.
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// NewRemoteSigner creates a new remote signer connected to the provided JSON-RPC endpoint.
+// The address is the EVM address of the account, managed by the remote signer, that will be used for signing.
+// Should be closed after usage.
+func NewRemoteSigner(ctx context.Context, endpoint string, address common.Address) (*RemoteSigner, error) {
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteSigner{
+		client:  client,
+		address: address,
+	}, nil
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignDigest requests an eip-191 signature over the digest using `eth_sign`.
+// The returned signature is normalized to have a recovery ID of 0 or 1, and
+// is checked to be signed by the signer address.
+func (s *RemoteSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	var signature hexutil.Bytes
+	err := s.client.CallContext(ctx, &signature, "eth_sign", s.address, hexutil.Bytes(digest))
+	if err != nil {
+		return nil, errors.Wrap(err, "remote signer eth_sign")
+	}
+	if len(signature) != 65 {
+		return nil, errors.Wrap(ErrInvalid, "remote signer signature length")
+	}
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+	if err := ValidateEthereumSignature(digest, signature, s.address); err != nil {
+		return nil, errors.Wrap(err, "remote signer signature")
+	}
+	return signature, nil
+}
+
+// remoteTransactionArgs the transaction arguments sent to the remote signer.
+type remoteTransactionArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+// SignTx requests the signature of the transaction using `eth_signTransaction`.
+// The remote signer can either return the raw signed transaction, or an object
+// containing it in its `raw` field.
+func (s *RemoteSigner) SignTx(ctx context.Context, tx *coregethtypes.Transaction, chainID *big.Int) (*coregethtypes.Transaction, error) {
+	args := remoteTransactionArgs{
+		From:    s.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.Type() == coregethtypes.DynamicFeeTxType {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+
+	var result json.RawMessage
+	err := s.client.CallContext(ctx, &result, "eth_signTransaction", args)
+	if err != nil {
+		return nil, errors.Wrap(err, "remote signer eth_signTransaction")
+	}
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err != nil {
+		var signed struct {
+			Raw hexutil.Bytes `json:"raw"`
+		}
+		if err := json.Unmarshal(result, &signed); err != nil {
+			return nil, errors.Wrap(err, "remote signer eth_signTransaction result")
+		}
+		raw = signed.Raw
+	}
+
+	signedTx := new(coregethtypes.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, errors.Wrap(err, "decoding remote signer transaction")
+	}
+	// the transaction should be replay protected, and for the requested chain only
+	if !signedTx.Protected() {
+		return nil, errors.Wrap(ErrInvalid, "remote signer transaction not replay protected")
+	}
+	if signedTx.ChainId().Cmp(chainID) != 0 {
+		return nil, errors.Wrap(ErrInvalid, "remote signer transaction chain ID not matching")
+	}
+	sender, err := coregethtypes.Sender(coregethtypes.LatestSignerForChainID(chainID), signedTx)
+	if err != nil {
+		return nil, errors.Wrap(err, "remote signer transaction sender")
+	}
+	if sender != s.address {
+		return nil, errors.Wrap(ErrInvalid, "remote signer transaction sender not matching")
+	}
+	// the remote signer shouldn't modify the transaction parameters
+	if signedTx.Type() != tx.Type() ||
+		signedTx.Nonce() != tx.Nonce() ||
+		signedTx.Gas() != tx.Gas() ||
+		signedTx.GasPrice().Cmp(tx.GasPrice()) != 0 ||
+		signedTx.GasFeeCap().Cmp(tx.GasFeeCap()) != 0 ||
+		signedTx.GasTipCap().Cmp(tx.GasTipCap()) != 0 ||
+		signedTx.Value().Cmp(tx.Value()) != 0 ||
+		!bytes.Equal(signedTx.Data(), tx.Data()) ||
+		(signedTx.To() == nil) != (tx.To() == nil) ||
+		(tx.To() != nil && *signedTx.To() != *tx.To()) {
+		return nil, errors.Wrap(ErrInvalid, "remote signer transaction not matching the requested one")
+	}
+	return signedTx, nil
+}
+
+// Close closes the connection to the remote signer.
+func (s *RemoteSigner) Close() {
+	s.client.Close()
+}
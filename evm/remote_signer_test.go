@@ -0,0 +1,161 @@
+package evm_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	coregethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSignerService a stub remote signer exposing the `eth_sign` and `eth_signTransaction` methods.
+type stubSignerService struct {
+	privKey *ecdsa.PrivateKey
+	// inflateFees if true, the gas price of the signed transactions is doubled.
+	inflateFees bool
+	// unprotected if true, the transactions are signed without the EIP-155 replay protection.
+	unprotected bool
+	// otherChain if true, the transactions are signed for a different chain ID than the requested one.
+	otherChain bool
+}
+
+func (s *stubSignerService) Sign(_ ethcmn.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	protectedHash := crypto.Keccak256Hash([]byte("\x19Ethereum Signed Message:\n32"), data)
+	signature, err := crypto.Sign(protectedHash.Bytes(), s.privKey)
+	if err != nil {
+		return nil, err
+	}
+	// remote signers return the signature with a recovery ID of 27 or 28
+	signature[64] += 27
+	return signature, nil
+}
+
+type stubTransactionArgs struct {
+	To       *ethcmn.Address `json:"to"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Nonce    hexutil.Uint64  `json:"nonce"`
+	Data     hexutil.Bytes   `json:"data"`
+	ChainID  *hexutil.Big    `json:"chainId"`
+}
+
+func (s *stubSignerService) SignTransaction(args stubTransactionArgs) (map[string]hexutil.Bytes, error) {
+	gasPrice := args.GasPrice.ToInt()
+	if s.inflateFees {
+		gasPrice = new(big.Int).Mul(gasPrice, big.NewInt(2))
+	}
+	tx := coregethtypes.NewTx(&coregethtypes.LegacyTx{
+		Nonce:    uint64(args.Nonce),
+		GasPrice: gasPrice,
+		Gas:      uint64(args.Gas),
+		To:       args.To,
+		Value:    args.Value.ToInt(),
+		Data:     args.Data,
+	})
+	var signer coregethtypes.Signer
+	switch {
+	case s.unprotected:
+		signer = coregethtypes.HomesteadSigner{}
+	case s.otherChain:
+		signer = coregethtypes.LatestSignerForChainID(new(big.Int).Add(args.ChainID.ToInt(), big.NewInt(1)))
+	default:
+		signer = coregethtypes.LatestSignerForChainID(args.ChainID.ToInt())
+	}
+	signedTx, err := coregethtypes.SignTx(tx, signer, s.privKey)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]hexutil.Bytes{"raw": raw}, nil
+}
+
+func newStubRemoteSigner(t *testing.T, service *stubSignerService) string {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", service))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return httpServer.URL
+}
+
+func TestRemoteSigner(t *testing.T) {
+	ctx := context.Background()
+	testPrivateKey, err := crypto.HexToECDSA("64a1d6f0e760a8d62b4afdde4096f16f51b401eaaecc915740f71770ea76a8ad")
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(testPrivateKey.PublicKey)
+	endpoint := newStubRemoteSigner(t, &stubSignerService{privKey: testPrivateKey})
+
+	signer, err := evm.NewRemoteSigner(ctx, endpoint, address)
+	require.NoError(t, err)
+	defer signer.Close()
+	assert.Equal(t, address, signer.Address())
+
+	// the remote signature should be the same as the keystore one
+	digest, err := hexutil.Decode("0x078c42ff72a01b355f9d76bfeecd2132a0d3f1aad9380870026c56e23e6d00e5")
+	require.NoError(t, err)
+	signature, err := signer.SignDigest(ctx, digest)
+	require.NoError(t, err)
+	assert.Equal(t, "ca2aa01f5b32722238e8f45356878e2cfbdc7c3335fbbf4e1dc3dfc53465e3e137103769d6956414014ae340cc4cb97384b2980eea47942f135931865471031a00", ethcmn.Bytes2Hex(signature))
+
+	to := ethcmn.HexToAddress("0x9c2B12b5a07FC6D719Ed7646e5041A7E85758329")
+	tx := coregethtypes.NewTx(&coregethtypes.LegacyTx{
+		Nonce:    10,
+		GasPrice: big.NewInt(1000),
+		Gas:      21000,
+		To:       &to,
+		Value:    big.NewInt(0),
+		Data:     []byte{0x1, 0x2},
+	})
+	chainID := big.NewInt(5)
+	signedTx, err := signer.SignTx(ctx, tx, chainID)
+	require.NoError(t, err)
+	sender, err := coregethtypes.Sender(coregethtypes.LatestSignerForChainID(chainID), signedTx)
+	require.NoError(t, err)
+	assert.Equal(t, address, sender)
+	assert.Equal(t, tx.Nonce(), signedTx.Nonce())
+
+	// a remote signer using a different key should be rejected
+	otherSigner, err := evm.NewRemoteSigner(ctx, endpoint, ethcmn.HexToAddress("0x91DEd26b5f38B065FC0204c7929Da1b2A21877Ad"))
+	require.NoError(t, err)
+	defer otherSigner.Close()
+	_, err = otherSigner.SignDigest(ctx, digest)
+	assert.Error(t, err)
+	_, err = otherSigner.SignTx(ctx, tx, chainID)
+	assert.Error(t, err)
+
+	// a remote signer inflating the transaction fees should be rejected
+	inflatingSigner, err := evm.NewRemoteSigner(ctx, newStubRemoteSigner(t, &stubSignerService{privKey: testPrivateKey, inflateFees: true}), address)
+	require.NoError(t, err)
+	defer inflatingSigner.Close()
+	_, err = inflatingSigner.SignTx(ctx, tx, chainID)
+	assert.ErrorIs(t, err, evm.ErrInvalid)
+
+	// a remote signer returning a transaction without replay protection should be rejected
+	unprotectedSigner, err := evm.NewRemoteSigner(ctx, newStubRemoteSigner(t, &stubSignerService{privKey: testPrivateKey, unprotected: true}), address)
+	require.NoError(t, err)
+	defer unprotectedSigner.Close()
+	_, err = unprotectedSigner.SignTx(ctx, tx, chainID)
+	assert.ErrorIs(t, err, evm.ErrInvalid)
+
+	// a remote signer returning a transaction for another chain should be rejected
+	otherChainSigner, err := evm.NewRemoteSigner(ctx, newStubRemoteSigner(t, &stubSignerService{privKey: testPrivateKey, otherChain: true}), address)
+	require.NoError(t, err)
+	defer otherChainSigner.Close()
+	_, err = otherChainSigner.SignTx(ctx, tx, chainID)
+	assert.ErrorIs(t, err, evm.ErrInvalid)
+}
@@ -0,0 +1,61 @@
+package evm
+
+import (
+	"bytes"
+	goerrors "errors"
+
+	blobstreamwrapper "github.com/celestiaorg/blobstream-contracts/v4/wrappers/Blobstream.sol"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// blobstreamErrors maps the Blobstream contract custom errors to their typed equivalent.
+var blobstreamErrors = map[string]error{
+	"InvalidDataRootTupleRootNonce": ErrInvalidDataRootTupleRootNonce,
+	"InvalidValidatorSetNonce":      ErrInvalidValidatorSetNonce,
+	"InsufficientVotingPower":       ErrInsufficientVotingPower,
+	"InvalidSignature":              ErrInvalidSignature,
+	"MalformedCurrentValidatorSet":  ErrMalformedCurrentValidatorSet,
+	"SuppliedValidatorSetInvalid":   ErrSuppliedValidatorSetInvalid,
+}
+
+// DecodeRevertError decodes the revert data contained in the provided call error into a
+// typed error.
+// Returns the typed error if the revert data corresponds to a Blobstream contract custom error,
+// an ErrContractReverted wrapping the revert reason if it can be decoded, or the provided error otherwise.
+func DecodeRevertError(err error) error {
+	var dataErr rpc.DataError
+	if err == nil || !goerrors.As(err, &dataErr) {
+		return err
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return err
+	}
+	data, decodeErr := hexutil.Decode(hexData)
+	if decodeErr != nil || len(data) < 4 {
+		return err
+	}
+
+	reason, unpackErr := abi.UnpackRevert(data)
+	if unpackErr == nil {
+		return errors.Wrap(ErrContractReverted, reason)
+	}
+
+	contractABI, abiErr := blobstreamwrapper.WrappersMetaData.GetAbi()
+	if abiErr != nil {
+		return err
+	}
+	for name, customErr := range contractABI.Errors {
+		if !bytes.Equal(customErr.ID[:4], data[:4]) {
+			continue
+		}
+		if typedErr, has := blobstreamErrors[name]; has {
+			return typedErr
+		}
+		return errors.Wrap(ErrContractReverted, name)
+	}
+	return errors.Wrap(ErrContractReverted, hexData)
+}
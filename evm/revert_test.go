@@ -0,0 +1,81 @@
+package evm_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	wrapper "github.com/celestiaorg/blobstream-contracts/v4/wrappers/Blobstream.sol"
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func (s *EVMTestSuite) TestSimulateSubmitDataRootTupleRoot() {
+	// deploy a new bridge contract
+	_, _, _, err := s.Client.DeployBlobstreamContract(s.Chain.Auth, s.Chain.Backend, *s.InitVs, 1, true)
+	s.NoError(err)
+
+	commitment := ethcmn.HexToHash("0x12345")
+	sign := func(nonce int64) []wrapper.Signature {
+		signBytes := types.DataCommitmentTupleRootSignBytes(big.NewInt(nonce), commitment[:])
+		ks := keystore.NewKeyStore(s.T().TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+		acc, err := ks.ImportECDSA(s.VsPrivateKey, "123")
+		s.Require().NoError(err)
+		s.Require().NoError(ks.Unlock(acc, "123"))
+		signature, err := evm.NewEthereumSignature(signBytes.Bytes(), ks, acc)
+		s.Require().NoError(err)
+		v, r, ss, err := evm.SigToVRS(ethcmn.Bytes2Hex(signature))
+		s.Require().NoError(err)
+		return []wrapper.Signature{{V: v, R: r, S: ss}}
+	}
+	from := s.Chain.Auth.From
+
+	tests := []struct {
+		name        string
+		nonce       uint64
+		sigs        []wrapper.Signature
+		expectedErr error
+	}{
+		{
+			name:  "valid submission",
+			nonce: 2,
+			sigs:  sign(2),
+		},
+		{
+			name:        "stale nonce",
+			nonce:       1,
+			sigs:        sign(1),
+			expectedErr: evm.ErrInvalidDataRootTupleRootNonce,
+		},
+		{
+			name:        "signature over a different nonce",
+			nonce:       2,
+			sigs:        sign(3),
+			expectedErr: evm.ErrInvalidSignature,
+		},
+		{
+			name:        "missing signatures",
+			nonce:       2,
+			sigs:        []wrapper.Signature{},
+			expectedErr: evm.ErrMalformedCurrentValidatorSet,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			err := s.Client.SimulateSubmitDataRootTupleRoot(context.Background(), from, commitment, tt.nonce, *s.InitVs, tt.sigs)
+			if tt.expectedErr == nil {
+				s.NoError(err)
+			} else {
+				s.ErrorIs(err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func (s *EVMTestSuite) TestDecodeRevertErrorWithoutRevertData() {
+	err := errors.New("connection refused")
+	s.Equal(err, evm.DecodeRevertError(err))
+	s.NoError(evm.DecodeRevertError(nil))
+}
@@ -0,0 +1,50 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	coregethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Signer signs digests and transactions on behalf of an EVM account.
+type Signer interface {
+	// Address returns the EVM address of the signing account.
+	Address() common.Address
+	// SignDigest creates an eip-191 signature over the provided digest.
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+	// SignTx signs the provided transaction for the provided chain ID.
+	SignTx(ctx context.Context, tx *coregethtypes.Transaction, chainID *big.Int) (*coregethtypes.Transaction, error)
+}
+
+var _ Signer = &KeystoreSigner{}
+
+// KeystoreSigner a Signer that uses an unlocked account from a local keystore.
+type KeystoreSigner struct {
+	ks  *keystore.KeyStore
+	acc accounts.Account
+}
+
+// NewKeystoreSigner creates a new keystore signer.
+// The account is expected to be unlocked.
+func NewKeystoreSigner(ks *keystore.KeyStore, acc accounts.Account) *KeystoreSigner {
+	return &KeystoreSigner{
+		ks:  ks,
+		acc: acc,
+	}
+}
+
+func (s *KeystoreSigner) Address() common.Address {
+	return s.acc.Address
+}
+
+func (s *KeystoreSigner) SignDigest(_ context.Context, digest []byte) ([]byte, error) {
+	return NewEthereumSignature(digest, s.ks, s.acc)
+}
+
+func (s *KeystoreSigner) SignTx(_ context.Context, tx *coregethtypes.Transaction, chainID *big.Int) (*coregethtypes.Transaction, error) {
+	return s.ks.SignTx(s.acc, tx, chainID)
+}
@@ -37,7 +37,7 @@ func (s *EVMTestSuite) SetupTest() {
 	err = ks.Unlock(acc, "123")
 	require.NoError(t, err)
 
-	s.Client = blobstreamtesting.NewEVMClient(ks, &acc)
+	s.Client = blobstreamtesting.NewEVMClient(evm.NewKeystoreSigner(ks, acc))
 	s.InitVs, err = celestiatypes.NewValset(
 		1,
 		10,
@@ -0,0 +1,14 @@
+package evm
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// WeiToEther converts the provided wei amount to ether.
+// The result is approximate and should only be used for reporting.
+func WeiToEther(wei *big.Int) float64 {
+	ether, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.Ether)).Float64()
+	return ether
+}
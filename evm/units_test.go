@@ -0,0 +1,17 @@
+package evm_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeiToEther(t *testing.T) {
+	assert.Equal(t, float64(0), evm.WeiToEther(big.NewInt(0)))
+	assert.Equal(t, float64(1), evm.WeiToEther(big.NewInt(params.Ether)))
+	assert.Equal(t, 0.0021, evm.WeiToEther(big.NewInt(21000*params.GWei*100)))
+	assert.Equal(t, float64(1000), evm.WeiToEther(new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))))
+}
@@ -0,0 +1,422 @@
+package monitor
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
+	"github.com/celestiaorg/orchestrator-relayer/helpers"
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/rpc"
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	corerpctypes "github.com/tendermint/tendermint/rpc/core/types"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// The queue channel's size
+const queueSize = 1000
+
+// Monitor follows the attestations and checks which valset members signed them.
+// The results are exported as Prometheus metrics.
+type Monitor struct {
+	Logger tmlog.Logger
+
+	AppQuerier *rpc.AppQuerier
+	TmQuerier  *rpc.TmQuerier
+	P2PQuerier *p2p.Querier
+	Retrier    *helpers.Retrier
+	Metrics    *telemetry.MonitorMetrics
+	Tracker    *Tracker
+
+	// SigningDelay the time to wait after an attestation is observed before checking its confirms,
+	// to give the orchestrators enough time to sign it.
+	SigningDelay time.Duration
+
+	// validators the known validators information referenced by their EVM addresses.
+	validators map[string]ValidatorInfo
+	// labels the metrics labels used for the validators referenced by their EVM addresses.
+	labels map[string][]string
+	// lastCheckedNonce the last nonce whose confirms were checked.
+	lastCheckedNonce uint64
+}
+
+func New(
+	logger tmlog.Logger,
+	appQuerier *rpc.AppQuerier,
+	tmQuerier *rpc.TmQuerier,
+	p2pQuerier *p2p.Querier,
+	retrier *helpers.Retrier,
+	metrics *telemetry.MonitorMetrics,
+	tracker *Tracker,
+	signingDelay time.Duration,
+) *Monitor {
+	return &Monitor{
+		Logger:       logger,
+		AppQuerier:   appQuerier,
+		TmQuerier:    tmQuerier,
+		P2PQuerier:   p2pQuerier,
+		Retrier:      retrier,
+		Metrics:      metrics,
+		Tracker:      tracker,
+		SigningDelay: signingDelay,
+		validators:   make(map[string]ValidatorInfo),
+		labels:       make(map[string][]string),
+	}
+}
+
+// NonceReport the result of checking the confirms of an attestation nonce.
+type NonceReport struct {
+	Nonce uint64
+	// Members the members of the valset that should sign the nonce.
+	Members []ValidatorInfo
+	// Signers the EVM addresses of the members that signed the nonce.
+	Signers           map[string]bool
+	SignedPower       uint64
+	TotalPower        uint64
+	MajorityThreshold uint64
+}
+
+// pendingNonce a nonce waiting to be checked.
+type pendingNonce struct {
+	nonce   uint64
+	checkAt time.Time
+}
+
+// Start starts the monitor. It first checks the recent nonces, covering the largest
+// participation window, then follows the new attestations until the context is canceled.
+func (m *Monitor) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	err := m.TmQuerier.WaitForHeight(ctx, 1)
+	if err != nil {
+		return err
+	}
+	latestNonce, err := m.AppQuerier.QueryLatestAttestationNonce(ctx)
+	if err != nil {
+		return err
+	}
+	// nonce 1 is not signed
+	m.lastCheckedNonce = 1
+	if latestNonce > m.Tracker.MaxWindow()+1 {
+		m.lastCheckedNonce = latestNonce - m.Tracker.MaxWindow()
+	}
+
+	queue := make(chan pendingNonce, queueSize)
+	if latestNonce > m.lastCheckedNonce+1 {
+		// the older nonces can be checked right away.
+		queue <- pendingNonce{nonce: latestNonce - 1, checkAt: time.Now()}
+	}
+	// the latest nonce might have just been created, so the orchestrators are given the signing delay
+	// to sign it, as for the new attestations.
+	queue <- pendingNonce{nonce: latestNonce, checkAt: time.Now().Add(m.SigningDelay)}
+
+	listenerErr := make(chan error, 1)
+	go func() {
+		defer cancel()
+		err := m.StartNewEventsListener(ctx, queue)
+		if err != nil {
+			m.Logger.Error("error listening to new attestations", "err", err)
+		}
+		listenerErr <- err
+	}()
+
+	err = m.ProcessNonces(ctx, queue)
+	cancel()
+	if err := <-listenerErr; err != nil {
+		return err
+	}
+	return err
+}
+
+// StartNewEventsListener listens for new attestations and enqueues their nonces to be
+// checked after the signing delay.
+func (m *Monitor) StartNewEventsListener(ctx context.Context, queue chan<- pendingNonce) error {
+	subscriptionName := "monitor-attestation-changes"
+	query := fmt.Sprintf("%s.%s='%s'", celestiatypes.EventTypeAttestationRequest, sdk.AttributeKeyModule, celestiatypes.ModuleName)
+	results, err := m.TmQuerier.SubscribeEvents(ctx, subscriptionName, query)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := m.TmQuerier.UnsubscribeEvents(ctx, subscriptionName, query)
+		if err != nil {
+			m.Logger.Error(err.Error())
+		}
+	}()
+	attestationEventName := fmt.Sprintf("%s.%s", celestiatypes.EventTypeAttestationRequest, celestiatypes.AttributeKeyNonce)
+	m.Logger.Info("listening for new attestations...")
+	// ticker for keeping an eye on the health of the tendermint RPC
+	// this is because the ws connection doesn't complain when the node is down
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if goerrors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+			return ctx.Err()
+		case <-ticker.C:
+			if !m.TmQuerier.IsRunning(ctx) {
+				m.Logger.Error("tendermint RPC down. Retrying to connect")
+				err := m.Retrier.Retry(ctx, func() error {
+					err := m.TmQuerier.Reconnect()
+					if err != nil {
+						return err
+					}
+					results, err = m.TmQuerier.SubscribeEvents(ctx, subscriptionName, query)
+					if err != nil {
+						return err
+					}
+					m.Logger.Debug("recovered connection")
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+		case result := <-results:
+			blockEvent := getEvent(result, coretypes.EventTypeKey)
+			if len(blockEvent) == 0 || blockEvent[0] != coretypes.EventNewBlock {
+				// we only want to handle the attestation when the block is committed
+				continue
+			}
+			for _, attEvent := range getEvent(result, attestationEventName) {
+				nonce, err := strconv.ParseUint(attEvent, 10, 64)
+				if err != nil {
+					return err
+				}
+				m.Logger.Debug("enqueueing new attestation nonce", "nonce", nonce)
+				select {
+				case <-ctx.Done():
+					return nil
+				case queue <- pendingNonce{nonce: nonce, checkAt: time.Now().Add(m.SigningDelay)}:
+				}
+			}
+		}
+	}
+}
+
+// ProcessNonces checks the enqueued nonces once their signing delay elapses.
+// The nonces between the last checked nonce and the dequeued one are checked as well,
+// so that no nonce is skipped if some attestation events were missed.
+func (m *Monitor) ProcessNonces(ctx context.Context, queue <-chan pendingNonce) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case pending := <-queue:
+			timer := time.NewTimer(time.Until(pending.checkAt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil
+			case <-timer.C:
+			}
+			for nonce := m.lastCheckedNonce + 1; nonce <= pending.nonce; nonce++ {
+				if ctx.Err() != nil {
+					return nil
+				}
+				report, err := m.CheckNonce(ctx, nonce)
+				if err != nil {
+					m.Logger.Error("failed to check nonce", "nonce", nonce, "err", err.Error())
+					err = m.Retrier.Retry(ctx, func() error {
+						report, err = m.CheckNonce(ctx, nonce)
+						return err
+					})
+					if err != nil {
+						m.Logger.Error("couldn't check nonce, skipping it", "nonce", nonce, "err", err.Error())
+						continue
+					}
+				}
+				m.Record(*report)
+			}
+			if pending.nonce > m.lastCheckedNonce {
+				m.lastCheckedNonce = pending.nonce
+			}
+		}
+	}
+}
+
+// CheckNonce queries the confirms of the provided nonce and returns which valset members signed it.
+func (m *Monitor) CheckNonce(ctx context.Context, nonce uint64) (*NonceReport, error) {
+	lastValset, err := m.AppQuerier.QueryLastValsetBeforeNonce(ctx, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	att, err := m.AppQuerier.QueryAttestationByNonce(ctx, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if att == nil {
+		return nil, celestiatypes.ErrAttestationNotFound
+	}
+
+	signers := make(map[string]bool)
+	switch castedAtt := att.(type) {
+	case *celestiatypes.Valset:
+		signBytes, err := castedAtt.SignBytes()
+		if err != nil {
+			return nil, err
+		}
+		confirms, err := m.P2PQuerier.QueryValsetConfirms(ctx, nonce, *lastValset, signBytes.Hex())
+		if err != nil {
+			return nil, err
+		}
+		for _, confirm := range confirms {
+			signers[confirm.EthAddress] = true
+		}
+	case *celestiatypes.DataCommitment:
+		commitment, err := m.TmQuerier.QueryCommitment(ctx, castedAtt.BeginBlock, castedAtt.EndBlock)
+		if err != nil {
+			return nil, err
+		}
+		dataRootHash := types.DataCommitmentTupleRootSignBytes(big.NewInt(int64(castedAtt.Nonce)), commitment)
+		confirms, err := m.P2PQuerier.QueryDataCommitmentConfirms(ctx, *lastValset, nonce, dataRootHash.Hex())
+		if err != nil {
+			return nil, err
+		}
+		for _, confirm := range confirms {
+			signers[confirm.EthAddress] = true
+		}
+	default:
+		return nil, errors.Wrap(types.ErrUnknownAttestationType, strconv.FormatUint(nonce, 10))
+	}
+
+	members, err := m.validatorsInfo(ctx, lastValset.Members)
+	if err != nil {
+		return nil, err
+	}
+	report := NonceReport{
+		Nonce:             nonce,
+		Members:           members,
+		Signers:           signers,
+		MajorityThreshold: lastValset.TwoThirdsThreshold(),
+	}
+	for _, member := range lastValset.Members {
+		report.TotalPower += member.Power
+		if signers[member.EvmAddress] {
+			report.SignedPower += member.Power
+		}
+	}
+	return &report, nil
+}
+
+// Record records the provided report in the tracker and updates the metrics.
+func (m *Monitor) Record(report NonceReport) {
+	m.Tracker.Record(report.Nonce, report.Members, report.Signers)
+	m.Logger.Info(
+		"checked nonce",
+		"nonce", report.Nonce,
+		"signers", len(report.Signers),
+		"members", len(report.Members),
+		"signed_power", report.SignedPower,
+		"majority_threshold", report.MajorityThreshold,
+	)
+	if m.Metrics == nil {
+		return
+	}
+
+	m.Metrics.LastCheckedNonce.Set(float64(report.Nonce))
+	m.Metrics.SignedPower.Set(float64(report.SignedPower))
+	m.Metrics.TotalPower.Set(float64(report.TotalPower))
+	m.Metrics.MajorityThreshold.Set(float64(report.MajorityThreshold))
+
+	for _, stats := range m.Tracker.Stats() {
+		labels := []string{stats.EvmAddress, stats.Moniker, stats.ValopAddress}
+		if previousLabels, ok := m.labels[stats.EvmAddress]; ok && !equalLabels(previousLabels, labels) {
+			// the validator information changed, so the previous series are removed.
+			m.deleteValidatorMetrics(previousLabels)
+		}
+		m.labels[stats.EvmAddress] = labels
+
+		m.Metrics.LastSignedNonce.WithLabelValues(labels...).Set(float64(stats.LastSignedNonce))
+		m.Metrics.MissedNonces.WithLabelValues(labels...).Set(float64(stats.MissedNonces))
+		for _, window := range m.Tracker.Windows() {
+			rate, ok := stats.ParticipationRate(window)
+			if !ok {
+				continue
+			}
+			m.Metrics.ParticipationRate.WithLabelValues(append(labels, strconv.FormatUint(window, 10))...).Set(rate)
+		}
+	}
+}
+
+func (m *Monitor) deleteValidatorMetrics(labels []string) {
+	m.Metrics.LastSignedNonce.DeleteLabelValues(labels...)
+	m.Metrics.MissedNonces.DeleteLabelValues(labels...)
+	for _, window := range m.Tracker.Windows() {
+		m.Metrics.ParticipationRate.DeleteLabelValues(append(labels, strconv.FormatUint(window, 10))...)
+	}
+}
+
+// validatorsInfo returns the information of the provided valset members.
+// The validators information is refreshed from the staking module if a member is unknown.
+func (m *Monitor) validatorsInfo(ctx context.Context, members []celestiatypes.BridgeValidator) ([]ValidatorInfo, error) {
+	for _, member := range members {
+		if _, ok := m.validators[member.EvmAddress]; !ok {
+			err := m.refreshValidatorsInfo(ctx)
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	infos := make([]ValidatorInfo, len(members))
+	for i, member := range members {
+		info, ok := m.validators[member.EvmAddress]
+		if !ok {
+			// the validator might have left the staking validator set.
+			info = ValidatorInfo{EvmAddress: member.EvmAddress}
+		}
+		infos[i] = info
+	}
+	return infos, nil
+}
+
+func (m *Monitor) refreshValidatorsInfo(ctx context.Context) error {
+	validatorSet, err := m.AppQuerier.QueryStakingValidatorSet(ctx)
+	if err != nil {
+		return err
+	}
+	for _, val := range validatorSet {
+		evmAddr, err := m.AppQuerier.QueryEVMAddress(ctx, val.OperatorAddress)
+		if err != nil {
+			return err
+		}
+		if evmAddr != "" {
+			m.validators[evmAddr] = ValidatorInfo{
+				EvmAddress:   evmAddr,
+				Moniker:      val.GetMoniker(),
+				ValopAddress: val.OperatorAddress,
+			}
+		}
+	}
+	return nil
+}
+
+func equalLabels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func getEvent(result corerpctypes.ResultEvent, eventName string) []string {
+	return result.Events[eventName]
+}
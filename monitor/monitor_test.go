@@ -0,0 +1,87 @@
+package monitor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/test/util/testnode"
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
+	"github.com/celestiaorg/orchestrator-relayer/helpers"
+	"github.com/celestiaorg/orchestrator-relayer/monitor"
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
+	blobstreamtesting "github.com/celestiaorg/orchestrator-relayer/testing"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+func TestCheckNonce(t *testing.T) {
+	ctx := context.Background()
+	codec := encoding.MakeConfig(app.ModuleEncodingRegisters...).Codec
+	node := blobstreamtesting.NewTestNode(
+		ctx,
+		t,
+		blobstreamtesting.CelestiaNetworkParams{
+			GenesisOpts: []testnode.GenesisOption{
+				testnode.ImmediateProposals(codec),
+				blobstreamtesting.SetDataCommitmentWindowParams(codec, celestiatypes.Params{DataCommitmentWindow: 101}),
+			},
+			TimeIotaMs:    1,
+			Pruning:       "default",
+			TimeoutCommit: 5 * time.Millisecond,
+		},
+	)
+	defer node.Close()
+	orch := blobstreamtesting.NewOrchestrator(t, node)
+
+	_, err := node.CelestiaNetwork.WaitForHeight(250)
+	require.NoError(t, err)
+
+	registry := prometheus.NewRegistry()
+	metrics, err := telemetry.NewMonitorMetrics(registry)
+	require.NoError(t, err)
+	logger := tmlog.NewNopLogger()
+	m := monitor.New(
+		logger,
+		orch.AppQuerier,
+		orch.TmQuerier,
+		orch.P2PQuerier,
+		helpers.NewRetrier(logger, 3, 500*time.Millisecond),
+		metrics,
+		monitor.NewTracker([]uint64{10}),
+		0,
+	)
+
+	// the valset member didn't sign the nonce
+	report, err := m.CheckNonce(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, report.Members, 1)
+	member := report.Members[0]
+	assert.NotEmpty(t, member.EvmAddress)
+	assert.Empty(t, report.Signers)
+	assert.Equal(t, uint64(0), report.SignedPower)
+	assert.Greater(t, report.TotalPower, uint64(0))
+	m.Record(*report)
+
+	// recording a nonce signed by the member
+	m.Record(monitor.NonceReport{
+		Nonce:             3,
+		Members:           report.Members,
+		Signers:           map[string]bool{member.EvmAddress: true},
+		SignedPower:       report.TotalPower,
+		TotalPower:        report.TotalPower,
+		MajorityThreshold: report.MajorityThreshold,
+	})
+
+	labels := []string{member.EvmAddress, member.Moniker, member.ValopAddress}
+	assert.Equal(t, float64(3), testutil.ToFloat64(metrics.LastSignedNonce.WithLabelValues(labels...)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.MissedNonces.WithLabelValues(labels...)))
+	assert.Equal(t, 0.5, testutil.ToFloat64(metrics.ParticipationRate.WithLabelValues(append(labels, "10")...)))
+	assert.Equal(t, float64(3), testutil.ToFloat64(metrics.LastCheckedNonce))
+	assert.Equal(t, float64(report.TotalPower), testutil.ToFloat64(metrics.SignedPower))
+}
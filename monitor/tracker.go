@@ -0,0 +1,122 @@
+package monitor
+
+import (
+	"sort"
+	"sync"
+)
+
+// ValidatorInfo identifies a validator in the monitor metrics.
+type ValidatorInfo struct {
+	EvmAddress   string
+	Moniker      string
+	ValopAddress string
+}
+
+// ValidatorStats the signing statistics of a validator.
+type ValidatorStats struct {
+	ValidatorInfo
+	// LastSignedNonce the last nonce signed by the validator. Zero if it didn't sign any nonce yet.
+	LastSignedNonce uint64
+	// MissedNonces the number of nonces that the validator should have signed, but didn't.
+	MissedNonces uint64
+	// history whether the validator signed the recent nonces it should have signed, oldest first.
+	history []bool
+}
+
+// ParticipationRate returns the ratio of the nonces signed by the validator over the
+// last `window` nonces it should have signed.
+// Returns false if the validator wasn't expected to sign any nonce yet.
+func (s ValidatorStats) ParticipationRate(window uint64) (float64, bool) {
+	history := s.history
+	if uint64(len(history)) > window {
+		history = history[uint64(len(history))-window:]
+	}
+	if len(history) == 0 {
+		return 0, false
+	}
+	signed := 0
+	for _, hasSigned := range history {
+		if hasSigned {
+			signed++
+		}
+	}
+	return float64(signed) / float64(len(history)), true
+}
+
+// Tracker keeps track of the signing statistics of the validators.
+type Tracker struct {
+	mu         sync.Mutex
+	windows    []uint64
+	maxWindow  uint64
+	validators map[string]*ValidatorStats
+}
+
+// NewTracker creates a new tracker computing the participation rates over the provided windows.
+func NewTracker(windows []uint64) *Tracker {
+	maxWindow := uint64(0)
+	for _, window := range windows {
+		if window > maxWindow {
+			maxWindow = window
+		}
+	}
+	return &Tracker{
+		windows:    windows,
+		maxWindow:  maxWindow,
+		validators: make(map[string]*ValidatorStats),
+	}
+}
+
+// Windows returns the windows over which the participation rates are computed.
+func (t *Tracker) Windows() []uint64 {
+	return t.windows
+}
+
+// MaxWindow returns the largest window over which the participation rates are computed.
+func (t *Tracker) MaxWindow() uint64 {
+	return t.maxWindow
+}
+
+// Record records whether the provided valset members signed the nonce.
+// The signers map is keyed by the EVM addresses of the members that signed.
+func (t *Tracker) Record(nonce uint64, members []ValidatorInfo, signers map[string]bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, member := range members {
+		stats, ok := t.validators[member.EvmAddress]
+		if !ok {
+			stats = &ValidatorStats{}
+			t.validators[member.EvmAddress] = stats
+		}
+		// the validator info can change, e.g. when the moniker is edited.
+		stats.ValidatorInfo = member
+
+		signed := signers[member.EvmAddress]
+		if signed {
+			if nonce > stats.LastSignedNonce {
+				stats.LastSignedNonce = nonce
+			}
+		} else {
+			stats.MissedNonces++
+		}
+		stats.history = append(stats.history, signed)
+		if uint64(len(stats.history)) > t.maxWindow {
+			stats.history = stats.history[uint64(len(stats.history))-t.maxWindow:]
+		}
+	}
+}
+
+// Stats returns the signing statistics of the tracked validators, sorted by EVM address.
+func (t *Tracker) Stats() []ValidatorStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats := make([]ValidatorStats, 0, len(t.validators))
+	for _, s := range t.validators {
+		statsCopy := *s
+		statsCopy.history = append([]bool{}, s.history...)
+		stats = append(stats, statsCopy)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].EvmAddress < stats[j].EvmAddress
+	})
+	return stats
+}
@@ -0,0 +1,49 @@
+package monitor_test
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/monitor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := monitor.NewTracker([]uint64{2, 4})
+	assert.Equal(t, uint64(4), tracker.MaxWindow())
+
+	val1 := monitor.ValidatorInfo{EvmAddress: "0x1", Moniker: "val1"}
+	val2 := monitor.ValidatorInfo{EvmAddress: "0x2", Moniker: "val2"}
+	members := []monitor.ValidatorInfo{val1, val2}
+
+	tracker.Record(2, members, map[string]bool{"0x1": true, "0x2": true})
+	tracker.Record(3, members, map[string]bool{"0x1": true})
+	tracker.Record(4, members, map[string]bool{"0x1": true})
+	tracker.Record(5, members, map[string]bool{"0x1": true, "0x2": true})
+	tracker.Record(6, members, map[string]bool{})
+
+	stats := tracker.Stats()
+	require.Len(t, stats, 2)
+
+	assert.Equal(t, val1, stats[0].ValidatorInfo)
+	assert.Equal(t, uint64(5), stats[0].LastSignedNonce)
+	assert.Equal(t, uint64(1), stats[0].MissedNonces)
+	rate, ok := stats[0].ParticipationRate(2)
+	require.True(t, ok)
+	assert.Equal(t, 0.5, rate)
+	// the history is capped at the largest window
+	rate, ok = stats[0].ParticipationRate(10)
+	require.True(t, ok)
+	assert.Equal(t, 0.75, rate)
+
+	assert.Equal(t, val2, stats[1].ValidatorInfo)
+	assert.Equal(t, uint64(5), stats[1].LastSignedNonce)
+	assert.Equal(t, uint64(3), stats[1].MissedNonces)
+	rate, ok = stats[1].ParticipationRate(4)
+	require.True(t, ok)
+	assert.Equal(t, 0.25, rate)
+
+	// validators that weren't expected to sign anything yet don't have a participation rate
+	_, ok = monitor.ValidatorStats{}.ParticipationRate(2)
+	assert.False(t, ok)
+}
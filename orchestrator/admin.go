@@ -0,0 +1,185 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+)
+
+const (
+	// AdminStatusEndpoint the admin API endpoint returning the orchestrator processing state.
+	AdminStatusEndpoint = "/status"
+	// AdminReprocessEndpoint the admin API endpoint to force re-processing a range of nonces.
+	// The range is specified using the `from` and `to` query parameters, both inclusive. If the `force`
+	// query parameter is true, the confirms are provided again even if they're already in the DHT.
+	AdminReprocessEndpoint = "/reprocess"
+	// AdminPeersEndpoint the admin API endpoint returning the scores of the P2P peers, depending on
+	// the validity of the DHT records they returned.
+	AdminPeersEndpoint = "/peers"
+)
+
+// AdminStatus the orchestrator processing state returned by the admin API.
+type AdminStatus struct {
+	EVMAddress             string `json:"evm_address"`
+	LatestSeenNonce        uint64 `json:"latest_seen_nonce"`
+	LastProcessedNonce     uint64 `json:"last_processed_nonce"`
+	NoncesQueueDepth       int    `json:"nonces_queue_depth"`
+	FailedNoncesQueueDepth int    `json:"failed_nonces_queue_depth"`
+	NoncesSignedLastHour   int    `json:"nonces_signed_last_hour"`
+	RequeuedNonces         uint64 `json:"requeued_nonces"`
+	ReprocessedNonces      uint64 `json:"reprocessed_nonces"`
+	DHTPeers               int    `json:"dht_peers"`
+}
+
+// AdminReprocessResponse the admin API response to a re-processing request.
+type AdminReprocessResponse struct {
+	Enqueued int    `json:"enqueued"`
+	Error    string `json:"error,omitempty"`
+}
+
+// AdminServer serves the orchestrator admin API, which exposes the live processing state
+// of the orchestrator and allows forcing the re-processing of nonces.
+// It should only be exposed locally.
+type AdminServer struct {
+	orch   *Orchestrator
+	server *http.Server
+}
+
+// NewAdminServer creates a new admin server for the provided orchestrator.
+func NewAdminServer(orch *Orchestrator, listenAddr string) *AdminServer {
+	s := &AdminServer{orch: orch}
+	mux := http.NewServeMux()
+	mux.HandleFunc(AdminStatusEndpoint, s.handleStatus)
+	mux.HandleFunc(AdminReprocessEndpoint, s.handleReprocess)
+	mux.HandleFunc(AdminPeersEndpoint, s.handlePeers)
+	s.server = &http.Server{
+		Addr:              listenAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s
+}
+
+// Handler returns the admin API handler.
+func (s *AdminServer) Handler() http.Handler {
+	return s.server.Handler
+}
+
+// Start starts listening on the admin API address and serves the requests in the background.
+func (s *AdminServer) Start() error {
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !goerrors.Is(err, http.ErrServerClosed) {
+			s.orch.Logger.Error("admin API server stopped", "err", err.Error())
+		}
+	}()
+	s.orch.Logger.Info("admin API started", "listen_addr", listener.Addr().String())
+	return nil
+}
+
+// Stop gracefully shuts down the admin API server.
+func (s *AdminServer) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+// Status returns the current processing state of the orchestrator.
+func (orch Orchestrator) Status() AdminStatus {
+	snapshot := orch.State.Snapshot(time.Now())
+	status := AdminStatus{
+		EVMAddress:             orch.EvmSigner.Address().Hex(),
+		LatestSeenNonce:        snapshot.LatestSeenNonce,
+		LastProcessedNonce:     orch.Checkpoint.LastProcessedNonce(),
+		NoncesQueueDepth:       snapshot.NoncesQueueDepth,
+		FailedNoncesQueueDepth: snapshot.FailedNoncesQueueDepth,
+		NoncesSignedLastHour:   snapshot.NoncesSignedLastHour,
+		RequeuedNonces:         snapshot.RequeuedNonces,
+		ReprocessedNonces:      snapshot.ReprocessedNonces,
+	}
+	if orch.P2PQuerier != nil && orch.P2PQuerier.BlobstreamDHT != nil {
+		status.DHTPeers = len(orch.P2PQuerier.BlobstreamDHT.RoutingTable().ListPeers())
+	}
+	return status
+}
+
+// PeerScores returns the scores of the P2P peers, ordered from the lowest to the highest.
+// Returns an empty list if the peers are not scored.
+func (orch Orchestrator) PeerScores() []p2p.PeerScore {
+	if orch.P2PQuerier == nil || orch.P2PQuerier.BlobstreamDHT == nil || orch.P2PQuerier.BlobstreamDHT.Scorer == nil {
+		return []p2p.PeerScore{}
+	}
+	return orch.P2PQuerier.BlobstreamDHT.Scorer.Scores()
+}
+
+func (s *AdminServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.orch.Status())
+}
+
+func (s *AdminServer) handleReprocess(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	from, err := strconv.ParseUint(r.URL.Query().Get("from"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, AdminReprocessResponse{Error: "invalid from nonce: " + err.Error()})
+		return
+	}
+	to, err := strconv.ParseUint(r.URL.Query().Get("to"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, AdminReprocessResponse{Error: "invalid to nonce: " + err.Error()})
+		return
+	}
+
+	force := false
+	if value := r.URL.Query().Get("force"); value != "" {
+		force, err = strconv.ParseBool(value)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, AdminReprocessResponse{Error: "invalid force flag: " + err.Error()})
+			return
+		}
+	}
+
+	count, err := s.orch.State.Reprocess(from, to, force)
+	switch {
+	case err == nil:
+		s.orch.Logger.Info("enqueued nonces to be re-processed", "from", from, "to", to, "force", force)
+		writeJSON(w, http.StatusAccepted, AdminReprocessResponse{Enqueued: count})
+	case goerrors.Is(err, ErrInvalidNonceRange), goerrors.Is(err, ErrNonceRangeTooLarge):
+		writeJSON(w, http.StatusBadRequest, AdminReprocessResponse{Error: err.Error()})
+	default:
+		s.orch.Logger.Error("failed to enqueue nonces to be re-processed", "from", from, "to", to, "enqueued", count, "err", err.Error())
+		writeJSON(w, http.StatusServiceUnavailable, AdminReprocessResponse{Enqueued: count, Error: err.Error()})
+	}
+}
+
+func (s *AdminServer) handlePeers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.orch.PeerScores())
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,55 @@
+package orchestrator_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/celestiaorg/orchestrator-relayer/orchestrator"
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+)
+
+func (s *OrchestratorTestSuite) TestAdminAPI() {
+	handler := orchestrator.NewAdminServer(s.Orchestrator, "localhost:0").Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, orchestrator.AdminStatusEndpoint, nil))
+	s.Require().Equal(http.StatusOK, rec.Code)
+	var status orchestrator.AdminStatus
+	s.Require().NoError(json.NewDecoder(rec.Body).Decode(&status))
+	s.Assert().Equal(s.Orchestrator.EvmSigner.Address().Hex(), status.EVMAddress)
+	s.Assert().Equal(s.Orchestrator.Status(), status)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, orchestrator.AdminStatusEndpoint, nil))
+	s.Assert().Equal(http.StatusMethodNotAllowed, rec.Code)
+
+	// the test network peers are not scored
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, orchestrator.AdminPeersEndpoint, nil))
+	s.Require().Equal(http.StatusOK, rec.Code)
+	var scores []p2p.PeerScore
+	s.Require().NoError(json.NewDecoder(rec.Body).Decode(&scores))
+	s.Assert().Empty(scores)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, orchestrator.AdminReprocessEndpoint+"?from=10&to=5", nil))
+	s.Assert().Equal(http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, orchestrator.AdminReprocessEndpoint+"?from=invalid&to=5", nil))
+	s.Assert().Equal(http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, orchestrator.AdminReprocessEndpoint+"?from=1&to=5&force=invalid", nil))
+	s.Assert().Equal(http.StatusBadRequest, rec.Code)
+
+	// the orchestrator is not running, so the nonces can't be enqueued
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, orchestrator.AdminReprocessEndpoint+"?from=1&to=5", nil))
+	s.Assert().Equal(http.StatusServiceUnavailable, rec.Code)
+	var resp orchestrator.AdminReprocessResponse
+	s.Require().NoError(json.NewDecoder(rec.Body).Decode(&resp))
+	s.Assert().Equal(0, resp.Enqueued)
+	s.Assert().Equal(orchestrator.ErrOrchestratorNotRunning.Error(), resp.Error)
+}
@@ -6,28 +6,58 @@ import (
 	"github.com/celestiaorg/orchestrator-relayer/p2p"
 
 	"github.com/celestiaorg/orchestrator-relayer/types"
+	tmlog "github.com/tendermint/tendermint/libs/log"
 )
 
 type Broadcaster struct {
 	BlobstreamDHT *p2p.BlobstreamDHT
+	// ConfirmsPubSub if set, the confirms are additionally published on the confirms gossip topic.
+	ConfirmsPubSub *p2p.ConfirmsPubSub
+	logger         tmlog.Logger
 }
 
-func NewBroadcaster(blobStreamDHT *p2p.BlobstreamDHT) *Broadcaster {
-	return &Broadcaster{BlobstreamDHT: blobStreamDHT}
+func NewBroadcaster(blobStreamDHT *p2p.BlobstreamDHT, confirmsPubSub *p2p.ConfirmsPubSub, logger tmlog.Logger) *Broadcaster {
+	return &Broadcaster{BlobstreamDHT: blobStreamDHT, ConfirmsPubSub: confirmsPubSub, logger: logger}
 }
 
 func (b Broadcaster) ProvideDataCommitmentConfirm(ctx context.Context, nonce uint64, confirm types.DataCommitmentConfirm, dataRootTupleRoot string) error {
 	if len(b.BlobstreamDHT.RoutingTable().ListPeers()) == 0 {
 		return ErrEmptyPeersTable
 	}
-	return b.BlobstreamDHT.PutDataCommitmentConfirm(ctx, p2p.GetDataCommitmentConfirmKey(nonce, confirm.EthAddress, dataRootTupleRoot), confirm)
+	key := p2p.GetDataCommitmentConfirmKey(nonce, confirm.EthAddress, dataRootTupleRoot)
+	err := b.BlobstreamDHT.PutDataCommitmentConfirm(ctx, key, confirm)
+	if err != nil {
+		return err
+	}
+	// the confirm is already provided under its own key, so failing to aggregate it is only logged.
+	err = b.BlobstreamDHT.AggregateConfirm(ctx, nonce, dataRootTupleRoot, confirm.EthAddress, confirm.Signature)
+	if err != nil {
+		b.logger.Error("couldn't aggregate data commitment confirm", "nonce", nonce, "err", err.Error())
+	}
+	if b.ConfirmsPubSub != nil {
+		return b.ConfirmsPubSub.PublishDataCommitmentConfirm(ctx, key, confirm)
+	}
+	return nil
 }
 
 func (b Broadcaster) ProvideValsetConfirm(ctx context.Context, nonce uint64, confirm types.ValsetConfirm, signBytes string) error {
 	if len(b.BlobstreamDHT.RoutingTable().ListPeers()) == 0 {
 		return ErrEmptyPeersTable
 	}
-	return b.BlobstreamDHT.PutValsetConfirm(ctx, p2p.GetValsetConfirmKey(nonce, confirm.EthAddress, signBytes), confirm)
+	key := p2p.GetValsetConfirmKey(nonce, confirm.EthAddress, signBytes)
+	err := b.BlobstreamDHT.PutValsetConfirm(ctx, key, confirm)
+	if err != nil {
+		return err
+	}
+	// the confirm is already provided under its own key, so failing to aggregate it is only logged.
+	err = b.BlobstreamDHT.AggregateConfirm(ctx, nonce, signBytes, confirm.EthAddress, confirm.Signature)
+	if err != nil {
+		b.logger.Error("couldn't aggregate valset confirm", "nonce", nonce, "err", err.Error())
+	}
+	if b.ConfirmsPubSub != nil {
+		return b.ConfirmsPubSub.PublishValsetConfirm(ctx, key, confirm)
+	}
+	return nil
 }
 
 func (b Broadcaster) ProvideLatestValset(ctx context.Context, latestValset types.LatestValset) error {
@@ -23,6 +23,7 @@ import (
 	blobstreamtesting "github.com/celestiaorg/orchestrator-relayer/testing"
 	"github.com/celestiaorg/orchestrator-relayer/types"
 	"github.com/stretchr/testify/assert"
+	tmlog "github.com/tendermint/tendermint/libs/log"
 )
 
 var (
@@ -54,7 +55,7 @@ func TestBroadcastDataCommitmentConfirm(t *testing.T) {
 	testKey := p2p.GetDataCommitmentConfirmKey(nonce, evmAddress, dataRootHash.Hex())
 
 	// Broadcast the confirm
-	broadcaster := orchestrator.NewBroadcaster(network.DHTs[1])
+	broadcaster := orchestrator.NewBroadcaster(network.DHTs[1], nil, tmlog.NewNopLogger())
 	err = broadcaster.ProvideDataCommitmentConfirm(context.Background(), nonce, *expectedConfirm, dataRootHash.Hex())
 	assert.NoError(t, err)
 
@@ -64,6 +65,11 @@ func TestBroadcastDataCommitmentConfirm(t *testing.T) {
 	assert.NotNil(t, actualConfirm)
 
 	assert.Equal(t, *expectedConfirm, actualConfirm)
+
+	// the signature is also aggregated with the other signatures of the nonce
+	aggregatedConfirms, err := network.DHTs[3].GetAggregatedConfirms(context.Background(), p2p.GetAggregatedConfirmsKey(nonce, dataRootHash.Hex()))
+	assert.NoError(t, err)
+	assert.True(t, aggregatedConfirms.Has(evmAddress))
 }
 
 func TestBroadcastValsetConfirm(t *testing.T) {
@@ -88,7 +94,7 @@ func TestBroadcastValsetConfirm(t *testing.T) {
 	testKey := p2p.GetValsetConfirmKey(nonce, evmAddress, signBytes.Hex())
 
 	// Broadcast the confirm
-	broadcaster := orchestrator.NewBroadcaster(network.DHTs[1])
+	broadcaster := orchestrator.NewBroadcaster(network.DHTs[1], nil, tmlog.NewNopLogger())
 	err = broadcaster.ProvideValsetConfirm(context.Background(), nonce, *expectedConfirm, signBytes.Hex())
 	assert.NoError(t, err)
 
@@ -121,7 +127,7 @@ func TestBroadcastLatestValset(t *testing.T) {
 	}
 
 	// Broadcast the valset
-	broadcaster := orchestrator.NewBroadcaster(network.DHTs[1])
+	broadcaster := orchestrator.NewBroadcaster(network.DHTs[1], nil, tmlog.NewNopLogger())
 	err := broadcaster.ProvideLatestValset(context.Background(), *types.ToLatestValset(expectedValset))
 	assert.NoError(t, err)
 
@@ -151,7 +157,7 @@ func TestEmptyPeersTable(t *testing.T) {
 	}
 
 	// Broadcast the confirm
-	broadcaster := orchestrator.NewBroadcaster(dht)
+	broadcaster := orchestrator.NewBroadcaster(dht, nil, tmlog.NewNopLogger())
 	err := broadcaster.ProvideDataCommitmentConfirm(context.Background(), 10, dcConfirm, "test root")
 
 	// check if the correct error is returned
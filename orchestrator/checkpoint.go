@@ -0,0 +1,177 @@
+package orchestrator
+
+import (
+	"context"
+	goerrors "errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+const (
+	// CheckpointNamespace the datastore namespace under which the orchestrator
+	// persists its progress.
+	CheckpointNamespace = "/orchestrator"
+	// lastProcessedNonceKey the key of the last fully processed nonce watermark.
+	lastProcessedNonceKey = CheckpointNamespace + "/last-processed-nonce"
+	// failedNoncesPrefix the prefix of the keys referencing the nonces that failed to be processed.
+	failedNoncesPrefix = CheckpointNamespace + "/failed"
+)
+
+// Checkpoint keeps track of the orchestrator progress and persists it in the data store, so that
+// the orchestrator can resume from where it stopped instead of re-processing every nonce on restart.
+// It maintains a "last processed nonce" watermark, which guarantees that all the nonces lower or equal
+// to it were processed, either successfully or by failing after retrying. The failed ones
+// are persisted separately to be re-processed subsequently.
+type Checkpoint struct {
+	store ds.Datastore
+
+	mu                 sync.Mutex
+	lastProcessedNonce uint64
+	// processedNonces contains the nonces that were processed but are higher than
+	// lastProcessedNonce + 1. They will be used to advance the watermark once the gap is filled.
+	processedNonces map[uint64]struct{}
+}
+
+// NewCheckpoint creates a new checkpoint backed by the provided data store and loads
+// the persisted watermark, if any.
+func NewCheckpoint(ctx context.Context, store ds.Datastore) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{
+		store:           store,
+		processedNonces: make(map[uint64]struct{}),
+	}
+	value, err := store.Get(ctx, ds.NewKey(lastProcessedNonceKey))
+	if err != nil {
+		if goerrors.Is(err, ds.ErrNotFound) {
+			return checkpoint, nil
+		}
+		return nil, err
+	}
+	nonce, err := strconv.ParseUint(string(value), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint.lastProcessedNonce = nonce
+	return checkpoint, nil
+}
+
+// LastProcessedNonce returns the last processed nonce watermark.
+// All the nonces lower or equal to it were either processed successfully or
+// are referenced in the failed nonces.
+func (c *Checkpoint) LastProcessedNonce() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastProcessedNonce
+}
+
+// SetFloor sets the watermark to the provided nonce if it is higher than the current one.
+// This is used when the nonces lower than a certain value cannot be processed anymore,
+// e.g. when they're pruned from state.
+func (c *Checkpoint) SetFloor(ctx context.Context, nonce uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if nonce <= c.lastProcessedNonce {
+		return nil
+	}
+	for processedNonce := range c.processedNonces {
+		if processedNonce <= nonce {
+			delete(c.processedNonces, processedNonce)
+		}
+	}
+	return c.advanceFrom(ctx, nonce)
+}
+
+// MarkProcessed marks the nonce as successfully processed, removes it from
+// the failed nonces if it was referenced there, and advances the watermark if possible.
+func (c *Checkpoint) MarkProcessed(ctx context.Context, nonce uint64) error {
+	err := c.store.Delete(ctx, failedNonceKey(nonce))
+	if err != nil {
+		return err
+	}
+	return c.markDone(ctx, nonce)
+}
+
+// MarkFailed persists the nonce as failed to be re-processed later, and advances
+// the watermark if possible.
+func (c *Checkpoint) MarkFailed(ctx context.Context, nonce uint64) error {
+	err := c.store.Put(ctx, failedNonceKey(nonce), []byte{})
+	if err != nil {
+		return err
+	}
+	return c.markDone(ctx, nonce)
+}
+
+// RemoveFailed removes the nonce from the failed nonces without affecting the watermark.
+// This is used to discard failed nonces that cannot be processed anymore.
+func (c *Checkpoint) RemoveFailed(ctx context.Context, nonce uint64) error {
+	return c.store.Delete(ctx, failedNonceKey(nonce))
+}
+
+// FailedNonces returns the persisted failed nonces sorted in ascending order.
+func (c *Checkpoint) FailedNonces(ctx context.Context) ([]uint64, error) {
+	results, err := c.store.Query(ctx, query.Query{
+		Prefix:   failedNoncesPrefix,
+		KeysOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	nonces := make([]uint64, 0)
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		nonce, err := strconv.ParseUint(strings.TrimPrefix(result.Key, failedNoncesPrefix+"/"), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		nonces = append(nonces, nonce)
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	return nonces, nil
+}
+
+// markDone records the nonce as done and moves the watermark forward as long as
+// the following nonces are also done.
+func (c *Checkpoint) markDone(ctx context.Context, nonce uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if nonce <= c.lastProcessedNonce {
+		return nil
+	}
+	c.processedNonces[nonce] = struct{}{}
+	return c.advanceFrom(ctx, c.lastProcessedNonce)
+}
+
+// advanceFrom moves the watermark to the provided nonce, then forward as long as the following
+// nonces are processed, and persists it if it changed. Expects the lock to be held.
+func (c *Checkpoint) advanceFrom(ctx context.Context, nonce uint64) error {
+	newWatermark := nonce
+	for {
+		if _, ok := c.processedNonces[newWatermark+1]; !ok {
+			break
+		}
+		delete(c.processedNonces, newWatermark+1)
+		newWatermark++
+	}
+	if newWatermark == c.lastProcessedNonce {
+		return nil
+	}
+	err := c.store.Put(ctx, ds.NewKey(lastProcessedNonceKey), []byte(strconv.FormatUint(newWatermark, 10)))
+	if err != nil {
+		return err
+	}
+	c.lastProcessedNonce = newWatermark
+	return nil
+}
+
+// failedNonceKey creates the key referencing a failed nonce.
+func failedNonceKey(nonce uint64) ds.Key {
+	return ds.NewKey(failedNoncesPrefix + "/" + strconv.FormatUint(nonce, 10))
+}
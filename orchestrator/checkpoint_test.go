@@ -0,0 +1,64 @@
+package orchestrator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/orchestrator"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	store := dssync.MutexWrap(ds.NewMapDatastore())
+
+	checkpoint, err := orchestrator.NewCheckpoint(ctx, store)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), checkpoint.LastProcessedNonce())
+
+	// the watermark doesn't advance if there is a gap
+	require.NoError(t, checkpoint.MarkProcessed(ctx, 2))
+	require.NoError(t, checkpoint.MarkProcessed(ctx, 3))
+	assert.Equal(t, uint64(0), checkpoint.LastProcessedNonce())
+
+	// filling the gap advances the watermark to the highest contiguous nonce
+	require.NoError(t, checkpoint.MarkProcessed(ctx, 1))
+	assert.Equal(t, uint64(3), checkpoint.LastProcessedNonce())
+
+	// failed nonces advance the watermark and are persisted
+	require.NoError(t, checkpoint.MarkFailed(ctx, 4))
+	require.NoError(t, checkpoint.MarkFailed(ctx, 6))
+	assert.Equal(t, uint64(4), checkpoint.LastProcessedNonce())
+	failedNonces, err := checkpoint.FailedNonces(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{4, 6}, failedNonces)
+
+	// processing a failed nonce removes it from the failed nonces
+	require.NoError(t, checkpoint.MarkProcessed(ctx, 4))
+	failedNonces, err = checkpoint.FailedNonces(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{6}, failedNonces)
+
+	// the watermark is loaded from the store
+	reloaded, err := orchestrator.NewCheckpoint(ctx, store)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), reloaded.LastProcessedNonce())
+
+	// setting the floor skips the pruned nonces and merges the already processed ones
+	require.NoError(t, checkpoint.SetFloor(ctx, 5))
+	assert.Equal(t, uint64(6), checkpoint.LastProcessedNonce())
+
+	// a lower floor doesn't move the watermark backwards
+	require.NoError(t, checkpoint.SetFloor(ctx, 2))
+	assert.Equal(t, uint64(6), checkpoint.LastProcessedNonce())
+
+	// removing a failed nonce doesn't affect the watermark
+	require.NoError(t, checkpoint.RemoveFailed(ctx, 6))
+	failedNonces, err = checkpoint.FailedNonces(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, failedNonces)
+	assert.Equal(t, uint64(6), checkpoint.LastProcessedNonce())
+}
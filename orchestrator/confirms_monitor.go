@@ -0,0 +1,161 @@
+package orchestrator
+
+import (
+	"context"
+	goerrors "errors"
+	"strconv"
+	"time"
+
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// ConfirmsMonitorConfig the configuration of the confirms monitor, which periodically verifies that
+// the confirms signed by the orchestrator are retrievable from the P2P network peers.
+type ConfirmsMonitorConfig struct {
+	// Interval the time between two checks.
+	Interval time.Duration
+	// Window the number of recent nonces to check.
+	Window uint64
+	// Rebroadcast if set, the confirms that are not retrievable are broadcast again.
+	Rebroadcast bool
+}
+
+// MonitorConfirms periodically checks that the confirms of the recent nonces are retrievable from
+// the P2P network peers, until the context is canceled.
+func (orch Orchestrator) MonitorConfirms(ctx context.Context, config ConfirmsMonitorConfig) error {
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if goerrors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+			return ctx.Err()
+		case <-ticker.C:
+			missed, err := orch.CheckConfirms(ctx, config.Window, config.Rebroadcast)
+			if err != nil {
+				orch.Logger.Error("failed to check the confirms", "err", err.Error())
+				continue
+			}
+			if len(missed) == 0 {
+				orch.Logger.Debug("all the recent confirms are retrievable from the P2P network", "window", config.Window)
+			}
+		}
+	}
+}
+
+// CheckConfirms checks that the confirms signed by the orchestrator for the last `window` nonces
+// are retrievable from peers other than the orchestrator itself.
+// Returns the nonces whose confirms are not retrievable. If rebroadcast is set, these confirms are
+// signed and broadcast again.
+// The nonces that the orchestrator didn't sign, e.g. because it's not part of the valset, are ignored.
+func (orch Orchestrator) CheckConfirms(ctx context.Context, window uint64, rebroadcast bool) ([]uint64, error) {
+	latestNonce, err := orch.AppQuerier.QueryLatestAttestationNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+	firstNonce := uint64(2) // nonce 1 is not signed
+	if latestNonce >= window && latestNonce-window+1 > firstNonce {
+		firstNonce = latestNonce - window + 1
+	}
+
+	missed := make([]uint64, 0)
+	for nonce := firstNonce; nonce <= latestNonce; nonce++ {
+		if ctx.Err() != nil {
+			return missed, ctx.Err()
+		}
+		isMissed, err := orch.checkConfirm(ctx, nonce, rebroadcast)
+		if err != nil {
+			orch.Logger.Error("failed to check the confirm", "nonce", nonce, "err", err.Error())
+			continue
+		}
+		if isMissed {
+			missed = append(missed, nonce)
+		}
+	}
+	return missed, nil
+}
+
+// checkConfirm checks that the confirm signed for the provided nonce is retrievable from the P2P network peers.
+// Returns true if the nonce was signed but its confirm is not retrievable.
+func (orch Orchestrator) checkConfirm(ctx context.Context, nonce uint64, rebroadcast bool) (bool, error) {
+	digest, err := orch.SlashingProtection.SignedDigest(ctx, nonce)
+	if err != nil {
+		return false, err
+	}
+	if digest == nil {
+		// the nonce wasn't signed by the orchestrator
+		return false, nil
+	}
+
+	att, err := orch.AppQuerier.QueryAttestationByNonce(ctx, nonce)
+	if err != nil {
+		return false, err
+	}
+	if att == nil {
+		return false, celestiatypes.ErrAttestationNotFound
+	}
+	var key string
+	switch att.(type) {
+	case *celestiatypes.Valset:
+		key = p2p.GetValsetConfirmKey(nonce, orch.EvmSigner.Address().Hex(), digest.Hex())
+	case *celestiatypes.DataCommitment:
+		key = p2p.GetDataCommitmentConfirmKey(nonce, orch.EvmSigner.Address().Hex(), digest.Hex())
+	default:
+		return false, errors.Wrap(types.ErrUnknownAttestationType, strconv.FormatUint(nonce, 10))
+	}
+
+	count, err := orch.P2PQuerier.QueryConfirmHoldersCount(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return false, nil
+	}
+
+	orch.Meters.MissedSignatures.Add(ctx, 1)
+	orch.Logger.Error(
+		"signature not retrievable from the P2P network",
+		"nonce", nonce,
+		"evm_address", orch.EvmSigner.Address().Hex(),
+		"digest", digest.Hex(),
+		"key", key,
+	)
+	if rebroadcast {
+		err := orch.rebroadcastConfirm(ctx, att, *digest)
+		if err != nil {
+			orch.Logger.Error("failed to rebroadcast the signature", "nonce", nonce, "err", err.Error())
+		} else {
+			orch.Logger.Info("rebroadcast the signature", "nonce", nonce)
+		}
+	}
+	return true, nil
+}
+
+// rebroadcastConfirm signs the provided digest again and broadcasts its confirm.
+// The digest should be the one recorded in the slashing protection for the attestation nonce.
+func (orch Orchestrator) rebroadcastConfirm(ctx context.Context, att celestiatypes.AttestationRequestI, digest ethcmn.Hash) error {
+	err := orch.SlashingProtection.CheckAndRecord(ctx, att.GetNonce(), digest)
+	if err != nil {
+		return err
+	}
+	signature, err := orch.EvmSigner.SignDigest(ctx, digest.Bytes())
+	if err != nil {
+		return err
+	}
+	switch att.(type) {
+	case *celestiatypes.Valset:
+		msg := types.NewValsetConfirm(orch.EvmSigner.Address(), ethcmn.Bytes2Hex(signature))
+		return orch.Broadcaster.ProvideValsetConfirm(ctx, att.GetNonce(), *msg, digest.Hex())
+	case *celestiatypes.DataCommitment:
+		msg := types.NewDataCommitmentConfirm(ethcmn.Bytes2Hex(signature), orch.EvmSigner.Address())
+		return orch.Broadcaster.ProvideDataCommitmentConfirm(ctx, att.GetNonce(), *msg, digest.Hex())
+	default:
+		return errors.Wrap(types.ErrUnknownAttestationType, strconv.FormatUint(att.GetNonce(), 10))
+	}
+}
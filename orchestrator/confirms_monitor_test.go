@@ -0,0 +1,40 @@
+package orchestrator_test
+
+import (
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *OrchestratorTestSuite) TestCheckConfirms() {
+	t := s.T()
+	_, err := s.Node.CelestiaNetwork.WaitForHeight(250)
+	require.NoError(t, err)
+
+	latestNonce, err := s.Orchestrator.AppQuerier.QueryLatestAttestationNonce(s.Node.Context)
+	require.NoError(t, err)
+	require.Greater(t, latestNonce, uint64(1))
+
+	// nothing signed yet, so nothing is missed
+	missed, err := s.Orchestrator.CheckConfirms(s.Node.Context, 10, false)
+	require.NoError(t, err)
+	assert.Empty(t, missed)
+
+	// recording a signed digest without broadcasting its confirm
+	digest := crypto.Keccak256Hash([]byte("confirms monitor"))
+	err = s.Orchestrator.SlashingProtection.CheckAndRecord(s.Node.Context, latestNonce, digest)
+	require.NoError(t, err)
+
+	missed, err = s.Orchestrator.CheckConfirms(s.Node.Context, 10, false)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{latestNonce}, missed)
+
+	// rebroadcasting the missed confirm makes it retrievable
+	missed, err = s.Orchestrator.CheckConfirms(s.Node.Context, 10, true)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{latestNonce}, missed)
+
+	missed, err = s.Orchestrator.CheckConfirms(s.Node.Context, 10, false)
+	require.NoError(t, err)
+	assert.Empty(t, missed)
+}
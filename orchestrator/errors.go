@@ -3,6 +3,14 @@ package orchestrator
 import "errors"
 
 var (
-	ErrEmptyPeersTable = errors.New("empty peers table")
-	ErrSignalChanNotif = errors.New("signal channel sent notification to stop")
+	ErrEmptyPeersTable     = errors.New("empty peers table")
+	ErrSignalChanNotif     = errors.New("signal channel sent notification to stop")
+	ErrConflictingDigest   = errors.New("conflicting digest already signed for nonce")
+	ErrInvalidSignedDigest = errors.New("invalid signed digest")
+
+	ErrInvalidNonceRange      = errors.New("invalid nonce range")
+	ErrNonceRangeTooLarge     = errors.New("nonce range larger than the nonces queue size")
+	ErrOrchestratorNotRunning = errors.New("orchestrator not running")
+	ErrQueueFull              = errors.New("nonces queue full")
+	ErrEventsListenerStalled  = errors.New("events listener stalled")
 )
@@ -12,8 +12,6 @@ import (
 	"github.com/celestiaorg/orchestrator-relayer/telemetry"
 
 	"github.com/celestiaorg/orchestrator-relayer/evm"
-	"github.com/ethereum/go-ethereum/accounts"
-	"github.com/ethereum/go-ethereum/accounts/keystore"
 
 	"github.com/celestiaorg/orchestrator-relayer/helpers"
 
@@ -39,8 +37,7 @@ const queueSize = 1000
 type Orchestrator struct {
 	Logger tmlog.Logger // maybe use a more general interface
 
-	EvmKeyStore *keystore.KeyStore
-	EvmAccount  *accounts.Account
+	EvmSigner evm.Signer
 
 	AppQuerier  *rpc.AppQuerier
 	TmQuerier   *rpc.TmQuerier
@@ -48,6 +45,13 @@ type Orchestrator struct {
 	Broadcaster *Broadcaster
 	Retrier     *helpers.Retrier
 	Meters      *telemetry.OrchestratorMeters
+	Checkpoint  *Checkpoint
+	State       *State
+
+	SlashingProtection *SlashingProtection
+	// ConfirmsMonitor if set, the orchestrator periodically verifies that its confirms are
+	// retrievable from the P2P network peers.
+	ConfirmsMonitor *ConfirmsMonitorConfig
 }
 
 func New(
@@ -57,20 +61,24 @@ func New(
 	p2pQuerier *p2p.Querier,
 	broadcaster *Broadcaster,
 	retrier *helpers.Retrier,
-	evmKeyStore *keystore.KeyStore,
-	evmAccount *accounts.Account,
+	evmSigner evm.Signer,
 	meters *telemetry.OrchestratorMeters,
+	checkpoint *Checkpoint,
+	slashingProtection *SlashingProtection,
 ) *Orchestrator {
 	return &Orchestrator{
 		Logger:      logger,
-		EvmKeyStore: evmKeyStore,
-		EvmAccount:  evmAccount,
+		EvmSigner:   evmSigner,
 		AppQuerier:  appQuerier,
 		TmQuerier:   tmQuerier,
 		P2PQuerier:  p2pQuerier,
 		Broadcaster: broadcaster,
 		Retrier:     retrier,
 		Meters:      meters,
+		Checkpoint:  checkpoint,
+		State:       NewState(),
+
+		SlashingProtection: slashingProtection,
 	}
 }
 
@@ -82,6 +90,11 @@ func (orch Orchestrator) Start(ctx context.Context) {
 	failedNoncesQueue := make(chan uint64, queueSize)
 	defer close(failedNoncesQueue)
 
+	// referencing the queues in the state so that they're reported by the admin API.
+	// the references are removed before closing the queues.
+	orch.State.setQueues(noncesQueue, failedNoncesQueue)
+	defer orch.State.setQueues(nil, nil)
+
 	// used to send a signal when the nonces processor wants to notify the nonces enqueuing services to stop.
 	signalChan := make(chan struct{})
 
@@ -122,6 +135,20 @@ func (orch Orchestrator) Start(ctx context.Context) {
 		}
 	}()
 
+	if orch.ConfirmsMonitor != nil {
+		// go routine for monitoring the confirms signed by the orchestrator
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := orch.MonitorConfirms(ctx, *orch.ConfirmsMonitor)
+			if err != nil {
+				orch.Logger.Error("error monitoring confirms", "err", err)
+				return
+			}
+			orch.Logger.Info("stopping monitoring confirms")
+		}()
+	}
+
 	wg.Wait()
 }
 
@@ -149,6 +176,9 @@ func (orch Orchestrator) StartNewEventsListener(
 	// which leaves the orchestrator in a hanging state
 	ticker := time.NewTicker(30 * time.Second)
 	for {
+		// the heartbeat is reported by the liveness health check, so that the orchestrator
+		// gets restarted if the listener is stuck or stopped.
+		orch.State.RecordEventsListenerHeartbeat(time.Now())
 		select {
 		case <-signalChan:
 			return nil
@@ -163,6 +193,7 @@ func (orch Orchestrator) StartNewEventsListener(
 			if !running {
 				orch.Logger.Error("tendermint RPC down. Retrying to connect")
 				err := orch.Retrier.Retry(ctx, func() error {
+					orch.State.RecordEventsListenerHeartbeat(time.Now())
 					err := orch.TmQuerier.Reconnect()
 					if err != nil {
 						return err
@@ -193,6 +224,7 @@ func (orch Orchestrator) StartNewEventsListener(
 					return err
 				}
 				orch.Logger.Debug("enqueueing new attestation nonce", "nonce", nonce)
+				orch.State.ObserveNonce(uint64(nonce))
 				select {
 				case <-ctx.Done():
 					return ctx.Err()
@@ -219,37 +251,81 @@ func (orch Orchestrator) EnqueueMissingEvents(
 	if err != nil {
 		return err
 	}
+	orch.State.ObserveNonce(latestNonce)
 
 	earliestAttestationNonce, err := orch.AppQuerier.QueryEarliestAttestationNonce(ctx)
 	if err != nil {
 		return err
 	}
 
-	orch.Logger.Info("syncing missing nonces", "latest_nonce", latestNonce, "first_nonce", earliestAttestationNonce)
+	// the nonces lower than the earliest attestation nonce are pruned and can't be processed anymore.
+	if earliestAttestationNonce > 0 {
+		err = orch.Checkpoint.SetFloor(ctx, uint64(earliestAttestationNonce)-1)
+		if err != nil {
+			return err
+		}
+	}
+
+	// resume from the last processed nonce instead of going over all the unpruned nonces.
+	firstNonce := uint64(earliestAttestationNonce)
+	if lastProcessedNonce := orch.Checkpoint.LastProcessedNonce(); lastProcessedNonce >= firstNonce {
+		firstNonce = lastProcessedNonce + 1
+	}
+
+	failedNonces, err := orch.Checkpoint.FailedNonces(ctx)
+	if err != nil {
+		return err
+	}
+
+	orch.Logger.Info("syncing missing nonces", "latest_nonce", latestNonce, "first_nonce", firstNonce, "failed_nonces_count", len(failedNonces))
 
 	// To accommodate the delay that might happen between starting the two go routines above.
 	// Probably, it would be a good idea to further refactor the orchestrator to the relayer style
 	// as it is entirely synchronous. Probably, enqueuing separately old nonces and new ones, is not
 	// the best design.
 	// TODO decide on this later
-	for i := uint64(0); i < latestNonce-uint64(earliestAttestationNonce)+1; i++ {
+	for nonce := latestNonce; nonce >= firstNonce && nonce > 0; nonce-- {
 		select {
 		case <-signalChan:
 			return nil
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			orch.Logger.Debug("enqueueing missing attestation nonce", "nonce", latestNonce-i)
+			orch.Logger.Debug("enqueueing missing attestation nonce", "nonce", nonce)
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
 			case <-signalChan:
 				return ErrSignalChanNotif
-			case queue <- latestNonce - i:
+			case queue <- nonce:
 			}
 		}
 	}
-	orch.Logger.Info("finished syncing missing nonces", "latest_nonce", latestNonce, "first_nonce", earliestAttestationNonce)
+
+	// re-enqueue the nonces that failed before the orchestrator was restarted
+	for _, nonce := range failedNonces {
+		if nonce < uint64(earliestAttestationNonce) {
+			orch.Logger.Debug("failed nonce was pruned, will not retry it", "nonce", nonce)
+			err := orch.Checkpoint.RemoveFailed(ctx, nonce)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if nonce >= firstNonce {
+			// already enqueued above
+			continue
+		}
+		orch.Logger.Debug("enqueueing previously failed attestation nonce", "nonce", nonce)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-signalChan:
+			return ErrSignalChanNotif
+		case queue <- nonce:
+		}
+	}
+	orch.Logger.Info("finished syncing missing nonces", "latest_nonce", latestNonce, "first_nonce", firstNonce)
 	return nil
 }
 
@@ -272,29 +348,51 @@ func (orch Orchestrator) ProcessNonces(
 					nonce := <-requeueQueue
 					noncesQueue <- nonce
 					orch.Meters.ReprocessedNonces.Add(ctx, 1)
+					orch.State.RecordReprocessed()
 					orch.Logger.Debug("failed nonce added to the nonces queue to be processed", "nonce", nonce)
 				}()
 			}
 		case nonce := <-noncesQueue:
 			orch.Logger.Info("processing nonce", "nonce", nonce)
 			start := time.Now()
-			if err := orch.Process(ctx, nonce); err != nil {
+			failed := false
+			process := orch.Process
+			if orch.State.takeForced(nonce) {
+				process = orch.ForceProcess
+			}
+			if err := process(ctx, nonce); err != nil {
 				orch.Meters.ProcessingTime.Record(ctx, time.Since(start).Seconds())
 				orch.Logger.Error("failed to process nonce, retrying", "nonce", nonce, "err", err)
 				if err := orch.Retrier.Retry(ctx, func() error {
-					return orch.Process(ctx, nonce)
+					return process(ctx, nonce)
 				}); err != nil {
 					orch.Meters.FailedNonces.Add(ctx, 1)
 					orch.Logger.Error("error processing nonce even after retrying", "err", err.Error())
+					failed = true
 					go orch.MaybeRequeue(ctx, requeueQueue, nonce)
 				}
 			}
+			orch.checkpointNonce(ctx, nonce, failed)
 			orch.Meters.ProcessingTime.Record(ctx, time.Since(start).Seconds())
 			orch.Meters.ProcessedNonces.Add(ctx, 1)
 		}
 	}
 }
 
+// checkpointNonce persists the processing result of the nonce so that it's not
+// re-processed when the orchestrator restarts, or gets retried if it failed.
+func (orch Orchestrator) checkpointNonce(ctx context.Context, nonce uint64, failed bool) {
+	var err error
+	if failed {
+		err = orch.Checkpoint.MarkFailed(ctx, nonce)
+	} else {
+		err = orch.Checkpoint.MarkProcessed(ctx, nonce)
+	}
+	if err != nil {
+		orch.Logger.Error("failed to checkpoint nonce", "nonce", nonce, "failed", failed, "err", err.Error())
+	}
+}
+
 // MaybeRequeue requeue the nonce to be re-processed subsequently if it's recent.
 func (orch Orchestrator) MaybeRequeue(ctx context.Context, requeueQueue chan<- uint64, nonce uint64) {
 	latestNonce, err := orch.AppQuerier.QueryLatestAttestationNonce(ctx)
@@ -305,12 +403,29 @@ func (orch Orchestrator) MaybeRequeue(ctx context.Context, requeueQueue chan<- u
 	if latestNonce <= RequeueWindow || nonce >= latestNonce-RequeueWindow {
 		orch.Logger.Debug("adding failed nonce to requeue queue", "nonce", nonce)
 		requeueQueue <- nonce
+		orch.State.RecordRequeued()
 	} else {
 		orch.Logger.Debug("nonce is too old, will not retry it in the future", "nonce", nonce)
 	}
 }
 
+// Process signs the attestation of the provided nonce and provides its confirm to the P2P network,
+// unless the orchestrator doesn't need to sign it, or its confirm is already in the DHT.
 func (orch Orchestrator) Process(ctx context.Context, nonce uint64) error {
+	return orch.process(ctx, nonce, false)
+}
+
+// ForceProcess signs the attestation of the provided nonce and provides its confirm to the
+// P2P network, even if its confirm is already in the DHT.
+// This allows re-providing confirms that are not reachable anymore by the other peers.
+func (orch Orchestrator) ForceProcess(ctx context.Context, nonce uint64) error {
+	return orch.process(ctx, nonce, true)
+}
+
+func (orch Orchestrator) process(ctx context.Context, nonce uint64, force bool) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "Orchestrator.Process", telemetry.Nonce(nonce))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	att, err := orch.AppQuerier.QueryAttestationByNonce(ctx, nonce)
 	if err != nil {
 		return err
@@ -328,7 +443,7 @@ func (orch Orchestrator) Process(ctx context.Context, nonce uint64) error {
 		previousValset, err := orch.AppQuerier.QueryLastValsetBeforeNonce(ctx, att.GetNonce())
 		if err != nil {
 			orch.Logger.Debug("failed to query last valset before nonce (most likely pruned). signing anyway", "err", err.Error())
-		} else if !ValidatorPartOfValset(previousValset.Members, orch.EvmAccount.Address.Hex()) {
+		} else if !ValidatorPartOfValset(previousValset.Members, orch.EvmSigner.Address().Hex()) {
 			// no need to sign if the orchestrator is not part of the validator set that needs to sign the attestation
 			orch.Logger.Info("validator not part of valset. won't sign", "nonce", nonce)
 			return nil
@@ -349,14 +464,16 @@ func (orch Orchestrator) Process(ctx context.Context, nonce uint64) error {
 		if err != nil {
 			return err
 		}
-		orch.Logger.Debug("checking if a signature has already been provided to the P2P network", "nonce", castedAtt.Nonce)
-		resp, err := orch.P2PQuerier.QueryValsetConfirmByEVMAddress(ctx, nonce, orch.EvmAccount.Address.Hex(), signBytes.Hex())
-		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("valset %d", nonce))
-		}
-		if resp != nil {
-			orch.Logger.Debug("already signed valset", "nonce", nonce, "signature", resp.Signature)
-			return nil
+		if !force {
+			orch.Logger.Debug("checking if a signature has already been provided to the P2P network", "nonce", castedAtt.Nonce)
+			resp, err := orch.P2PQuerier.QueryValsetConfirmByEVMAddress(ctx, nonce, orch.EvmSigner.Address().Hex(), signBytes.Hex())
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("valset %d", nonce))
+			}
+			if resp != nil {
+				orch.Logger.Debug("already signed valset", "nonce", nonce, "signature", resp.Signature)
+				return nil
+			}
 		}
 		err = orch.ProcessValsetEvent(ctx, *castedAtt)
 		if err != nil {
@@ -376,19 +493,21 @@ func (orch Orchestrator) Process(ctx context.Context, nonce uint64) error {
 		}
 		orch.Logger.Debug("creating data commitment sign bytes", "nonce", castedAtt.Nonce)
 		dataRootHash := types.DataCommitmentTupleRootSignBytes(big.NewInt(int64(castedAtt.Nonce)), commitment)
-		orch.Logger.Debug("checking if a signature has already been provided to the P2P network", "nonce", nonce)
-		resp, err := orch.P2PQuerier.QueryDataCommitmentConfirmByEVMAddress(
-			ctx,
-			castedAtt.Nonce,
-			orch.EvmAccount.Address.Hex(),
-			dataRootHash.Hex(),
-		)
-		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("data commitment %d", nonce))
-		}
-		if resp != nil {
-			orch.Logger.Debug("already signed data commitment", "nonce", nonce, "begin_block", castedAtt.BeginBlock, "end_block", castedAtt.EndBlock, "data_root_tuple_root", dataRootHash.Hex(), "signature", resp.Signature)
-			return nil
+		if !force {
+			orch.Logger.Debug("checking if a signature has already been provided to the P2P network", "nonce", nonce)
+			resp, err := orch.P2PQuerier.QueryDataCommitmentConfirmByEVMAddress(
+				ctx,
+				castedAtt.Nonce,
+				orch.EvmSigner.Address().Hex(),
+				dataRootHash.Hex(),
+			)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("data commitment %d", nonce))
+			}
+			if resp != nil {
+				orch.Logger.Debug("already signed data commitment", "nonce", nonce, "begin_block", castedAtt.BeginBlock, "end_block", castedAtt.EndBlock, "data_root_tuple_root", dataRootHash.Hex(), "signature", resp.Signature)
+				return nil
+			}
 		}
 		err = orch.ProcessDataCommitmentEvent(ctx, *castedAtt, dataRootHash)
 		if err != nil {
@@ -401,7 +520,10 @@ func (orch Orchestrator) Process(ctx context.Context, nonce uint64) error {
 	}
 }
 
-func (orch Orchestrator) ProcessValsetEvent(ctx context.Context, valset celestiatypes.Valset) error {
+func (orch Orchestrator) ProcessValsetEvent(ctx context.Context, valset celestiatypes.Valset) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "Orchestrator.ProcessValsetEvent", telemetry.Nonce(valset.Nonce))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	// add the valset to the p2p network
 	// it's alright if this fails, we can expect other nodes to do it successfully
 	orch.Logger.Debug("providing the latest valset to P2P network", "nonce", valset.Nonce)
@@ -411,22 +533,28 @@ func (orch Orchestrator) ProcessValsetEvent(ctx context.Context, valset celestia
 	if err != nil {
 		return err
 	}
+	err = orch.SlashingProtection.CheckAndRecord(ctx, valset.Nonce, signBytes)
+	if err != nil {
+		return err
+	}
 	orch.Logger.Debug("signing valset", "nonce", valset.Nonce)
-	signature, err := evm.NewEthereumSignature(signBytes.Bytes(), orch.EvmKeyStore, *orch.EvmAccount)
+	signature, err := orch.EvmSigner.SignDigest(ctx, signBytes.Bytes())
 	if err != nil {
 		return err
 	}
 
 	// create and send the valset hash
 	msg := types.NewValsetConfirm(
-		orch.EvmAccount.Address,
+		orch.EvmSigner.Address(),
 		ethcmn.Bytes2Hex(signature),
 	)
+	msg.TraceParent = telemetry.TraceParent(ctx)
 	orch.Logger.Debug("providing the valset confirm to P2P network", "nonce", valset.Nonce)
 	err = orch.Broadcaster.ProvideValsetConfirm(ctx, valset.Nonce, *msg, signBytes.Hex())
 	if err != nil {
 		return err
 	}
+	orch.State.RecordSigned(time.Now())
 	orch.Logger.Info("signed Valset", "nonce", valset.Nonce)
 	return nil
 }
@@ -435,18 +563,27 @@ func (orch Orchestrator) ProcessDataCommitmentEvent(
 	ctx context.Context,
 	dc celestiatypes.DataCommitment,
 	dataRootTupleRoot ethcmn.Hash,
-) error {
+) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "Orchestrator.ProcessDataCommitmentEvent", telemetry.Nonce(dc.Nonce))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	err = orch.SlashingProtection.CheckAndRecord(ctx, dc.Nonce, dataRootTupleRoot)
+	if err != nil {
+		return err
+	}
 	orch.Logger.Debug("signing data commitment", "nonce", dc.Nonce)
-	dcSig, err := evm.NewEthereumSignature(dataRootTupleRoot.Bytes(), orch.EvmKeyStore, *orch.EvmAccount)
+	dcSig, err := orch.EvmSigner.SignDigest(ctx, dataRootTupleRoot.Bytes())
 	if err != nil {
 		return err
 	}
-	msg := types.NewDataCommitmentConfirm(ethcmn.Bytes2Hex(dcSig), orch.EvmAccount.Address)
+	msg := types.NewDataCommitmentConfirm(ethcmn.Bytes2Hex(dcSig), orch.EvmSigner.Address())
+	msg.TraceParent = telemetry.TraceParent(ctx)
 	orch.Logger.Debug("providing the data commitment confirm to P2P network", "nonce", dc.Nonce)
 	err = orch.Broadcaster.ProvideDataCommitmentConfirm(ctx, dc.Nonce, *msg, dataRootTupleRoot.Hex())
 	if err != nil {
 		return err
 	}
+	orch.State.RecordSigned(time.Now())
 	orch.Logger.Info("signed commitment", "nonce", dc.Nonce, "begin_block", dc.BeginBlock, "end_block", dc.EndBlock, "data_root_tuple_root", dataRootTupleRoot.Hex())
 	return nil
 }
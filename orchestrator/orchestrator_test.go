@@ -41,10 +41,17 @@ func (s *OrchestratorTestSuite) TestProcessDataCommitmentEvent() {
 	// retrieving the signature
 	confirm, err := s.Node.DHTNetwork.DHTs[0].GetDataCommitmentConfirm(
 		s.Node.Context,
-		p2p.GetDataCommitmentConfirmKey(2, s.Orchestrator.EvmAccount.Address.Hex(), dataRootTupleRoot.Hex()),
+		p2p.GetDataCommitmentConfirmKey(2, s.Orchestrator.EvmSigner.Address().Hex(), dataRootTupleRoot.Hex()),
 	)
 	require.NoError(t, err)
-	assert.Equal(t, s.Orchestrator.EvmAccount.Address.Hex(), confirm.EthAddress)
+	assert.Equal(t, s.Orchestrator.EvmSigner.Address().Hex(), confirm.EthAddress)
+
+	// signing a conflicting data commitment for the same nonce is refused
+	conflictingCommitment, err := hexutil.Decode("0x5678")
+	require.NoError(t, err)
+	conflictingDataRootTupleRoot := types.DataCommitmentTupleRootSignBytes(big.NewInt(2), conflictingCommitment)
+	err = s.Orchestrator.ProcessDataCommitmentEvent(s.Node.Context, *dc, conflictingDataRootTupleRoot)
+	assert.ErrorIs(t, err, orchestrator.ErrConflictingDigest)
 }
 
 func (s *OrchestratorTestSuite) TestProcessValsetEvent() {
@@ -57,7 +64,7 @@ func (s *OrchestratorTestSuite) TestProcessValsetEvent() {
 		10,
 		[]*celestiatypes.InternalBridgeValidator{{
 			Power:      10,
-			EVMAddress: s.Orchestrator.EvmAccount.Address,
+			EVMAddress: s.Orchestrator.EvmSigner.Address(),
 		}},
 		time.Now(),
 	)
@@ -73,10 +80,10 @@ func (s *OrchestratorTestSuite) TestProcessValsetEvent() {
 	// retrieving the signature
 	confirm, err := s.Node.DHTNetwork.DHTs[0].GetValsetConfirm(
 		s.Node.Context,
-		p2p.GetValsetConfirmKey(2, s.Orchestrator.EvmAccount.Address.Hex(), signBytes.Hex()),
+		p2p.GetValsetConfirmKey(2, s.Orchestrator.EvmSigner.Address().Hex(), signBytes.Hex()),
 	)
 	require.NoError(t, err)
-	assert.Equal(t, s.Orchestrator.EvmAccount.Address.Hex(), confirm.EthAddress)
+	assert.Equal(t, s.Orchestrator.EvmSigner.Address().Hex(), confirm.EthAddress)
 }
 
 func (s *OrchestratorTestSuite) TestProcessValsetEventAndProvideValset() {
@@ -89,7 +96,7 @@ func (s *OrchestratorTestSuite) TestProcessValsetEventAndProvideValset() {
 		10,
 		[]*celestiatypes.InternalBridgeValidator{{
 			Power:      10,
-			EVMAddress: s.Orchestrator.EvmAccount.Address,
+			EVMAddress: s.Orchestrator.EvmSigner.Address(),
 		}},
 		time.Now(),
 	)
@@ -219,4 +226,6 @@ func TestProcessWithoutValsetInStore(t *testing.T) {
 	latestNonce, err := orch.AppQuerier.QueryLatestAttestationNonce(ctx)
 	require.NoError(t, err)
 	assert.NoError(t, orch.Process(ctx, latestNonce))
+	// force processing the nonce provides the same confirm again
+	assert.NoError(t, orch.ForceProcess(ctx, latestNonce))
 }
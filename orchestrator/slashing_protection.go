@@ -0,0 +1,152 @@
+package orchestrator
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/pkg/errors"
+)
+
+// slashingProtectionPrefix the prefix of the keys referencing the signed digests.
+const slashingProtectionPrefix = CheckpointNamespace + "/signed"
+
+// SignedDigest a record of a digest signed by the orchestrator for a certain nonce.
+type SignedDigest struct {
+	Nonce  uint64 `json:"nonce"`
+	Digest string `json:"digest"`
+}
+
+// SlashingProtectionHistory the interchange format used to export and import
+// the slashing protection records.
+type SlashingProtectionHistory struct {
+	SignedDigests []SignedDigest `json:"signed_digests"`
+}
+
+// SlashingProtection keeps an append-only record of the digests signed by the orchestrator
+// in the data store, and refuses to sign a digest for a nonce that was already signed
+// with a different digest.
+// This protects the orchestrator from signing conflicting attestations, e.g. when
+// connected to a forked or misconfigured Celestia node.
+type SlashingProtection struct {
+	store ds.Datastore
+	mu    sync.Mutex
+}
+
+// NewSlashingProtection creates a new slashing protection backed by the provided data store.
+func NewSlashingProtection(store ds.Datastore) *SlashingProtection {
+	return &SlashingProtection{store: store}
+}
+
+// CheckAndRecord records the digest as signed for the provided nonce.
+// Returns ErrConflictingDigest if a different digest was already signed for the same nonce.
+// Recording the same digest multiple times is allowed.
+func (sp *SlashingProtection) CheckAndRecord(ctx context.Context, nonce uint64, digest ethcmn.Hash) error {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	if err := sp.check(ctx, nonce, digest); err != nil {
+		return err
+	}
+	return sp.store.Put(ctx, signedDigestKey(nonce), []byte(digest.Hex()))
+}
+
+// SignedDigest returns the digest signed for the provided nonce, or nil if none was signed.
+func (sp *SlashingProtection) SignedDigest(ctx context.Context, nonce uint64) (*ethcmn.Hash, error) {
+	value, err := sp.store.Get(ctx, signedDigestKey(nonce))
+	if err != nil {
+		if goerrors.Is(err, ds.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	digest := ethcmn.HexToHash(string(value))
+	return &digest, nil
+}
+
+// Export returns all the signed digests sorted by nonce.
+func (sp *SlashingProtection) Export(ctx context.Context) (*SlashingProtectionHistory, error) {
+	results, err := sp.store.Query(ctx, query.Query{Prefix: slashingProtectionPrefix})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	history := &SlashingProtectionHistory{SignedDigests: make([]SignedDigest, 0)}
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		nonce, err := strconv.ParseUint(strings.TrimPrefix(result.Key, slashingProtectionPrefix+"/"), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		history.SignedDigests = append(history.SignedDigests, SignedDigest{
+			Nonce:  nonce,
+			Digest: string(result.Value),
+		})
+	}
+	sort.Slice(history.SignedDigests, func(i, j int) bool {
+		return history.SignedDigests[i].Nonce < history.SignedDigests[j].Nonce
+	})
+	return history, nil
+}
+
+// Import adds the provided signed digests to the records.
+// If any of the imported digests conflicts with an existing one, nothing is imported
+// and ErrConflictingDigest is returned.
+func (sp *SlashingProtection) Import(ctx context.Context, history SlashingProtectionHistory) error {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	imported := make(map[uint64]ethcmn.Hash, len(history.SignedDigests))
+	for _, signedDigest := range history.SignedDigests {
+		rawDigest, err := hexutil.Decode(signedDigest.Digest)
+		if err != nil || len(rawDigest) != ethcmn.HashLength {
+			return errors.Wrap(ErrInvalidSignedDigest, fmt.Sprintf("nonce %d", signedDigest.Nonce))
+		}
+		digest := ethcmn.BytesToHash(rawDigest)
+		if previous, ok := imported[signedDigest.Nonce]; ok && previous != digest {
+			return errors.Wrap(ErrConflictingDigest, fmt.Sprintf("nonce %d", signedDigest.Nonce))
+		}
+		if err := sp.check(ctx, signedDigest.Nonce, digest); err != nil {
+			return err
+		}
+		imported[signedDigest.Nonce] = digest
+	}
+	for nonce, digest := range imported {
+		if err := sp.store.Put(ctx, signedDigestKey(nonce), []byte(digest.Hex())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check verifies that the digest doesn't conflict with an already signed one. Expects the lock to be held.
+func (sp *SlashingProtection) check(ctx context.Context, nonce uint64, digest ethcmn.Hash) error {
+	value, err := sp.store.Get(ctx, signedDigestKey(nonce))
+	if err != nil {
+		if goerrors.Is(err, ds.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if ethcmn.HexToHash(string(value)) != digest {
+		return errors.Wrap(
+			ErrConflictingDigest,
+			fmt.Sprintf("nonce %d: signed digest %s, requested digest %s", nonce, string(value), digest.Hex()),
+		)
+	}
+	return nil
+}
+
+// signedDigestKey creates the key referencing the digest signed for a nonce.
+func signedDigestKey(nonce uint64) ds.Key {
+	return ds.NewKey(slashingProtectionPrefix + "/" + strconv.FormatUint(nonce, 10))
+}
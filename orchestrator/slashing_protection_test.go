@@ -0,0 +1,67 @@
+package orchestrator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/orchestrator"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlashingProtection(t *testing.T) {
+	ctx := context.Background()
+	sp := orchestrator.NewSlashingProtection(dssync.MutexWrap(ds.NewMapDatastore()))
+	digest1 := ethcmn.HexToHash("0x078c42ff72a01b355f9d76bfeecd2132a0d3f1aad9380870026c56e23e6d00e5")
+	digest2 := ethcmn.HexToHash("0x1d2c0ec1cdc5da4ba2a6a5c5ab2f6a1f5d1ccbe8b4d5f7b44e3a1e6b1d0a8f4c")
+
+	signed, err := sp.SignedDigest(ctx, 10)
+	require.NoError(t, err)
+	assert.Nil(t, signed)
+
+	// signing the same digest multiple times is allowed
+	require.NoError(t, sp.CheckAndRecord(ctx, 10, digest1))
+	require.NoError(t, sp.CheckAndRecord(ctx, 10, digest1))
+	signed, err = sp.SignedDigest(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, digest1, *signed)
+
+	// signing a conflicting digest is refused
+	err = sp.CheckAndRecord(ctx, 10, digest2)
+	assert.ErrorIs(t, err, orchestrator.ErrConflictingDigest)
+
+	require.NoError(t, sp.CheckAndRecord(ctx, 2, digest2))
+	history, err := sp.Export(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []orchestrator.SignedDigest{
+		{Nonce: 2, Digest: digest2.Hex()},
+		{Nonce: 10, Digest: digest1.Hex()},
+	}, history.SignedDigests)
+
+	// importing the history into a new store
+	imported := orchestrator.NewSlashingProtection(dssync.MutexWrap(ds.NewMapDatastore()))
+	require.NoError(t, imported.CheckAndRecord(ctx, 11, digest1))
+	require.NoError(t, imported.Import(ctx, *history))
+	importedHistory, err := imported.Export(ctx)
+	require.NoError(t, err)
+	assert.Len(t, importedHistory.SignedDigests, 3)
+
+	// a conflicting import is rejected entirely
+	err = imported.Import(ctx, orchestrator.SlashingProtectionHistory{SignedDigests: []orchestrator.SignedDigest{
+		{Nonce: 12, Digest: digest1.Hex()},
+		{Nonce: 11, Digest: digest2.Hex()},
+	}})
+	assert.ErrorIs(t, err, orchestrator.ErrConflictingDigest)
+	signed, err = imported.SignedDigest(ctx, 12)
+	require.NoError(t, err)
+	assert.Nil(t, signed)
+
+	// invalid digests are rejected
+	err = imported.Import(ctx, orchestrator.SlashingProtectionHistory{SignedDigests: []orchestrator.SignedDigest{
+		{Nonce: 13, Digest: "0x1234"},
+	}})
+	assert.ErrorIs(t, err, orchestrator.ErrInvalidSignedDigest)
+}
@@ -0,0 +1,179 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// signedNoncesWindow the window during which the signed nonces are counted in the orchestrator state.
+const signedNoncesWindow = time.Hour
+
+// EventsListenerHeartbeatTimeout the maximum time since the last heartbeat of the events listener
+// for it to be considered alive.
+const EventsListenerHeartbeatTimeout = 5 * time.Minute
+
+// State keeps track of the live processing state of the orchestrator, so that it can be
+// exposed to the operators via the admin API instead of having to go through the logs.
+type State struct {
+	mu sync.Mutex
+	// latestSeenNonce the highest attestation nonce seen by the orchestrator.
+	latestSeenNonce uint64
+	// signedAt the signing times of the nonces signed during the last signedNoncesWindow.
+	signedAt []time.Time
+	// requeuedNonces the number of failed nonces added to the failed nonces queue.
+	requeuedNonces uint64
+	// reprocessedNonces the number of failed nonces moved back to the nonces queue.
+	reprocessedNonces uint64
+	// eventsListenerHeartbeat the last time the events listener loop made progress.
+	// Zero if the events listener didn't start yet.
+	eventsListenerHeartbeat time.Time
+	// forcedNonces the enqueued nonces to be processed even if their confirm is already in the DHT.
+	forcedNonces map[uint64]struct{}
+
+	// noncesQueue and failedNoncesQueue are only set while the orchestrator is running.
+	noncesQueue       chan uint64
+	failedNoncesQueue chan uint64
+}
+
+// NewState creates a new empty orchestrator state.
+func NewState() *State {
+	return &State{forcedNonces: make(map[uint64]struct{})}
+}
+
+// StateSnapshot a point in time copy of the orchestrator state.
+type StateSnapshot struct {
+	LatestSeenNonce        uint64
+	NoncesQueueDepth       int
+	FailedNoncesQueueDepth int
+	NoncesSignedLastHour   int
+	RequeuedNonces         uint64
+	ReprocessedNonces      uint64
+}
+
+// setQueues references the orchestrator queues so that their depth can be reported, and
+// nonces can be enqueued to be re-processed. Nil queues should be set before closing them.
+func (s *State) setQueues(noncesQueue chan uint64, failedNoncesQueue chan uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.noncesQueue = noncesQueue
+	s.failedNoncesQueue = failedNoncesQueue
+}
+
+// ObserveNonce records the provided attestation nonce as seen if it is higher than the latest one.
+func (s *State) ObserveNonce(nonce uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if nonce > s.latestSeenNonce {
+		s.latestSeenNonce = nonce
+	}
+}
+
+// RecordEventsListenerHeartbeat records that the events listener loop made progress at the provided time.
+func (s *State) RecordEventsListenerHeartbeat(at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.eventsListenerHeartbeat = at
+}
+
+// CheckEventsListener returns an error if the events listener started but didn't record a heartbeat
+// during the last EventsListenerHeartbeatTimeout, i.e. it's stuck or stopped.
+func (s *State) CheckEventsListener(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.eventsListenerHeartbeat.IsZero() {
+		return nil
+	}
+	if elapsed := now.Sub(s.eventsListenerHeartbeat); elapsed > EventsListenerHeartbeatTimeout {
+		return fmt.Errorf("%w: last heartbeat %s ago", ErrEventsListenerStalled, elapsed.Round(time.Second))
+	}
+	return nil
+}
+
+// RecordSigned records that a nonce was signed at the provided time.
+func (s *State) RecordSigned(at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneSigned(at)
+	s.signedAt = append(s.signedAt, at)
+}
+
+// RecordRequeued records that a failed nonce was added to the failed nonces queue.
+func (s *State) RecordRequeued() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requeuedNonces++
+}
+
+// RecordReprocessed records that a failed nonce was moved back to the nonces queue.
+func (s *State) RecordReprocessed() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reprocessedNonces++
+}
+
+// Snapshot returns a copy of the state at the provided time.
+func (s *State) Snapshot(now time.Time) StateSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneSigned(now)
+	return StateSnapshot{
+		LatestSeenNonce:        s.latestSeenNonce,
+		NoncesQueueDepth:       len(s.noncesQueue),
+		FailedNoncesQueueDepth: len(s.failedNoncesQueue),
+		NoncesSignedLastHour:   len(s.signedAt),
+		RequeuedNonces:         s.requeuedNonces,
+		ReprocessedNonces:      s.reprocessedNonces,
+	}
+}
+
+// Reprocess enqueues the nonces in the [from, to] range to be processed again.
+// If force is true, the nonces are signed and their confirms provided again even if they're
+// already in the DHT.
+// Returns the number of enqueued nonces. If the nonces queue gets full, the remaining nonces
+// are not enqueued and ErrQueueFull is returned.
+func (s *State) Reprocess(from uint64, to uint64, force bool) (int, error) {
+	if from == 0 || from > to {
+		return 0, ErrInvalidNonceRange
+	}
+	if to-from >= queueSize {
+		return 0, ErrNonceRangeTooLarge
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.noncesQueue == nil {
+		return 0, ErrOrchestratorNotRunning
+	}
+	count := 0
+	for nonce := from; nonce <= to; nonce++ {
+		select {
+		case s.noncesQueue <- nonce:
+			if force {
+				s.forcedNonces[nonce] = struct{}{}
+			}
+			count++
+		default:
+			// not blocking while holding the lock, as the queues are only consumed while the orchestrator is running.
+			return count, ErrQueueFull
+		}
+	}
+	return count, nil
+}
+
+// takeForced returns true if the nonce was enqueued to be force processed, and clears it.
+func (s *State) takeForced(nonce uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, forced := s.forcedNonces[nonce]
+	delete(s.forcedNonces, nonce)
+	return forced
+}
+
+// pruneSigned removes the signing times that are outside the signed nonces window.
+func (s *State) pruneSigned(now time.Time) {
+	i := 0
+	for i < len(s.signedAt) && now.Sub(s.signedAt[i]) > signedNoncesWindow {
+		i++
+	}
+	s.signedAt = s.signedAt[i:]
+}
@@ -0,0 +1,61 @@
+package orchestrator_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/orchestrator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState(t *testing.T) {
+	state := orchestrator.NewState()
+	now := time.Now()
+
+	state.ObserveNonce(10)
+	state.ObserveNonce(5)
+	state.RecordSigned(now.Add(-2 * time.Hour))
+	state.RecordSigned(now.Add(-30 * time.Minute))
+	state.RecordSigned(now)
+	state.RecordRequeued()
+	state.RecordRequeued()
+	state.RecordReprocessed()
+
+	snapshot := state.Snapshot(now)
+	assert.Equal(t, uint64(10), snapshot.LatestSeenNonce)
+	assert.Equal(t, 2, snapshot.NoncesSignedLastHour)
+	assert.Equal(t, uint64(2), snapshot.RequeuedNonces)
+	assert.Equal(t, uint64(1), snapshot.ReprocessedNonces)
+	assert.Equal(t, 0, snapshot.NoncesQueueDepth)
+	assert.Equal(t, 0, snapshot.FailedNoncesQueueDepth)
+
+	assert.Equal(t, 1, state.Snapshot(now.Add(45*time.Minute)).NoncesSignedLastHour)
+}
+
+func TestStateReprocess(t *testing.T) {
+	state := orchestrator.NewState()
+
+	_, err := state.Reprocess(0, 10, false)
+	assert.ErrorIs(t, err, orchestrator.ErrInvalidNonceRange)
+	_, err = state.Reprocess(10, 5, false)
+	assert.ErrorIs(t, err, orchestrator.ErrInvalidNonceRange)
+	_, err = state.Reprocess(1, 5000, false)
+	assert.ErrorIs(t, err, orchestrator.ErrNonceRangeTooLarge)
+
+	count, err := state.Reprocess(1, 10, false)
+	require.ErrorIs(t, err, orchestrator.ErrOrchestratorNotRunning)
+	assert.Equal(t, 0, count)
+}
+
+func TestStateCheckEventsListener(t *testing.T) {
+	state := orchestrator.NewState()
+	now := time.Now()
+
+	// the events listener is considered alive until it starts
+	assert.NoError(t, state.CheckEventsListener(now))
+
+	state.RecordEventsListenerHeartbeat(now)
+	assert.NoError(t, state.CheckEventsListener(now.Add(orchestrator.EventsListenerHeartbeatTimeout)))
+	assert.ErrorIs(t, state.CheckEventsListener(now.Add(orchestrator.EventsListenerHeartbeatTimeout+time.Second)), orchestrator.ErrEventsListenerStalled)
+}
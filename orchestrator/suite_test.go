@@ -11,6 +11,8 @@ import (
 	"github.com/celestiaorg/celestia-app/x/qgb/types"
 	"github.com/celestiaorg/orchestrator-relayer/orchestrator"
 	blobstreamtesting "github.com/celestiaorg/orchestrator-relayer/testing"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -40,6 +42,11 @@ func (s *OrchestratorTestSuite) SetupSuite() {
 	s.Orchestrator = blobstreamtesting.NewOrchestrator(t, s.Node)
 }
 
+// SetupTest resets the slashing protection records since the tests sign different digests for the same nonces.
+func (s *OrchestratorTestSuite) SetupTest() {
+	s.Orchestrator.SlashingProtection = orchestrator.NewSlashingProtection(dssync.MutexWrap(ds.NewMapDatastore()))
+}
+
 func (s *OrchestratorTestSuite) TearDownSuite() {
 	s.Node.Close()
 }
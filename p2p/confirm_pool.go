@@ -0,0 +1,208 @@
+package p2p
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/rpc"
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	// ConfirmPoolRetention the number of nonces, before the latest attestation nonce, for which
+	// the confirms are kept in the confirm pool.
+	ConfirmPoolRetention = uint64(1000)
+	// DefaultConfirmPoolUpdateInterval the default time between two updates of the latest attestation
+	// nonce and the valset members the confirm pool accepts confirms for.
+	DefaultConfirmPoolUpdateInterval = 10 * time.Second
+)
+
+// ConfirmPool a local pool of the confirms received via the confirms gossip topic.
+// It's used to avoid querying the DHT for confirms that were already received.
+// Only the confirms signed by the current or previous valset members, for attestation nonces
+// known to the app, are accepted. So, the pool accepts no confirm until it's updated.
+// A single confirm is kept per signer and nonce, so that a valset member signing many different
+// digests can't evict the confirms of the other members.
+type ConfirmPool struct {
+	logger tmlog.Logger
+
+	mu       sync.RWMutex
+	confirms map[string]poolConfirm
+	// signers the key of the confirm held for every signer and nonce.
+	signers map[poolSigner]string
+	// latestNonce the latest attestation nonce known from the app.
+	latestNonce uint64
+	// members the EVM addresses of the current and previous valset members.
+	members map[ethcmn.Address]struct{}
+}
+
+type poolConfirm struct {
+	nonce uint64
+	value []byte
+}
+
+type poolSigner struct {
+	nonce  uint64
+	signer ethcmn.Address
+}
+
+// NewConfirmPool creates a new empty ConfirmPool.
+func NewConfirmPool(logger tmlog.Logger) *ConfirmPool {
+	return &ConfirmPool{
+		logger:   logger,
+		confirms: make(map[string]poolConfirm),
+		signers:  make(map[poolSigner]string),
+		members:  make(map[ethcmn.Address]struct{}),
+	}
+}
+
+// Add adds the provided confirm to the pool.
+// The confirm value is expected to be already validated against its key.
+// Returns an error if the confirm is for a nonce that is unknown or out of the retention, if it's not
+// signed by a valset member, or if the pool already holds a confirm of its signer for a different
+// digest of the same nonce.
+func (p *ConfirmPool) Add(key string, value []byte) error {
+	_, nonce, evmAddr, _, err := ParseKey(key)
+	if err != nil {
+		return err
+	}
+	if !ethcmn.IsHexAddress(evmAddr) {
+		return ErrInvalidEVMAddress
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if nonce > p.latestNonce || p.isOutOfRetention(nonce) {
+		return ErrConfirmNonceOutOfRange
+	}
+	signer := ethcmn.HexToAddress(evmAddr)
+	if _, isMember := p.members[signer]; !isMember {
+		return ErrConfirmSignerNotMember
+	}
+	ps := poolSigner{nonce: nonce, signer: signer}
+	if existingKey, has := p.signers[ps]; has && existingKey != key {
+		return ErrConfirmSignerDuplicate
+	}
+	p.signers[ps] = key
+	p.confirms[key] = poolConfirm{nonce: nonce, value: value}
+	return nil
+}
+
+// SetLatestNonce sets the latest attestation nonce known from the app, and prunes the confirms
+// that fall out of the retention. The latest nonce never decreases.
+func (p *ConfirmPool) SetLatestNonce(nonce uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if nonce <= p.latestNonce {
+		return
+	}
+	p.latestNonce = nonce
+	for k, confirm := range p.confirms {
+		if p.isOutOfRetention(confirm.nonce) {
+			delete(p.confirms, k)
+		}
+	}
+	for ps := range p.signers {
+		if p.isOutOfRetention(ps.nonce) {
+			delete(p.signers, ps)
+		}
+	}
+}
+
+// SetValsetMembers sets the EVM addresses whose confirms are accepted.
+func (p *ConfirmPool) SetValsetMembers(addresses []ethcmn.Address) {
+	members := make(map[ethcmn.Address]struct{}, len(addresses))
+	for _, address := range addresses {
+		members[address] = struct{}{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.members = members
+}
+
+// Update queries the latest attestation nonce, and the members of the latest valset and the one
+// before it, so that the pool accepts their confirms.
+func (p *ConfirmPool) Update(ctx context.Context, appQuerier *rpc.AppQuerier) error {
+	latestNonce, err := appQuerier.QueryLatestAttestationNonce(ctx)
+	if err != nil {
+		return err
+	}
+	addresses, err := queryValsetMembers(ctx, appQuerier)
+	if err != nil {
+		return err
+	}
+	p.SetValsetMembers(addresses)
+	p.SetLatestNonce(latestNonce)
+	return nil
+}
+
+// StartUpdates updates the pool right away, then every interval until the context is canceled.
+func (p *ConfirmPool) StartUpdates(ctx context.Context, appQuerier *rpc.AppQuerier, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := p.Update(ctx, appQuerier)
+		if err != nil && ctx.Err() == nil {
+			p.logger.Error("couldn't update the confirm pool", "err", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// isOutOfRetention returns true if the nonce is older than the retention.
+// Expects the lock to be held.
+func (p *ConfirmPool) isOutOfRetention(nonce uint64) bool {
+	return p.latestNonce > ConfirmPoolRetention && nonce < p.latestNonce-ConfirmPoolRetention
+}
+
+// Len returns the number of confirms in the pool.
+func (p *ConfirmPool) Len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.confirms)
+}
+
+// get returns the confirm value referenced by the provided key.
+func (p *ConfirmPool) get(key string) ([]byte, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	confirm, has := p.confirms[key]
+	return confirm.value, has
+}
+
+// GetDataCommitmentConfirm returns the data commitment confirm referenced by the provided key.
+// Returns false if the confirm is not in the pool.
+// The key can be generated using the `GetDataCommitmentConfirmKey` method.
+func (p *ConfirmPool) GetDataCommitmentConfirm(key string) (types.DataCommitmentConfirm, bool) {
+	value, has := p.get(key)
+	if !has {
+		return types.DataCommitmentConfirm{}, false
+	}
+	confirm, err := types.UnmarshalDataCommitmentConfirm(value)
+	if err != nil {
+		return types.DataCommitmentConfirm{}, false
+	}
+	return confirm, true
+}
+
+// GetValsetConfirm returns the valset confirm referenced by the provided key.
+// Returns false if the confirm is not in the pool.
+// The key can be generated using the `GetValsetConfirmKey` method.
+func (p *ConfirmPool) GetValsetConfirm(key string) (types.ValsetConfirm, bool) {
+	value, has := p.get(key)
+	if !has {
+		return types.ValsetConfirm{}, false
+	}
+	confirm, err := types.UnmarshalValsetConfirm(value)
+	if err != nil {
+		return types.ValsetConfirm{}, false
+	}
+	return confirm, true
+}
@@ -0,0 +1,87 @@
+package p2p_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+func TestConfirmPool(t *testing.T) {
+	pool := p2p.NewConfirmPool(tmlog.NewNopLogger())
+
+	vc := types.ValsetConfirm{EthAddress: evmAddress, Signature: "signature"}
+	encodedVc, err := types.MarshalValsetConfirm(vc)
+	require.NoError(t, err)
+	vcKey := p2p.GetValsetConfirmKey(1, evmAddress, "0x1234")
+
+	// no confirm is accepted before the pool is updated
+	assert.ErrorIs(t, pool.Add(vcKey, encodedVc), p2p.ErrConfirmNonceOutOfRange)
+	pool.SetLatestNonce(2)
+	assert.ErrorIs(t, pool.Add(vcKey, encodedVc), p2p.ErrConfirmSignerNotMember)
+	pool.SetValsetMembers([]common.Address{common.HexToAddress(evmAddress)})
+	require.NoError(t, pool.Add(vcKey, encodedVc))
+
+	dcc := types.DataCommitmentConfirm{EthAddress: evmAddress, Signature: "signature"}
+	encodedDcc, err := types.MarshalDataCommitmentConfirm(dcc)
+	require.NoError(t, err)
+	dccKey := p2p.GetDataCommitmentConfirmKey(2, evmAddress, "0x1234")
+	require.NoError(t, pool.Add(dccKey, encodedDcc))
+
+	actualVc, has := pool.GetValsetConfirm(vcKey)
+	assert.True(t, has)
+	assert.Equal(t, vc, actualVc)
+	actualDcc, has := pool.GetDataCommitmentConfirm(dccKey)
+	assert.True(t, has)
+	assert.Equal(t, dcc, actualDcc)
+	_, has = pool.GetDataCommitmentConfirm(p2p.GetDataCommitmentConfirmKey(3, evmAddress, "0x1234"))
+	assert.False(t, has)
+
+	// invalid keys are rejected
+	assert.Error(t, pool.Add("invalid", encodedDcc))
+
+	// confirms for nonces past the latest attestation nonce are rejected
+	newKey := p2p.GetDataCommitmentConfirmKey(2+p2p.ConfirmPoolRetention, evmAddress, "0x1234")
+	assert.ErrorIs(t, pool.Add(newKey, encodedDcc), p2p.ErrConfirmNonceOutOfRange)
+
+	// advancing the latest nonce past the retention prunes the old confirms
+	pool.SetLatestNonce(2 + p2p.ConfirmPoolRetention)
+	require.NoError(t, pool.Add(newKey, encodedDcc))
+	assert.Equal(t, 2, pool.Len())
+	_, has = pool.GetValsetConfirm(vcKey)
+	assert.False(t, has)
+
+	// confirms older than the retention are ignored
+	assert.ErrorIs(t, pool.Add(vcKey, encodedVc), p2p.ErrConfirmNonceOutOfRange)
+	assert.Equal(t, 2, pool.Len())
+
+	// the latest nonce never decreases
+	pool.SetLatestNonce(1)
+	assert.ErrorIs(t, pool.Add(vcKey, encodedVc), p2p.ErrConfirmNonceOutOfRange)
+}
+
+func TestConfirmPoolOneConfirmPerSigner(t *testing.T) {
+	otherAddress := "0x91DEd26b5f38B065FC0204c7929Da1b2A21877Ad"
+	pool := p2p.NewConfirmPool(tmlog.NewNopLogger())
+	pool.SetLatestNonce(10)
+	pool.SetValsetMembers([]common.Address{common.HexToAddress(evmAddress), common.HexToAddress(otherAddress)})
+
+	dcc := types.DataCommitmentConfirm{EthAddress: evmAddress, Signature: "signature"}
+	encodedDcc, err := types.MarshalDataCommitmentConfirm(dcc)
+	require.NoError(t, err)
+	require.NoError(t, pool.Add(p2p.GetDataCommitmentConfirmKey(10, evmAddress, common.BigToHash(big.NewInt(0)).Hex()), encodedDcc))
+	// the signer can't add confirms over other digests for the same nonce
+	for i := 1; i < 10; i++ {
+		assert.ErrorIs(t, pool.Add(p2p.GetDataCommitmentConfirmKey(10, evmAddress, common.BigToHash(big.NewInt(int64(i))).Hex()), encodedDcc), p2p.ErrConfirmSignerDuplicate)
+	}
+	// the existing confirm can still be replaced, and the other signers and nonces are not affected
+	require.NoError(t, pool.Add(p2p.GetDataCommitmentConfirmKey(10, evmAddress, common.BigToHash(big.NewInt(0)).Hex()), encodedDcc))
+	require.NoError(t, pool.Add(p2p.GetDataCommitmentConfirmKey(10, otherAddress, "0x1234"), encodedDcc))
+	require.NoError(t, pool.Add(p2p.GetDataCommitmentConfirmKey(9, evmAddress, "0x1234"), encodedDcc))
+	assert.Equal(t, 3, pool.Len())
+}
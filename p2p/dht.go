@@ -2,23 +2,62 @@ package p2p
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/libp2p/go-libp2p-kad-dht/providers"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-msgio"
 
+	dhtpb "github.com/libp2p/go-libp2p-kad-dht/pb"
+
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
+	"github.com/celestiaorg/orchestrator-relayer/rpc"
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
 	"github.com/celestiaorg/orchestrator-relayer/types"
+	"github.com/gogo/protobuf/proto"
 	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
+	record "github.com/libp2p/go-libp2p-record"
+	recpb "github.com/libp2p/go-libp2p-record/pb"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/routing"
+	"github.com/multiformats/go-base32"
 	tmlog "github.com/tendermint/tendermint/libs/log"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
-	ProtocolPrefix                 = "/blobstream/0.1.0" // TODO "/blobstream/<version>" ?
+	// ProtocolPrefix the prefix of the Blobstream protocols. The DHT protocol keeps using it regardless
+	// of the records format, so that the nodes supporting different formats still form a single network.
+	ProtocolPrefix = "/blobstream/0.1.0"
+	// BinaryRecordsProtocolPrefix the prefix of the protocol version supporting the binary records format.
+	BinaryRecordsProtocolPrefix = "/blobstream/0.2.0"
+	// BinaryRecordsProtocolID the protocol advertised by the nodes able to decode the binary encoded records.
+	// The records are only binary encoded once all the peers they're put to advertise it, so that the network
+	// can switch formats without having all the nodes upgrade at the same time.
+	BinaryRecordsProtocolID = BinaryRecordsProtocolPrefix + "/records"
+
 	DataCommitmentConfirmNamespace = "dcc"
 	ValsetConfirmNamespace         = "vc"
 	LatestValsetNamespace          = "lv"
+	RelayAnnouncementNamespace     = "ra"
+	AggregatedConfirmsNamespace    = "ac"
+
+	// DefaultValsetMembersUpdateInterval the default time between two updates of the valset members
+	// whose signatures are kept in the aggregated confirms records.
+	DefaultValsetMembersUpdateInterval = 5 * time.Minute
+
+	// dhtProtocolID the protocol used by the Blobstream DHT nodes to exchange records.
+	dhtProtocolID = ProtocolPrefix + "/kad/1.0.0"
+	// remoteValueTimeout the maximum time to wait for a peer to return a value.
+	remoteValueTimeout = 10 * time.Second
+	// confirmsQuorum the number of valid values, including the local one, after which the scored
+	// lookups of the confirms stop waiting for the remaining peers. The confirms are immutable, so
+	// any valid value is as good as the others.
+	confirmsQuorum = 3
 )
 
 // BlobstreamDHT wrapper around the `IpfsDHT` implementation.
@@ -26,34 +65,73 @@ const (
 type BlobstreamDHT struct {
 	*dht.IpfsDHT
 	logger tmlog.Logger
+	// store the datastore where the DHT saves its records.
+	store ds.Batching
+	// Scorer if not nil, the peers are scored depending on the validity of the records they return,
+	// and the ones returning too many invalid records are disconnected and banned.
+	Scorer *PeerScorer
+	// JSONRecords if true, the records are always put to the DHT using the legacy JSON format, instead
+	// of the format negotiated with the peers they're put to.
+	JSONRecords bool
+	// aggregatedConfirmsValidator the validator of the aggregated confirms records, kept to update the
+	// voting powers it selects the records with.
+	aggregatedConfirmsValidator *AggregatedConfirmsValidator
 }
 
 // NewBlobstreamDHT create a new IPFS DHT using a suitable configuration for the Blobstream.
 // If nil is passed for bootstrappers, the DHT will not try to connect to any existing peer.
-func NewBlobstreamDHT(ctx context.Context, h host.Host, store ds.Batching, bootstrappers []peer.AddrInfo, logger tmlog.Logger) (*BlobstreamDHT, error) {
+// If the gater is not nil, only the peers it allows are added to the routing table.
+func NewBlobstreamDHT(
+	ctx context.Context,
+	h host.Host,
+	store ds.Batching,
+	bootstrappers []peer.AddrInfo,
+	gater *Gater,
+	logger tmlog.Logger,
+) (*BlobstreamDHT, error) {
 	// this values is set to a year, so that even in super-stable networks, we have at least
 	// one valset in store for a year.
 	providers.ProvideValidity = time.Hour * 24 * 365
 
-	router, err := dht.New(
-		ctx,
-		h,
+	aggregatedConfirmsValidator := NewAggregatedConfirmsValidator()
+	options := []dht.Option{
 		dht.Datastore(store),
 		dht.Mode(dht.ModeServer),
 		dht.ProtocolPrefix(ProtocolPrefix),
 		dht.NamespacedValidator(DataCommitmentConfirmNamespace, DataCommitmentConfirmValidator{}),
 		dht.NamespacedValidator(ValsetConfirmNamespace, ValsetConfirmValidator{}),
 		dht.NamespacedValidator(LatestValsetNamespace, LatestValsetValidator{}),
+		dht.NamespacedValidator(RelayAnnouncementNamespace, RelayAnnouncementValidator{}),
+		dht.NamespacedValidator(AggregatedConfirmsNamespace, aggregatedConfirmsValidator),
 		dht.BootstrapPeers(bootstrappers...),
 		dht.DisableProviders(),
-	)
+	}
+	if gater != nil {
+		options = append(options, dht.RoutingTableFilter(gater.RoutingTableFilter))
+	}
+	router, err := dht.New(ctx, h, options...)
 	if err != nil {
 		return nil, err
 	}
+	if gater != nil {
+		// the peers are filtered out of the routing table when they connect, as they're not bound yet.
+		// So, they're added once bound. The ones not answering are evicted by the routing table refresh.
+		gater.setOnBind(func(id peer.ID) {
+			_, _ = router.RoutingTable().TryAddPeer(id, true, true)
+		})
+	}
+
+	// advertising the support of the binary records format to the peers. The protocol doesn't exchange
+	// any data, it's only listed in the host protocols.
+	h.SetStreamHandler(BinaryRecordsProtocolID, func(stream network.Stream) {
+		_ = stream.Close()
+	})
 
 	return &BlobstreamDHT{
-		IpfsDHT: router,
-		logger:  logger,
+		IpfsDHT:                     router,
+		logger:                      logger,
+		store:                       store,
+		aggregatedConfirmsValidator: aggregatedConfirmsValidator,
 	}, nil
 }
 
@@ -97,15 +175,362 @@ func (q BlobstreamDHT) WaitForPeers(ctx context.Context, timeout time.Duration,
 	}
 }
 
+// RecordFormat negotiates the format to encode the record referenced by the key with. The binary format
+// is used if all the peers closest to the key, i.e. the ones the record is put to, advertise the
+// BinaryRecordsProtocolID, so that the record can be decoded by all of them. Otherwise, or if the JSON
+// format is forced, see `JSONRecords`, the legacy JSON format is used.
+func (q BlobstreamDHT) RecordFormat(ctx context.Context, key string) types.RecordFormat {
+	if q.JSONRecords {
+		return types.RecordFormatJSON
+	}
+	peers, err := q.GetClosestPeers(ctx, key)
+	if err != nil {
+		q.logger.Debug("failed to get the closest peers to negotiate the record format", "key", key, "err", err.Error())
+		return types.RecordFormatJSON
+	}
+	if len(peers) == 0 {
+		return types.RecordFormatJSON
+	}
+	for _, p := range peers {
+		if p == q.PeerID() {
+			continue
+		}
+		supported, err := q.Host().Peerstore().SupportsProtocols(p, BinaryRecordsProtocolID)
+		if err != nil || len(supported) == 0 {
+			return types.RecordFormatJSON
+		}
+	}
+	return types.RecordFormatBinaryV1
+}
+
+// CountPeersWithValue asks the peers closest to the key, excluding the local node, for the value
+// referenced by the key. Returns the number of peers that hold a valid value for it.
+// This allows verifying that a value is retrievable from the network even if the local node
+// holds it, as the `GetValue` method also looks up the local data store.
+func (q BlobstreamDHT) CountPeersWithValue(ctx context.Context, key string) (int, error) {
+	peers, err := q.GetClosestPeers(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, p := range peers {
+		if p == q.PeerID() {
+			continue
+		}
+		value, err := q.getRemoteValue(ctx, p, key)
+		if err != nil {
+			q.logger.Debug("failed to get value from peer", "peer", p.String(), "err", err.Error())
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if err := q.Validator.Validate(key, value); err != nil {
+			q.logger.Debug("peer returned an invalid value", "peer", p.String(), "err", err.Error())
+			q.penalizePeer(p, err)
+			continue
+		}
+		q.rewardPeer(p)
+		count++
+	}
+	return count, nil
+}
+
+// GetValue looks for the value referenced by the key in the local datastore and in the peers closest to it.
+// If the DHT has a scorer, the value is requested from every peer separately, instead of relying on the
+// `IpfsDHT.GetValue` method, so that the peers returning invalid values can be penalized. Similarly to
+// the `IpfsDHT.GetValue` method, the lookup collects the values of all the peers, unless a quorum is set
+// using the `dht.Quorum` option. The confirms lookups default to a quorum, as any valid confirm can be
+// selected, while the mutable records, e.g. the latest valset, need all the values to select the freshest.
+// Returns the value selected by the validator, or `routing.ErrNotFound` if no valid value was found.
+func (q BlobstreamDHT) GetValue(ctx context.Context, key string, opts ...routing.Option) ([]byte, error) {
+	if q.Scorer == nil {
+		return q.IpfsDHT.GetValue(ctx, key, opts...)
+	}
+
+	quorum, err := getValueQuorum(key, opts...)
+	if err != nil {
+		return nil, err
+	}
+	values, err := q.getValues(ctx, key, quorum)
+	if err != nil {
+		return nil, err
+	}
+	index, err := q.Validator.Select(key, values)
+	if err != nil {
+		return nil, err
+	}
+	return values[index], nil
+}
+
+// getValueQuorum returns the number of valid values after which the lookup of the provided key stops.
+// The quorum set using the `dht.Quorum` option takes precedence over the namespace default.
+// Returns 0 if all the values should be collected.
+func getValueQuorum(key string, opts ...routing.Option) (int, error) {
+	var options routing.Options
+	if err := options.Apply(opts...); err != nil {
+		return 0, err
+	}
+	// the key of the quorum option is internal to the kad-dht package, so it's taken from a locally
+	// applied option.
+	var quorumOptions routing.Options
+	if err := quorumOptions.Apply(dht.Quorum(0)); err != nil {
+		return 0, err
+	}
+	for optionKey := range quorumOptions.Other {
+		if quorum, ok := options.Other[optionKey].(int); ok {
+			return quorum, nil
+		}
+	}
+
+	namespace, _, err := record.SplitKey(key)
+	if err != nil {
+		return 0, err
+	}
+	switch namespace {
+	case DataCommitmentConfirmNamespace, ValsetConfirmNamespace:
+		return confirmsQuorum, nil
+	default:
+		return 0, nil
+	}
+}
+
+// GetValues returns all the valid values referenced by the key in the local datastore and in the peers
+// closest to it, so that the caller can select between them. If the DHT has a scorer, the peers returning
+// invalid values are penalized.
+// Returns `routing.ErrNotFound` if no valid value was found.
+func (q BlobstreamDHT) GetValues(ctx context.Context, key string) ([][]byte, error) {
+	return q.getValues(ctx, key, 0)
+}
+
+// getValues requests the value referenced by the key from the local datastore and from every peer
+// closest to it, and returns the valid ones. If the quorum is not 0, the lookup returns once the quorum
+// of valid values is collected.
+// Returns `routing.ErrNotFound` if no valid value was found.
+func (q BlobstreamDHT) getValues(ctx context.Context, key string, quorum int) ([][]byte, error) {
+	values := make([][]byte, 0)
+	localValue, err := q.getLocalValue(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if localValue != nil {
+		values = append(values, localValue)
+	}
+
+	peers, err := q.GetClosestPeers(ctx, key)
+	if err != nil {
+		// the local value is still returned if the DHT has no peers, similarly to the `IpfsDHT.GetValue` method.
+		if len(values) != 0 && ctx.Err() == nil {
+			return values, nil
+		}
+		return nil, err
+	}
+
+	// the remaining requests are canceled once the quorum is reached.
+	lookupCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// the channel is buffered so that the requests still running after the quorum don't block.
+	results := make(chan []byte, len(peers))
+	requests := 0
+	for _, p := range peers {
+		if p == q.PeerID() {
+			continue
+		}
+		requests++
+		go func(p peer.ID) {
+			value, err := q.getRemoteValue(lookupCtx, p, key)
+			if err != nil {
+				q.logger.Debug("failed to get value from peer", "peer", p.String(), "err", err.Error())
+				results <- nil
+				return
+			}
+			if value == nil {
+				results <- nil
+				return
+			}
+			if err := q.Validator.Validate(key, value); err != nil {
+				q.logger.Debug("peer returned an invalid value", "peer", p.String(), "err", err.Error())
+				q.penalizePeer(p, err)
+				results <- nil
+				return
+			}
+			q.rewardPeer(p)
+			results <- value
+		}(p)
+	}
+	for ; requests > 0 && (quorum == 0 || len(values) < quorum); requests-- {
+		if value := <-results; value != nil {
+			values = append(values, value)
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if len(values) == 0 {
+		return nil, routing.ErrNotFound
+	}
+	return values, nil
+}
+
+// getLocalValue returns the valid value referenced by the key in the DHT datastore.
+// Returns nil if the datastore doesn't hold a valid value for it.
+func (q BlobstreamDHT) getLocalValue(ctx context.Context, key string) ([]byte, error) {
+	encodedRecord, err := q.store.Get(ctx, ds.NewKey(base32.RawStdEncoding.EncodeToString([]byte(key))))
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	record := new(recpb.Record)
+	if err := proto.Unmarshal(encodedRecord, record); err != nil {
+		return nil, err
+	}
+	if string(record.GetKey()) != key {
+		return nil, nil
+	}
+	if err := q.Validator.Validate(key, record.GetValue()); err != nil {
+		q.logger.Debug("skipping invalid local record", "key", key, "err", err.Error())
+		return nil, nil
+	}
+	return record.GetValue(), nil
+}
+
+// penalizePeer records that the peer returned a record failing the validation with the provided error,
+// and disconnects it if it got banned. The records rejected because of the local state are not penalized,
+// see `isStatelessValidationError`.
+func (q BlobstreamDHT) penalizePeer(p peer.ID, validationErr error) {
+	if q.Scorer == nil || !isStatelessValidationError(validationErr) {
+		return
+	}
+	score, banned := q.Scorer.RecordInvalidRecord(p)
+	q.Host().ConnManager().TagPeer(p, peerScoreTag, score)
+	if banned {
+		q.RoutingTable().RemovePeer(p)
+		if err := q.Host().Network().ClosePeer(p); err != nil {
+			q.logger.Debug("failed to disconnect banned peer", "peer", p.String(), "err", err.Error())
+		}
+	}
+}
+
+// isStatelessValidationError returns true if the validation error only depends on the record, e.g. a decoding
+// error or an invalid signature, so that the peer returning it can be held responsible. The rejections depending
+// on the local view of the valset members, which can be outdated, are not stateless: the record might be valid
+// for the peer returning it.
+func isStatelessValidationError(err error) bool {
+	return !errors.Is(err, ErrTooManyAggregatedSignatures)
+}
+
+// rewardPeer records that the peer returned a valid record.
+func (q BlobstreamDHT) rewardPeer(p peer.ID) {
+	if q.Scorer == nil {
+		return
+	}
+	score := q.Scorer.RecordValidRecord(p)
+	q.Host().ConnManager().TagPeer(p, peerScoreTag, score)
+}
+
+// RepublishRecords puts the valid records held in the DHT datastore back to the DHT, so that
+// they are replicated to the peers closest to their keys.
+// This allows a node using a persistent datastore to serve the records it was holding before
+// a restart to the new peers of the network.
+// Returns the number of republished records.
+func (q BlobstreamDHT) RepublishRecords(ctx context.Context) (int, error) {
+	results, err := q.store.Query(ctx, query.Query{})
+	if err != nil {
+		return 0, err
+	}
+	// the records are loaded first so that the datastore is not written to while iterating it.
+	records := make([]*recpb.Record, 0)
+	for result := range results.Next() {
+		if result.Error != nil {
+			_ = results.Close()
+			return 0, result.Error
+		}
+		record := new(recpb.Record)
+		if err := proto.Unmarshal(result.Value, record); err != nil {
+			q.logger.Debug("skipping non record entry", "key", result.Key)
+			continue
+		}
+		if err := q.Validator.Validate(string(record.GetKey()), record.GetValue()); err != nil {
+			q.logger.Debug("skipping invalid record", "key", result.Key, "err", err.Error())
+			continue
+		}
+		records = append(records, record)
+	}
+	err = results.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, record := range records {
+		err := q.PutValue(ctx, string(record.GetKey()), record.GetValue())
+		if err != nil {
+			if ctx.Err() != nil {
+				return count, ctx.Err()
+			}
+			q.logger.Debug("failed to republish record", "key", string(record.GetKey()), "err", err.Error())
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// getRemoteValue requests the value referenced by the key from the provided peer.
+// Returns nil if the peer doesn't hold it.
+func (q BlobstreamDHT) getRemoteValue(ctx context.Context, p peer.ID, key string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteValueTimeout)
+	defer cancel()
+	stream, err := q.Host().NewStream(ctx, p, dhtProtocolID)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = stream.SetDeadline(deadline)
+	}
+
+	request, err := dhtpb.NewMessage(dhtpb.Message_GET_VALUE, []byte(key), 0).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	if err := msgio.NewVarintWriter(stream).WriteMsg(request); err != nil {
+		_ = stream.Reset()
+		return nil, err
+	}
+	encodedResponse, err := msgio.NewVarintReaderSize(stream, network.MessageSizeMax).ReadMsg()
+	if err != nil {
+		_ = stream.Reset()
+		return nil, err
+	}
+	response := new(dhtpb.Message)
+	if err := response.Unmarshal(encodedResponse); err != nil {
+		return nil, err
+	}
+	record := response.GetRecord()
+	if record == nil || string(record.GetKey()) != key {
+		return nil, nil
+	}
+	return record.GetValue(), nil
+}
+
 // Note: The Get and Put methods do not run any validations on the data commitment confirms
 // and valset confirms. The checks are supposed to be handled by the validators under `p2p/validators.go`.
-// Same goes for the Marshal and Unmarshal methods (as long as they're using simple Json encoding).
+// Same goes for the Encode and Unmarshal methods, except that the binary format requires the signatures
+// to be hex encoded.
+// The records are encoded using the format negotiated with the peers they're put to, see `RecordFormat`.
 
 // PutDataCommitmentConfirm encodes a data commitment confirm then puts its value to the DHT.
 // The key can be generated using the `GetDataCommitmentConfirmKey` method.
 // Returns an error if it fails to do so.
-func (q BlobstreamDHT) PutDataCommitmentConfirm(ctx context.Context, key string, dcc types.DataCommitmentConfirm) error {
-	encodedData, err := types.MarshalDataCommitmentConfirm(dcc)
+func (q BlobstreamDHT) PutDataCommitmentConfirm(ctx context.Context, key string, dcc types.DataCommitmentConfirm) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "BlobstreamDHT.PutDataCommitmentConfirm", attribute.String("key", key))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	encodedData, err := types.EncodeDataCommitmentConfirm(dcc, q.RecordFormat(ctx, key))
 	if err != nil {
 		return err
 	}
@@ -119,7 +544,10 @@ func (q BlobstreamDHT) PutDataCommitmentConfirm(ctx context.Context, key string,
 // GetDataCommitmentConfirm looks for a data commitment confirm referenced by its key in the DHT.
 // The key can be generated using the `GetDataCommitmentConfirmKey` method.
 // Returns an error if it fails to get the confirm.
-func (q BlobstreamDHT) GetDataCommitmentConfirm(ctx context.Context, key string) (types.DataCommitmentConfirm, error) {
+func (q BlobstreamDHT) GetDataCommitmentConfirm(ctx context.Context, key string) (_ types.DataCommitmentConfirm, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "BlobstreamDHT.GetDataCommitmentConfirm", attribute.String("key", key))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	encodedConfirm, err := q.GetValue(ctx, key) // this is a blocking call, we should probably use timeout and channel
 	if err != nil {
 		return types.DataCommitmentConfirm{}, err
@@ -134,8 +562,11 @@ func (q BlobstreamDHT) GetDataCommitmentConfirm(ctx context.Context, key string)
 // PutValsetConfirm encodes a valset confirm then puts its value to the DHT.
 // The key can be generated using the `GetValsetConfirmKey` method.
 // Returns an error if it fails to do so.
-func (q BlobstreamDHT) PutValsetConfirm(ctx context.Context, key string, vc types.ValsetConfirm) error {
-	encodedData, err := types.MarshalValsetConfirm(vc)
+func (q BlobstreamDHT) PutValsetConfirm(ctx context.Context, key string, vc types.ValsetConfirm) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "BlobstreamDHT.PutValsetConfirm", attribute.String("key", key))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	encodedData, err := types.EncodeValsetConfirm(vc, q.RecordFormat(ctx, key))
 	if err != nil {
 		return err
 	}
@@ -149,7 +580,10 @@ func (q BlobstreamDHT) PutValsetConfirm(ctx context.Context, key string, vc type
 // GetValsetConfirm looks for a valset confirm referenced by its key in the DHT.
 // The key can be generated using the `GetValsetConfirmKey` method.
 // Returns an error if it fails to get the confirm.
-func (q BlobstreamDHT) GetValsetConfirm(ctx context.Context, key string) (types.ValsetConfirm, error) {
+func (q BlobstreamDHT) GetValsetConfirm(ctx context.Context, key string) (_ types.ValsetConfirm, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "BlobstreamDHT.GetValsetConfirm", attribute.String("key", key))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	encodedConfirm, err := q.GetValue(ctx, key) // this is a blocking call, we should probably use timeout and channel
 	if err != nil {
 		return types.ValsetConfirm{}, err
@@ -166,7 +600,7 @@ func (q BlobstreamDHT) GetValsetConfirm(ctx context.Context, key string) (types.
 // If the valset is not the latest, it will fail.
 // Returns an error if it fails.
 func (q BlobstreamDHT) PutLatestValset(ctx context.Context, v types.LatestValset) error {
-	encodedData, err := types.MarshalLatestValset(v)
+	encodedData, err := types.EncodeLatestValset(v, q.RecordFormat(ctx, GetLatestValsetKey()))
 	if err != nil {
 		return err
 	}
@@ -191,3 +625,136 @@ func (q BlobstreamDHT) GetLatestValset(ctx context.Context) (types.LatestValset,
 	}
 	return valset, nil
 }
+
+// PutRelayAnnouncement encodes a relay announcement then puts its value to the DHT.
+// The key can be generated using the `GetRelayAnnouncementKey` method.
+// Returns an error if it fails to do so.
+func (q BlobstreamDHT) PutRelayAnnouncement(ctx context.Context, key string, ra types.RelayAnnouncement) error {
+	encodedData, err := types.MarshalRelayAnnouncement(ra)
+	if err != nil {
+		return err
+	}
+	err = q.PutValue(ctx, key, encodedData)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetRelayAnnouncement looks for a relay announcement referenced by its key in the DHT.
+// The key can be generated using the `GetRelayAnnouncementKey` method.
+// Returns an error if it fails to get the announcement.
+func (q BlobstreamDHT) GetRelayAnnouncement(ctx context.Context, key string) (types.RelayAnnouncement, error) {
+	encoded, err := q.GetValue(ctx, key)
+	if err != nil {
+		return types.RelayAnnouncement{}, err
+	}
+	announcement, err := types.UnmarshalRelayAnnouncement(encoded)
+	if err != nil {
+		return types.RelayAnnouncement{}, err
+	}
+	return announcement, nil
+}
+
+// SetValsetMembers sets the valset members whose signatures are kept in the aggregated confirms records,
+// and whose voting power is used to select the best record, when multiple valid ones are found.
+func (q BlobstreamDHT) SetValsetMembers(members []celestiatypes.BridgeValidator) {
+	q.aggregatedConfirmsValidator.SetValsetMembers(members)
+}
+
+// UpdateValsetMembers queries the latest valset and the one before it, and sets their members as the
+// valset members of the aggregated confirms records. Keeping the previous valset members allows the
+// records of the latest valset to be collected while it's being relayed.
+func (q BlobstreamDHT) UpdateValsetMembers(ctx context.Context, appQuerier *rpc.AppQuerier) error {
+	members, err := queryValsetBridgeValidators(ctx, appQuerier)
+	if err != nil {
+		return err
+	}
+	q.SetValsetMembers(members)
+	return nil
+}
+
+// StartValsetUpdates updates the valset members right away, then every interval until the context is canceled.
+func (q BlobstreamDHT) StartValsetUpdates(ctx context.Context, appQuerier *rpc.AppQuerier, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := q.UpdateValsetMembers(ctx, appQuerier)
+		if err != nil && ctx.Err() == nil {
+			q.logger.Error("couldn't update the aggregated confirms valset members", "err", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PutAggregatedConfirms merges the aggregated confirms with the record held locally and the one found
+// in the network for the same key, then puts the merged record to the DHT.
+// The signatures of the addresses that are not valset members are dropped before merging, if the
+// valset members are set.
+// The key can be generated using the `GetAggregatedConfirmsKey` method.
+// Returns an error if it fails to do so.
+func (q BlobstreamDHT) PutAggregatedConfirms(ctx context.Context, key string, ac types.AggregatedConfirms) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "BlobstreamDHT.PutAggregatedConfirms", attribute.String("key", key))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	merged := types.NewAggregatedConfirms(ac.Nonce, ac.Digest)
+	merged.Merge(q.aggregatedConfirmsValidator.FilterMembers(ac))
+	// the local record is merged separately as it might not be the one selected from the network,
+	// in which case the merged record could be rejected for being worse than it.
+	localValue, err := q.getLocalValue(ctx, key)
+	if err != nil {
+		return err
+	}
+	if localValue != nil {
+		// the local value is validated when read, so it can be unmarshalled
+		local, _ := types.UnmarshalAggregatedConfirms(localValue)
+		merged.Merge(q.aggregatedConfirmsValidator.FilterMembers(local))
+	}
+	existing, err := q.GetAggregatedConfirms(ctx, key)
+	if err == nil {
+		merged.Merge(q.aggregatedConfirmsValidator.FilterMembers(existing))
+	} else if !errors.Is(err, routing.ErrNotFound) {
+		return err
+	}
+
+	encodedData, err := types.EncodeAggregatedConfirms(*merged, q.RecordFormat(ctx, key))
+	if err != nil {
+		return err
+	}
+	err = q.PutValue(ctx, key, encodedData)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// AggregateConfirm adds the signature to the aggregated confirms record of the nonce and digest,
+// then re-publishes it to the DHT.
+// Returns an error if it fails to do so.
+func (q BlobstreamDHT) AggregateConfirm(ctx context.Context, nonce uint64, digest string, ethAddress string, signature string) error {
+	ac := types.NewAggregatedConfirms(nonce, digest)
+	ac.Add(ethAddress, signature)
+	return q.PutAggregatedConfirms(ctx, GetAggregatedConfirmsKey(nonce, digest), *ac)
+}
+
+// GetAggregatedConfirms looks for the aggregated confirms record referenced by its key in the DHT.
+// The key can be generated using the `GetAggregatedConfirmsKey` method.
+// Returns an error if it fails to get the record.
+func (q BlobstreamDHT) GetAggregatedConfirms(ctx context.Context, key string) (_ types.AggregatedConfirms, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "BlobstreamDHT.GetAggregatedConfirms", attribute.String("key", key))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	encoded, err := q.GetValue(ctx, key)
+	if err != nil {
+		return types.AggregatedConfirms{}, err
+	}
+	ac, err := types.UnmarshalAggregatedConfirms(encoded)
+	if err != nil {
+		return types.AggregatedConfirms{}, err
+	}
+	return ac, nil
+}
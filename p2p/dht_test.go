@@ -97,6 +97,131 @@ func TestPutDataCommitmentConfirm(t *testing.T) {
 	assert.Equal(t, expectedConfirm, actualConfirm)
 }
 
+func TestCountPeersWithValue(t *testing.T) {
+	network := blobstreamtesting.NewDHTNetwork(context.Background(), 2)
+	defer network.Stop()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.ImportECDSA(privateKey, "123")
+	require.NoError(t, err)
+	err = ks.Unlock(acc, "123")
+	require.NoError(t, err)
+
+	nonce := uint64(10)
+	bCommitment, _ := hex.DecodeString("1234")
+	dataRootHash := types.DataCommitmentTupleRootSignBytes(big.NewInt(int64(nonce)), bCommitment)
+	signature, err := evm.NewEthereumSignature(dataRootHash.Bytes(), ks, acc)
+	require.NoError(t, err)
+	confirm := types.DataCommitmentConfirm{
+		EthAddress: evmAddress,
+		Signature:  hex.EncodeToString(signature),
+	}
+	testKey := p2p.GetDataCommitmentConfirmKey(nonce, evmAddress, dataRootHash.Hex())
+
+	// the confirm isn't held by any peer
+	count, err := network.DHTs[1].CountPeersWithValue(context.Background(), testKey)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	err = network.DHTs[1].PutDataCommitmentConfirm(context.Background(), testKey, confirm)
+	require.NoError(t, err)
+
+	// the local node isn't counted
+	count, err = network.DHTs[1].CountPeersWithValue(context.Background(), testKey)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestRepublishRecords(t *testing.T) {
+	ctx := context.Background()
+	h1, _, dht1 := blobstreamtesting.NewTestDHT(ctx, nil)
+	defer dht1.Close()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.ImportECDSA(privateKey, "123")
+	require.NoError(t, err)
+	err = ks.Unlock(acc, "123")
+	require.NoError(t, err)
+
+	nonce := uint64(10)
+	bCommitment, _ := hex.DecodeString("1234")
+	dataRootHash := types.DataCommitmentTupleRootSignBytes(big.NewInt(int64(nonce)), bCommitment)
+	signature, err := evm.NewEthereumSignature(dataRootHash.Bytes(), ks, acc)
+	require.NoError(t, err)
+	confirm := types.DataCommitmentConfirm{
+		EthAddress: evmAddress,
+		Signature:  hex.EncodeToString(signature),
+	}
+	testKey := p2p.GetDataCommitmentConfirmKey(nonce, evmAddress, dataRootHash.Hex())
+
+	// the first node is not connected to any peer, so the confirm is only saved locally
+	// and the put fails to find the peers to send it to.
+	err = dht1.PutDataCommitmentConfirm(ctx, testKey, confirm)
+	require.Error(t, err)
+	_, err = dht1.GetDataCommitmentConfirm(ctx, testKey)
+	require.NoError(t, err)
+
+	_, _, dht2 := blobstreamtesting.NewTestDHT(ctx, []peer.AddrInfo{{ID: h1.ID(), Addrs: h1.Addrs()}})
+	defer dht2.Close()
+	err = dht1.WaitForPeers(ctx, 5*time.Second, time.Millisecond, 1)
+	require.NoError(t, err)
+
+	count, err := dht1.CountPeersWithValue(ctx, testKey)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	republished, err := dht1.RepublishRecords(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, republished)
+
+	// the confirm is now held by the new peer
+	count, err = dht1.CountPeersWithValue(ctx, testKey)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestPutRelayAnnouncement(t *testing.T) {
+	network := blobstreamtesting.NewDHTNetwork(context.Background(), 2)
+	defer network.Stop()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.ImportECDSA(privateKey, "123")
+	require.NoError(t, err)
+	err = ks.Unlock(acc, "123")
+	require.NoError(t, err)
+
+	nonce := uint64(10)
+	evmChainID := uint64(5)
+	txHash := common.HexToHash("0x1234")
+	timestamp := time.Now().Unix()
+	signBytes := types.RelayAnnouncementSignBytes(nonce, evmChainID, txHash, timestamp)
+	signature, err := evm.NewEthereumSignature(signBytes.Bytes(), ks, acc)
+	require.NoError(t, err)
+
+	expectedAnnouncement := *types.NewRelayAnnouncement(
+		nonce,
+		evmChainID,
+		common.HexToAddress(evmAddress),
+		txHash,
+		timestamp,
+		hex.EncodeToString(signature),
+	)
+	testKey := p2p.GetRelayAnnouncementKey(nonce, evmAddress, evmChainID)
+
+	// put the test announcement in the DHT
+	err = network.DHTs[0].PutRelayAnnouncement(context.Background(), testKey, expectedAnnouncement)
+	assert.NoError(t, err)
+
+	// try to get the announcement from the other peer
+	actualAnnouncement, err := network.DHTs[1].GetRelayAnnouncement(context.Background(), testKey)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAnnouncement, actualAnnouncement)
+
+	// announcements for other chains are not found
+	_, err = network.DHTs[1].GetRelayAnnouncement(context.Background(), p2p.GetRelayAnnouncementKey(nonce, evmAddress, evmChainID+1))
+	assert.Error(t, err)
+}
+
 func TestPutLatestValset(t *testing.T) {
 	network := blobstreamtesting.NewDHTNetwork(context.Background(), 2)
 	defer network.Stop()
@@ -359,3 +484,30 @@ func TestWaitForPeers(t *testing.T) {
 	err = dht1.WaitForPeers(ctx, 100*time.Millisecond, time.Millisecond, 1)
 	assert.NoError(t, err)
 }
+
+func TestRecordFormat(t *testing.T) {
+	ctx := context.Background()
+	network := blobstreamtesting.NewDHTNetwork(ctx, 2)
+	defer network.Stop()
+	key := p2p.GetLatestValsetKey()
+
+	// the peer the record is put to supports the binary records
+	assert.Eventually(t, func() bool {
+		return network.DHTs[0].RecordFormat(ctx, key) == types.RecordFormatBinaryV1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the JSON format can be forced
+	network.DHTs[0].JSONRecords = true
+	assert.Equal(t, types.RecordFormatJSON, network.DHTs[0].RecordFormat(ctx, key))
+	network.DHTs[0].JSONRecords = false
+
+	// a legacy peer, only supporting the JSON records, joins the peers the record is put to
+	h3, _, dht3 := blobstreamtesting.NewTestDHT(ctx, []peer.AddrInfo{{ID: network.Hosts[0].ID(), Addrs: network.Hosts[0].Addrs()}})
+	defer dht3.Close()
+	h3.RemoveStreamHandler(p2p.BinaryRecordsProtocolID)
+	err := blobstreamtesting.WaitForPeerTableToUpdate(ctx, []*p2p.BlobstreamDHT{network.DHTs[0], dht3}, time.Minute)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return network.DHTs[0].RecordFormat(ctx, key) == types.RecordFormatJSON
+	}, 5*time.Second, 10*time.Millisecond)
+}
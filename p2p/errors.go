@@ -21,4 +21,15 @@ var (
 	ErrEmptyDigest                     = errors.New("empty digest")
 	ErrEmptyValset                     = errors.New("empty valset")
 	ErrInvalidLatestValsetKey          = errors.New("invalid latest valset key")
+	ErrInvalidEVMChainID               = errors.New("invalid evm chain id")
+	ErrAnnouncementKeyMismatch         = errors.New("relay announcement doesn't match its key")
+	ErrNotEnoughPeers                  = errors.New("not enough peers in the DHT routing table")
+	ErrPeerBindingMismatch             = errors.New("peer binding doesn't reference the peer that sent it")
+	ErrConfirmNonceOutOfRange          = errors.New("confirm nonce unknown or out of the retention")
+	ErrConfirmSignerNotMember          = errors.New("confirm not signed by a valset member")
+	ErrConfirmSignerDuplicate          = errors.New("confirm pool already holding a confirm of the signer for the nonce")
+	ErrEmptyAggregatedConfirms         = errors.New("aggregated confirms record without signatures")
+	ErrTooManyAggregatedSignatures     = errors.New("aggregated confirms record holding more signatures than the valset members")
+	ErrDuplicateSignature              = errors.New("duplicate signature in aggregated confirms record")
+	ErrAggregatedConfirmsKeyMismatch   = errors.New("aggregated confirms record doesn't match its key")
 )
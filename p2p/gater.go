@@ -0,0 +1,313 @@
+package p2p
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
+	"github.com/celestiaorg/orchestrator-relayer/rpc"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	// DefaultHandshakeGracePeriod the default time a peer that is not in the allowlist has to prove
+	// that it's bound to a valset member before being disconnected.
+	DefaultHandshakeGracePeriod = 30 * time.Second
+	// DefaultRejectionPeriod the default time a peer whose grace period elapsed is rejected before
+	// being given a new grace period, e.g. in case it became a valset member in the meantime.
+	DefaultRejectionPeriod = 10 * time.Minute
+	// maxFirstSeenPeers the maximum number of peers, that are not allowed yet, tracked by the gater.
+	// The new peers are rejected once it's reached.
+	maxFirstSeenPeers = 10000
+)
+
+// GatingConfig the configuration of the P2P connection gating.
+type GatingConfig struct {
+	// Enable only accepts the connections of the allowlisted peers, and of the peers bound to
+	// an EVM address of the current or previous valset.
+	Enable bool `mapstructure:"enable" json:"enable"`
+	// Allowlist comma-separated IDs, or multiaddresses, of the peers that are always accepted.
+	// For example, the bootstrappers and the relayers.
+	Allowlist string `mapstructure:"allowlist" json:"allowlist"`
+}
+
+// ParseAllowlist parses the comma-separated IDs, or multiaddresses ending with the peer ID,
+// of the allowlisted peers.
+func ParseAllowlist(allowlist string) ([]peer.ID, error) {
+	ids := make([]peer.ID, 0)
+	for _, entry := range strings.Split(allowlist, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.HasPrefix(entry, "/") {
+			addrInfo, err := peer.AddrInfoFromString(entry)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, addrInfo.ID)
+			continue
+		}
+		id, err := peer.Decode(entry)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+var _ connmgr.ConnectionGater = &Gater{}
+
+// Gater a libp2p connection gater only accepting the allowlisted peers, and the peers that proved,
+// via the handshake, that their P2P key is bound to an EVM address of the current or previous valset.
+// The other peers are accepted for a grace period, so that they can complete the handshake. During
+// it, they're kept out of the DHT routing table and the GossipSub mesh using the RoutingTableFilter
+// and PubSubPeerFilter. Once it's elapsed, they're rejected for the DefaultRejectionPeriod.
+type Gater struct {
+	logger      tmlog.Logger
+	gracePeriod time.Duration
+	// onBind if set, called when a peer is bound to a valset member.
+	onBind func(id peer.ID)
+
+	mu sync.RWMutex
+	// allowlist the peers that are always accepted.
+	allowlist map[peer.ID]struct{}
+	// members the EVM addresses of the current and previous valsets members.
+	members map[ethcmn.Address]struct{}
+	// bindings the EVM addresses the peers proved to be bound to.
+	bindings map[peer.ID]ethcmn.Address
+	// firstSeen the time the peers that are not allowed yet were first seen.
+	firstSeen map[peer.ID]time.Time
+}
+
+// NewGater creates a new gater. If the grace period is not positive, the DefaultHandshakeGracePeriod is used.
+func NewGater(logger tmlog.Logger, allowlist []peer.ID, gracePeriod time.Duration) *Gater {
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultHandshakeGracePeriod
+	}
+	allowed := make(map[peer.ID]struct{}, len(allowlist))
+	for _, id := range allowlist {
+		allowed[id] = struct{}{}
+	}
+	return &Gater{
+		logger:      logger,
+		gracePeriod: gracePeriod,
+		allowlist:   allowed,
+		members:     make(map[ethcmn.Address]struct{}),
+		bindings:    make(map[peer.ID]ethcmn.Address),
+		firstSeen:   make(map[peer.ID]time.Time),
+	}
+}
+
+// SetValsetMembers sets the EVM addresses whose bound peers are accepted.
+// The rejected peers that became members are accepted again once their rejection period elapses.
+func (g *Gater) SetValsetMembers(addresses []ethcmn.Address) {
+	members := make(map[ethcmn.Address]struct{}, len(addresses))
+	for _, addr := range addresses {
+		members[addr] = struct{}{}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.members = members
+}
+
+// Bind records the EVM address the peer proved to be bound to.
+func (g *Gater) Bind(id peer.ID, addr ethcmn.Address) {
+	g.mu.Lock()
+	g.bindings[id] = addr
+	delete(g.firstSeen, id)
+	allowed := g.isAllowed(id)
+	onBind := g.onBind
+	g.mu.Unlock()
+	if allowed && onBind != nil {
+		onBind(id)
+	}
+}
+
+// setOnBind sets the function called when a peer is bound to a valset member.
+func (g *Gater) setOnBind(onBind func(id peer.ID)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.onBind = onBind
+}
+
+// Forget removes the binding of the disconnected peer, as it's sent again on reconnection,
+// and its first seen time if its grace period didn't elapse, so that the gater doesn't track
+// the peers that are gone.
+// The expired peers are kept until their rejection period elapses.
+func (g *Gater) Forget(id peer.ID) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.bindings, id)
+	if firstSeen, ok := g.firstSeen[id]; ok && time.Since(firstSeen) <= g.gracePeriod {
+		delete(g.firstSeen, id)
+	}
+}
+
+// Prune removes the peers whose rejection period elapsed.
+func (g *Gater) Prune() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.prune()
+}
+
+func (g *Gater) prune() {
+	for id, firstSeen := range g.firstSeen {
+		if time.Since(firstSeen) > g.gracePeriod+DefaultRejectionPeriod {
+			delete(g.firstSeen, id)
+		}
+	}
+}
+
+// IsAllowed returns true if the peer is allowlisted, or bound to a valset member.
+func (g *Gater) IsAllowed(id peer.ID) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.isAllowed(id)
+}
+
+func (g *Gater) isAllowed(id peer.ID) bool {
+	if _, ok := g.allowlist[id]; ok {
+		return true
+	}
+	addr, ok := g.bindings[id]
+	if !ok {
+		return false
+	}
+	_, ok = g.members[addr]
+	return ok
+}
+
+// IsExpired returns true if the peer is not allowed and its grace period has elapsed.
+// If the peer was never seen, or its rejection period elapsed, its grace period starts.
+// If the gater already tracks too many peers, the new ones are considered expired.
+func (g *Gater) IsExpired(id peer.ID) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.isAllowed(id) {
+		return false
+	}
+	firstSeen, ok := g.firstSeen[id]
+	if !ok || time.Since(firstSeen) > g.gracePeriod+DefaultRejectionPeriod {
+		if !ok && len(g.firstSeen) >= maxFirstSeenPeers {
+			g.prune()
+			if len(g.firstSeen) >= maxFirstSeenPeers {
+				return true
+			}
+		}
+		g.firstSeen[id] = time.Now()
+		return false
+	}
+	return time.Since(firstSeen) > g.gracePeriod
+}
+
+// RoutingTableFilter a DHT routing table filter only accepting the allowed peers, so that the peers
+// are not queried, or returned to the other peers, during their grace period.
+func (g *Gater) RoutingTableFilter(_ interface{}, id peer.ID) bool {
+	return g.IsAllowed(id)
+}
+
+// PubSubPeerFilter a GossipSub peer filter only accepting the allowed peers, so that the confirms
+// are not exchanged with the peers during their grace period.
+func (g *Gater) PubSubPeerFilter(id peer.ID, _ string) bool {
+	return g.IsAllowed(id)
+}
+
+// InterceptPeerDial rejects dialing the peers whose grace period has elapsed.
+func (g *Gater) InterceptPeerDial(id peer.ID) bool {
+	return !g.IsExpired(id)
+}
+
+// InterceptAddrDial accepts all the addresses, as the peer is already checked when dialed.
+func (g *Gater) InterceptAddrDial(_ peer.ID, _ ma.Multiaddr) bool {
+	return true
+}
+
+// InterceptAccept accepts all the inbound connections, as their peer is only known once secured.
+func (g *Gater) InterceptAccept(_ network.ConnMultiaddrs) bool {
+	return true
+}
+
+// InterceptSecured rejects the connections of the peers whose grace period has elapsed.
+func (g *Gater) InterceptSecured(_ network.Direction, id peer.ID, _ network.ConnMultiaddrs) bool {
+	if g.IsExpired(id) {
+		g.logger.Debug("rejecting connection from peer not bound to a valset member", "peer", id.String())
+		return false
+	}
+	return true
+}
+
+// InterceptUpgraded accepts all the upgraded connections, as they were already checked once secured.
+func (g *Gater) InterceptUpgraded(_ network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// UpdateValsetMembers queries the latest valset and the one before it, and sets their members as the
+// EVM addresses whose bound peers are accepted. Keeping the previous valset members allows the orchestrators
+// to keep their connections while the latest valset is being relayed.
+func (g *Gater) UpdateValsetMembers(ctx context.Context, appQuerier *rpc.AppQuerier) error {
+	addresses, err := queryValsetMembers(ctx, appQuerier)
+	if err != nil {
+		return err
+	}
+	g.SetValsetMembers(addresses)
+	return nil
+}
+
+// queryValsetMembers queries the EVM addresses of the members of the latest valset and the one before it.
+func queryValsetMembers(ctx context.Context, appQuerier *rpc.AppQuerier) ([]ethcmn.Address, error) {
+	members, err := queryValsetBridgeValidators(ctx, appQuerier)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]ethcmn.Address, 0, len(members))
+	for _, member := range members {
+		addresses = append(addresses, ethcmn.HexToAddress(member.EvmAddress))
+	}
+	return addresses, nil
+}
+
+// queryValsetBridgeValidators queries the members of the latest valset and the one before it.
+// The members of both valsets are returned twice, the latest valset member last, so that its
+// voting power prevails when the members are indexed by address.
+func queryValsetBridgeValidators(ctx context.Context, appQuerier *rpc.AppQuerier) ([]celestiatypes.BridgeValidator, error) {
+	latestValset, err := appQuerier.QueryLatestValset(ctx)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]celestiatypes.BridgeValidator, 0, len(latestValset.Members))
+	if latestValset.Nonce > 1 {
+		previousValset, err := appQuerier.QueryLastValsetBeforeNonce(ctx, latestValset.Nonce)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, previousValset.Members...)
+	}
+	return append(members, latestValset.Members...), nil
+}
+
+// StartValsetUpdates updates the valset members every interval until the context is canceled.
+func (g *Gater) StartValsetUpdates(ctx context.Context, appQuerier *rpc.AppQuerier, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := g.UpdateValsetMembers(ctx, appQuerier)
+			if err != nil && ctx.Err() == nil {
+				g.logger.Error("couldn't update the gater valset members", "err", err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package p2p_test
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+func newTestPeerKey(t *testing.T) (crypto.PrivKey, peer.ID) {
+	privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	id, err := peer.IDFromPrivateKey(privKey)
+	require.NoError(t, err)
+	return privKey, id
+}
+
+func TestParseAllowlist(t *testing.T) {
+	_, id1 := newTestPeerKey(t)
+	_, id2 := newTestPeerKey(t)
+
+	tests := []struct {
+		name      string
+		allowlist string
+		expected  []peer.ID
+		wantErr   bool
+	}{
+		{
+			name:      "empty allowlist",
+			allowlist: "",
+			expected:  []peer.ID{},
+		},
+		{
+			name:      "peer IDs and multiaddresses",
+			allowlist: fmt.Sprintf("%s, /ip4/127.0.0.1/tcp/30000/p2p/%s,", id1.String(), id2.String()),
+			expected:  []peer.ID{id1, id2},
+		},
+		{
+			name:      "invalid peer ID",
+			allowlist: "invalid",
+			wantErr:   true,
+		},
+		{
+			name:      "multiaddress without peer ID",
+			allowlist: "/ip4/127.0.0.1/tcp/30000",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := p2p.ParseAllowlist(tt.allowlist)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, ids)
+			}
+		})
+	}
+}
+
+func TestGater(t *testing.T) {
+	_, allowlisted := newTestPeerKey(t)
+	_, member := newTestPeerKey(t)
+	_, stranger := newTestPeerKey(t)
+	memberAddr := ethcmn.HexToAddress(evmAddress)
+
+	gracePeriod := 50 * time.Millisecond
+	gater := p2p.NewGater(tmlog.NewNopLogger(), []peer.ID{allowlisted}, gracePeriod)
+	gater.SetValsetMembers([]ethcmn.Address{memberAddr})
+
+	// the allowlisted peers are always accepted
+	assert.True(t, gater.IsAllowed(allowlisted))
+	assert.False(t, gater.IsExpired(allowlisted))
+
+	// the other peers are accepted during their grace period
+	assert.False(t, gater.IsAllowed(member))
+	assert.False(t, gater.IsExpired(member))
+	assert.False(t, gater.IsExpired(stranger))
+	assert.True(t, gater.InterceptPeerDial(stranger))
+
+	// then, only the peers bound to a valset member are accepted
+	gater.Bind(member, memberAddr)
+	time.Sleep(2 * gracePeriod)
+	assert.True(t, gater.IsAllowed(member))
+	assert.False(t, gater.IsExpired(member))
+	assert.True(t, gater.IsExpired(stranger))
+	assert.False(t, gater.InterceptPeerDial(stranger))
+
+	// only the allowed peers are kept in the DHT routing table and the GossipSub mesh
+	assert.True(t, gater.RoutingTableFilter(nil, member))
+	assert.True(t, gater.PubSubPeerFilter(allowlisted, p2p.ConfirmsTopic))
+	assert.False(t, gater.RoutingTableFilter(nil, stranger))
+	assert.False(t, gater.PubSubPeerFilter(stranger, p2p.ConfirmsTopic))
+
+	// the valset changes don't give a new grace period to the rejected peers
+	gater.SetValsetMembers([]ethcmn.Address{ethcmn.HexToAddress("0x91DEd26b5f38B065FC0204c7929Da1b2A21877Ad")})
+	assert.False(t, gater.IsAllowed(member))
+	assert.False(t, gater.IsExpired(member))
+	assert.True(t, gater.IsExpired(stranger))
+
+	// the disconnected peers are forgotten during their grace period, but stay rejected once it elapsed
+	gater.Forget(stranger)
+	assert.True(t, gater.IsExpired(stranger))
+	_, newcomer := newTestPeerKey(t)
+	assert.False(t, gater.IsExpired(newcomer))
+	gater.Forget(newcomer)
+	time.Sleep(2 * gracePeriod)
+	assert.False(t, gater.IsExpired(newcomer))
+}
+
+func TestHandshake(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	privKey1, id1 := newTestPeerKey(t)
+	privKey2, _ := newTestPeerKey(t)
+
+	// the first host only accepts the peers bound to the valset member
+	gater1 := p2p.NewGater(tmlog.NewNopLogger(), nil, time.Minute)
+	gater1.SetValsetMembers([]ethcmn.Address{ethcmn.HexToAddress(evmAddress)})
+	h1, err := p2p.CreateHost("/ip4/127.0.0.1/tcp/0", privKey1, nil, gater1, nil)
+	require.NoError(t, err)
+	defer h1.Close()
+	go func() { _ = p2p.NewHandshake(h1, gater1, nil, tmlog.NewNopLogger()).Start(ctx) }()
+
+	// the second host allowlists the first one, and proves its binding to the valset member
+	gater2 := p2p.NewGater(tmlog.NewNopLogger(), []peer.ID{id1}, time.Minute)
+	h2, err := p2p.CreateHost("/ip4/127.0.0.1/tcp/0", privKey2, nil, gater2, nil)
+	require.NoError(t, err)
+	defer h2.Close()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.ImportECDSA(privateKey, "123")
+	require.NoError(t, err)
+	err = ks.Unlock(acc, "123")
+	require.NoError(t, err)
+	go func() {
+		_ = p2p.NewHandshake(h2, gater2, evm.NewKeystoreSigner(ks, acc), tmlog.NewNopLogger()).Start(ctx)
+	}()
+
+	err = h2.Connect(ctx, peer.AddrInfo{ID: h1.ID(), Addrs: h1.Addrs()})
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return gater1.IsAllowed(h2.ID()) }, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestValidatePeerBinding(t *testing.T) {
+	_, id1 := newTestPeerKey(t)
+	_, id2 := newTestPeerKey(t)
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.ImportECDSA(privateKey, "123")
+	require.NoError(t, err)
+	err = ks.Unlock(acc, "123")
+	require.NoError(t, err)
+	signature, err := evm.NewEthereumSignature(types.PeerBindingSignBytes(id1.String()).Bytes(), ks, acc)
+	require.NoError(t, err)
+	binding := *types.NewPeerBinding(id1.String(), ethcmn.HexToAddress(evmAddress), ethcmn.Bytes2Hex(signature))
+
+	addr, err := p2p.ValidatePeerBinding(binding, id1)
+	require.NoError(t, err)
+	assert.Equal(t, ethcmn.HexToAddress(evmAddress), addr)
+
+	// a binding relayed by another peer is rejected
+	_, err = p2p.ValidatePeerBinding(binding, id2)
+	assert.ErrorIs(t, err, p2p.ErrPeerBindingMismatch)
+
+	// a binding signed by another address is rejected
+	binding.EVMAddress = "0x91DEd26b5f38B065FC0204c7929Da1b2A21877Ad"
+	_, err = p2p.ValidatePeerBinding(binding, id1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,181 @@
+package p2p
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-msgio"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	// HandshakeProtocolID the protocol used by the peers to prove they're bound to an EVM address.
+	HandshakeProtocolID = ProtocolPrefix + "/handshake/1.0.0"
+	// handshakeTimeout the maximum time to send or receive a peer binding.
+	handshakeTimeout = 10 * time.Second
+	// maxPeerBindingSize the maximum size of an encoded peer binding.
+	maxPeerBindingSize = 1024
+)
+
+// Handshake proves to the connected peers that the host P2P key is bound to the EVM address of
+// the signer, and verifies the bindings sent by the peers for the gater.
+// The peers not allowed by the gater once their grace period elapsed are disconnected.
+type Handshake struct {
+	host   host.Host
+	gater  *Gater
+	logger tmlog.Logger
+	// signer the EVM signer proving the binding. If nil, no binding is sent to the peers,
+	// and the host is expected to be allowlisted by them.
+	signer evm.Signer
+	// binding the encoded peer binding sent to the connected peers.
+	binding []byte
+}
+
+// NewHandshake creates a new handshake, and registers its stream handler on the host
+// to receive the peers bindings.
+func NewHandshake(h host.Host, gater *Gater, signer evm.Signer, logger tmlog.Logger) *Handshake {
+	hs := &Handshake{
+		host:   h,
+		gater:  gater,
+		logger: logger,
+		signer: signer,
+	}
+	h.SetStreamHandler(HandshakeProtocolID, hs.handleStream)
+	return hs
+}
+
+// Start signs the host binding, sends it to the connected peers and to the newly connected ones,
+// then disconnects the peers that are not allowed by the gater once their grace period elapsed,
+// until the context is canceled.
+// The disconnected peers are forgotten by the gater.
+func (hs *Handshake) Start(ctx context.Context) error {
+	disconnectNotifiee := &network.NotifyBundle{
+		DisconnectedF: func(n network.Network, conn network.Conn) {
+			if n.Connectedness(conn.RemotePeer()) != network.Connected {
+				hs.gater.Forget(conn.RemotePeer())
+			}
+		},
+	}
+	hs.host.Network().Notify(disconnectNotifiee)
+	defer hs.host.Network().StopNotify(disconnectNotifiee)
+
+	if hs.signer != nil {
+		signature, err := hs.signer.SignDigest(ctx, types.PeerBindingSignBytes(hs.host.ID().String()).Bytes())
+		if err != nil {
+			return err
+		}
+		binding, err := types.MarshalPeerBinding(*types.NewPeerBinding(
+			hs.host.ID().String(),
+			hs.signer.Address(),
+			hex.EncodeToString(signature),
+		))
+		if err != nil {
+			return err
+		}
+		hs.binding = binding
+
+		notifiee := &network.NotifyBundle{
+			ConnectedF: func(_ network.Network, conn network.Conn) {
+				go hs.sendBinding(ctx, conn.RemotePeer())
+			},
+		}
+		hs.host.Network().Notify(notifiee)
+		defer hs.host.Network().StopNotify(notifiee)
+		for _, p := range hs.host.Network().Peers() {
+			go hs.sendBinding(ctx, p)
+		}
+	}
+
+	ticker := time.NewTicker(hs.gater.gracePeriod / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			hs.gater.Prune()
+			for _, p := range hs.host.Network().Peers() {
+				if hs.gater.IsExpired(p) {
+					hs.logger.Debug("disconnecting peer not bound to a valset member", "peer", p.String())
+					_ = hs.host.Network().ClosePeer(p)
+				}
+			}
+		}
+	}
+}
+
+// sendBinding sends the host binding to the provided peer.
+func (hs *Handshake) sendBinding(ctx context.Context, p peer.ID) {
+	ctx, cancel := context.WithTimeout(ctx, handshakeTimeout)
+	defer cancel()
+	stream, err := hs.host.NewStream(ctx, p, HandshakeProtocolID)
+	if err != nil {
+		hs.logger.Debug("couldn't open handshake stream", "peer", p.String(), "err", err.Error())
+		return
+	}
+	defer stream.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = stream.SetDeadline(deadline)
+	}
+	if err := msgio.NewVarintWriter(stream).WriteMsg(hs.binding); err != nil {
+		_ = stream.Reset()
+		hs.logger.Debug("couldn't send peer binding", "peer", p.String(), "err", err.Error())
+	}
+}
+
+// handleStream receives a peer binding, and records it in the gater if it's valid.
+func (hs *Handshake) handleStream(stream network.Stream) {
+	defer stream.Close()
+	_ = stream.SetDeadline(time.Now().Add(handshakeTimeout))
+	remotePeer := stream.Conn().RemotePeer()
+	encoded, err := msgio.NewVarintReaderSize(stream, maxPeerBindingSize).ReadMsg()
+	if err != nil {
+		_ = stream.Reset()
+		hs.logger.Debug("couldn't read peer binding", "peer", remotePeer.String(), "err", err.Error())
+		return
+	}
+	binding, err := types.UnmarshalPeerBinding(encoded)
+	if err != nil {
+		hs.logger.Debug("couldn't decode peer binding", "peer", remotePeer.String(), "err", err.Error())
+		return
+	}
+	addr, err := ValidatePeerBinding(binding, remotePeer)
+	if err != nil {
+		hs.logger.Debug("invalid peer binding", "peer", remotePeer.String(), "err", err.Error())
+		return
+	}
+	hs.gater.Bind(remotePeer, addr)
+	hs.logger.Debug("peer bound", "peer", remotePeer.String(), "evm_address", addr.Hex())
+}
+
+// ValidatePeerBinding checks that the binding was sent by the peer it references, and that it was
+// signed by the EVM address it binds the peer to. Returns the bound EVM address.
+func ValidatePeerBinding(binding types.PeerBinding, remotePeer peer.ID) (ethcmn.Address, error) {
+	if binding.PeerID != remotePeer.String() {
+		return ethcmn.Address{}, ErrPeerBindingMismatch
+	}
+	if !ethcmn.IsHexAddress(binding.EVMAddress) {
+		return ethcmn.Address{}, ErrInvalidEVMAddress
+	}
+	signature := binding.Signature
+	if len(signature) > 2 && signature[:2] == "0x" {
+		signature = signature[2:]
+	}
+	bSignature, err := hex.DecodeString(signature)
+	if err != nil {
+		return ethcmn.Address{}, err
+	}
+	addr := ethcmn.HexToAddress(binding.EVMAddress)
+	err = evm.ValidateEthereumSignature(types.PeerBindingSignBytes(binding.PeerID).Bytes(), bSignature, addr)
+	if err != nil {
+		return ethcmn.Address{}, err
+	}
+	return addr, nil
+}
@@ -2,8 +2,12 @@ package p2p
 
 import (
 	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -12,7 +16,15 @@ import (
 // The listen address is a MultiAddress of the format: /ip4/0.0.0.0/tcp/0
 // Using port 0 means that it will use a random open port.
 // The private key shouldn't be nil.
-func CreateHost(listenMultiAddr string, privateKey crypto.PrivKey, registerer prometheus.Registerer) (host.Host, error) {
+// If the gater is not nil, it's used to gate the host connections.
+// If the scorer is not nil, the connections of the peers it banned are rejected.
+func CreateHost(
+	listenMultiAddr string,
+	privateKey crypto.PrivKey,
+	registerer prometheus.Registerer,
+	gater *Gater,
+	scorer *PeerScorer,
+) (host.Host, error) {
 	multiAddr, err := multiaddr.NewMultiaddr(listenMultiAddr)
 	if err != nil {
 		return nil, err
@@ -28,6 +40,16 @@ func CreateHost(listenMultiAddr string, privateKey crypto.PrivKey, registerer pr
 	} else {
 		params = append(params, libp2p.DisableMetrics())
 	}
+	gaters := make(connectionGaters, 0)
+	if gater != nil {
+		gaters = append(gaters, gater)
+	}
+	if scorer != nil {
+		gaters = append(gaters, scorer)
+	}
+	if len(gaters) != 0 {
+		params = append(params, libp2p.ConnectionGater(gaters))
+	}
 
 	h, err := libp2p.New(params...)
 	if err != nil {
@@ -36,3 +58,51 @@ func CreateHost(listenMultiAddr string, privateKey crypto.PrivKey, registerer pr
 
 	return h, nil
 }
+
+// connectionGaters a connection gater only accepting the connections accepted by all the gaters.
+type connectionGaters []connmgr.ConnectionGater
+
+func (gs connectionGaters) InterceptPeerDial(id peer.ID) bool {
+	for _, g := range gs {
+		if !g.InterceptPeerDial(id) {
+			return false
+		}
+	}
+	return true
+}
+
+func (gs connectionGaters) InterceptAddrDial(id peer.ID, addr multiaddr.Multiaddr) bool {
+	for _, g := range gs {
+		if !g.InterceptAddrDial(id, addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func (gs connectionGaters) InterceptAccept(addrs network.ConnMultiaddrs) bool {
+	for _, g := range gs {
+		if !g.InterceptAccept(addrs) {
+			return false
+		}
+	}
+	return true
+}
+
+func (gs connectionGaters) InterceptSecured(dir network.Direction, id peer.ID, addrs network.ConnMultiaddrs) bool {
+	for _, g := range gs {
+		if !g.InterceptSecured(dir, id, addrs) {
+			return false
+		}
+	}
+	return true
+}
+
+func (gs connectionGaters) InterceptUpgraded(conn network.Conn) (bool, control.DisconnectReason) {
+	for _, g := range gs {
+		if allow, reason := g.InterceptUpgraded(conn); !allow {
+			return false, reason
+		}
+	}
+	return true, 0
+}
@@ -32,6 +32,28 @@ func GetValsetConfirmKey(nonce uint64, evmAddr string, signBytes string) string
 		evmAddr + ":" + signBytes
 }
 
+// GetRelayAnnouncementKey creates a relay announcement key in the
+// format: "/<RelayAnnouncementNamespace>/<nonce>:<relayer_evm_account>:<evm_chain_id>":
+// - nonce: the nonce of the relayed attestation in hex format
+// - relayer evm address: the 0x prefixed relayer EVM address in hex format
+// - evm chain id: the ID of the EVM chain the attestation is relayed to in hex format.
+// Expects the EVM address to be a correct address.
+func GetRelayAnnouncementKey(nonce uint64, relayerAddr string, evmChainID uint64) string {
+	return "/" + RelayAnnouncementNamespace + "/" +
+		strconv.FormatUint(nonce, 16) + ":" +
+		relayerAddr + ":" + strconv.FormatUint(evmChainID, 16)
+}
+
+// GetAggregatedConfirmsKey creates an aggregated confirms key in the
+// format: "/<AggregatedConfirmsNamespace>/<nonce>:<digest>":
+// - nonce: the nonce of the signed attestation in hex format
+// - digest: the valset sign bytes, or the data root tuple root, in a 0x prefixed hex format,
+// that is signed over by the orchestrators.
+func GetAggregatedConfirmsKey(nonce uint64, digest string) string {
+	return "/" + AggregatedConfirmsNamespace + "/" +
+		strconv.FormatUint(nonce, 16) + ":" + digest
+}
+
 // GetLatestValsetKey creates the latest valset key.
 func GetLatestValsetKey() string {
 	return "/" + LatestValsetNamespace + "/latest"
@@ -66,3 +88,28 @@ func ParseKey(key string) (namespace string, nonce uint64, evmAddr string, diges
 	}
 	return
 }
+
+// ParseAggregatedConfirmsKey parses an aggregated confirms key and returns its fields.
+// Will return an error if the key is missing some fields, some fields are empty, or otherwise invalid.
+func ParseAggregatedConfirmsKey(key string) (nonce uint64, digest string, err error) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 3 {
+		return 0, "", ErrInvalidConfirmKey
+	}
+	if parts[1] != AggregatedConfirmsNamespace {
+		return 0, "", ErrInvalidConfirmNamespace
+	}
+	values := strings.Split(parts[2], ":")
+	if len(values) != 2 {
+		return 0, "", ErrInvalidConfirmKey
+	}
+	nonce, err = strconv.ParseUint(values[0], 16, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to parse nonce: %s", err.Error())
+	}
+	digest = values[1]
+	if digest == "" {
+		return 0, "", ErrEmptyDigest
+	}
+	return
+}
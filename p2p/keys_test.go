@@ -133,3 +133,37 @@ func TestParseKey(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRelayAnnouncementKey(t *testing.T) {
+	key := p2p.GetRelayAnnouncementKey(10, "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488", 5)
+	assert.Equal(t, "/ra/a:0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488:5", key)
+
+	namespace, nonce, evmAddr, digest, err := p2p.ParseKey(key)
+	assert.NoError(t, err)
+	assert.Equal(t, p2p.RelayAnnouncementNamespace, namespace)
+	assert.Equal(t, uint64(10), nonce)
+	assert.Equal(t, "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488", evmAddr)
+	assert.Equal(t, "5", digest)
+}
+
+func TestGetAggregatedConfirmsKey(t *testing.T) {
+	key := p2p.GetAggregatedConfirmsKey(10, "0x1234")
+	assert.Equal(t, "/ac/a:0x1234", key)
+
+	nonce, digest, err := p2p.ParseAggregatedConfirmsKey(key)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), nonce)
+	assert.Equal(t, "0x1234", digest)
+
+	invalidKeys := []string{
+		"/vc/a:0x1234",
+		"/ac/a:0xfA906e15C9Eaf338c4110f0E21983c6b3b2d622b:0x1234",
+		"/ac/a:",
+		"/ac/invalid:0x1234",
+		"",
+	}
+	for _, invalidKey := range invalidKeys {
+		_, _, err := p2p.ParseAggregatedConfirmsKey(invalidKey)
+		assert.Error(t, err, invalidKey)
+	}
+}
@@ -0,0 +1,88 @@
+package p2p
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/multiformats/go-base32"
+)
+
+// PruneConfirms deletes the confirms, aggregated confirms and relay announcements whose nonce is lower than
+// the provided one from the datastore.
+// The keys can either be the confirm keys, as saved to the relayer signature store, or their
+// base32 encoding, as saved by the DHT to its datastore. The other keys are left untouched.
+// Returns the number of deleted entries.
+func PruneConfirms(ctx context.Context, store datastore.Batching, beforeNonce uint64) (int, error) {
+	results, err := store.Query(ctx, query.Query{KeysOnly: true})
+	if err != nil {
+		return 0, err
+	}
+	keys := make([]datastore.Key, 0)
+	for result := range results.Next() {
+		if result.Error != nil {
+			_ = results.Close()
+			return 0, result.Error
+		}
+		nonce, ok := parseStoredKeyNonce(result.Key)
+		if ok && nonce < beforeNonce {
+			keys = append(keys, datastore.NewKey(result.Key))
+		}
+	}
+	err = results.Close()
+	if err != nil {
+		return 0, err
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	batch, err := store.Batch(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, key := range keys {
+		err := batch.Delete(ctx, key)
+		if err != nil {
+			return 0, err
+		}
+	}
+	err = batch.Commit(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(keys), nil
+}
+
+// parseStoredKeyNonce returns the nonce referenced by a datastore key, and true if the key
+// is a confirm, an aggregated confirms or a relay announcement key.
+func parseStoredKeyNonce(key string) (uint64, bool) {
+	if nonce, ok := parseKeyNonce(key); ok {
+		return nonce, true
+	}
+	// the DHT saves the records under the base32 encoding of their keys.
+	decoded, err := base32.RawStdEncoding.DecodeString(strings.TrimPrefix(key, "/"))
+	if err != nil {
+		return 0, false
+	}
+	return parseKeyNonce(string(decoded))
+}
+
+// parseKeyNonce returns the nonce referenced by a key, and true if the key is a confirm, an
+// aggregated confirms or a relay announcement key.
+func parseKeyNonce(key string) (uint64, bool) {
+	if nonce, _, err := ParseAggregatedConfirmsKey(key); err == nil {
+		return nonce, true
+	}
+	namespace, nonce, _, _, err := ParseKey(key)
+	if err != nil {
+		return 0, false
+	}
+	switch namespace {
+	case DataCommitmentConfirmNamespace, ValsetConfirmNamespace, RelayAnnouncementNamespace:
+		return nonce, true
+	default:
+		return 0, false
+	}
+}
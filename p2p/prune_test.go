@@ -0,0 +1,56 @@
+package p2p_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/ipfs/go-datastore"
+	"github.com/multiformats/go-base32"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneConfirms(t *testing.T) {
+	ctx := context.Background()
+	store := datastore.NewMapDatastore()
+
+	// the DHT saves the records under the base32 encoding of their keys.
+	dhtKey := func(key string) string {
+		return base32.RawStdEncoding.EncodeToString([]byte(key))
+	}
+	evmAddr := "0xfA906e15C9Eaf338c4110f0E21983c6b3b2d622b"
+	keys := map[string]bool{
+		// key: should be pruned
+		p2p.GetDataCommitmentConfirmKey(1, evmAddr, "0x1234"):     true,
+		p2p.GetDataCommitmentConfirmKey(2, evmAddr, "0x1234"):     true,
+		p2p.GetDataCommitmentConfirmKey(3, evmAddr, "0x1234"):     false,
+		dhtKey(p2p.GetValsetConfirmKey(1, evmAddr, "0x1234")):     true,
+		dhtKey(p2p.GetValsetConfirmKey(16, evmAddr, "0x1234")):    false,
+		dhtKey(p2p.GetRelayAnnouncementKey(2, evmAddr, 5)):        true,
+		dhtKey(p2p.GetAggregatedConfirmsKey(2, "0x1234")):         true,
+		dhtKey(p2p.GetAggregatedConfirmsKey(3, "0x1234")):         false,
+		dhtKey(p2p.GetLatestValsetKey()):                          false,
+		"/orchestrator/last-processed-nonce":                      false,
+		"/dcc/invalid":                                            false,
+		dhtKey(p2p.GetDataCommitmentConfirmKey(4, evmAddr, "0x")): false,
+	}
+	for key := range keys {
+		require.NoError(t, store.Put(ctx, datastore.NewKey(key), []byte("value")))
+	}
+
+	count, err := p2p.PruneConfirms(ctx, store, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+
+	for key, pruned := range keys {
+		has, err := store.Has(ctx, datastore.NewKey(key))
+		require.NoError(t, err)
+		assert.Equal(t, !pruned, has, key)
+	}
+
+	// pruning again doesn't delete anything
+	count, err = p2p.PruneConfirms(ctx, store, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
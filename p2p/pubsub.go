@@ -0,0 +1,158 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+// ConfirmsTopic the GossipSub topic on which the orchestrators publish their confirms.
+const ConfirmsTopic = ProtocolPrefix + "/confirms"
+
+// ConfirmMessage the message published on the confirms topic.
+// It contains the confirm along with its DHT key, so that it can be validated using
+// the same validators as the DHT.
+type ConfirmMessage struct {
+	// Key the DHT key of the confirm.
+	Key string `json:"key"`
+	// Value the encoded confirm.
+	Value []byte `json:"value"`
+}
+
+// ConfirmsPubSub publishes and receives confirms using the confirms GossipSub topic.
+type ConfirmsPubSub struct {
+	topic        *pubsub.Topic
+	subscription *pubsub.Subscription
+	logger       tmlog.Logger
+}
+
+// NewConfirmsPubSub creates a new GossipSub router on the provided host, and joins the confirms topic.
+// The received messages are validated using the DHT validators defined under `p2p/validators.go`.
+// Subscribing to the topic, even if the received confirms are not used, allows the node to forward
+// the confirms to its peers.
+// If the gater is not nil, the confirms are only exchanged with the peers it allows.
+func NewConfirmsPubSub(ctx context.Context, h host.Host, gater *Gater, logger tmlog.Logger) (*ConfirmsPubSub, error) {
+	options := make([]pubsub.Option, 0)
+	validator := ValidateConfirmMessage
+	if gater != nil {
+		options = append(options, pubsub.WithPeerFilter(gater.PubSubPeerFilter))
+		validator = func(ctx context.Context, from peer.ID, msg *pubsub.Message) bool {
+			if from != h.ID() && !gater.IsAllowed(from) {
+				return false
+			}
+			return ValidateConfirmMessage(ctx, from, msg)
+		}
+	}
+	ps, err := pubsub.NewGossipSub(ctx, h, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ps.RegisterTopicValidator(ConfirmsTopic, validator)
+	if err != nil {
+		return nil, err
+	}
+
+	topic, err := ps.Join(ConfirmsTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := topic.Subscribe()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConfirmsPubSub{
+		topic:        topic,
+		subscription: subscription,
+		logger:       logger,
+	}, nil
+}
+
+// ValidateConfirmMessage validates a message received on the confirms topic using the DHT validator
+// corresponding to the confirm key namespace.
+func ValidateConfirmMessage(_ context.Context, _ peer.ID, msg *pubsub.Message) bool {
+	var confirmMsg ConfirmMessage
+	if err := json.Unmarshal(msg.Data, &confirmMsg); err != nil {
+		return false
+	}
+	namespace, _, _, _, err := ParseKey(confirmMsg.Key)
+	if err != nil {
+		return false
+	}
+	switch namespace {
+	case DataCommitmentConfirmNamespace:
+		return DataCommitmentConfirmValidator{}.Validate(confirmMsg.Key, confirmMsg.Value) == nil
+	case ValsetConfirmNamespace:
+		return ValsetConfirmValidator{}.Validate(confirmMsg.Key, confirmMsg.Value) == nil
+	default:
+		return false
+	}
+}
+
+// PublishDataCommitmentConfirm encodes a data commitment confirm then publishes it on the confirms topic.
+// The key can be generated using the `GetDataCommitmentConfirmKey` method.
+func (ps *ConfirmsPubSub) PublishDataCommitmentConfirm(ctx context.Context, key string, dcc types.DataCommitmentConfirm) error {
+	encodedData, err := types.MarshalDataCommitmentConfirm(dcc)
+	if err != nil {
+		return err
+	}
+	return ps.publish(ctx, key, encodedData)
+}
+
+// PublishValsetConfirm encodes a valset confirm then publishes it on the confirms topic.
+// The key can be generated using the `GetValsetConfirmKey` method.
+func (ps *ConfirmsPubSub) PublishValsetConfirm(ctx context.Context, key string, vc types.ValsetConfirm) error {
+	encodedData, err := types.MarshalValsetConfirm(vc)
+	if err != nil {
+		return err
+	}
+	return ps.publish(ctx, key, encodedData)
+}
+
+func (ps *ConfirmsPubSub) publish(ctx context.Context, key string, value []byte) error {
+	encodedMsg, err := json.Marshal(ConfirmMessage{Key: key, Value: value})
+	if err != nil {
+		return err
+	}
+	return ps.topic.Publish(ctx, encodedMsg)
+}
+
+// Start receives the confirms published on the confirms topic, and adds them to the provided pool.
+// If the pool is nil, the received confirms are discarded.
+// Blocks until the context is done.
+func (ps *ConfirmsPubSub) Start(ctx context.Context, pool *ConfirmPool) error {
+	for {
+		msg, err := ps.subscription.Next(ctx)
+		if err != nil {
+			if errors.Is(err, ctx.Err()) || errors.Is(err, pubsub.ErrSubscriptionCancelled) {
+				return nil
+			}
+			return err
+		}
+		if pool == nil {
+			continue
+		}
+		var confirmMsg ConfirmMessage
+		// the message was validated by the topic validator, so it can be decoded
+		if err := json.Unmarshal(msg.Data, &confirmMsg); err != nil {
+			continue
+		}
+		if err := pool.Add(confirmMsg.Key, confirmMsg.Value); err != nil {
+			ps.logger.Debug("failed to add confirm to pool", "key", confirmMsg.Key, "err", err.Error())
+		}
+	}
+}
+
+// Close leaves the confirms topic.
+func (ps *ConfirmsPubSub) Close() error {
+	ps.subscription.Cancel()
+	return ps.topic.Close()
+}
@@ -0,0 +1,129 @@
+package p2p_test
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	blobstreamtesting "github.com/celestiaorg/orchestrator-relayer/testing"
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+func TestConfirmsPubSub(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	network := blobstreamtesting.NewDHTNetwork(ctx, 2)
+	defer network.Stop()
+
+	publisher, err := p2p.NewConfirmsPubSub(ctx, network.DHTs[0].Host(), nil, tmlog.NewNopLogger())
+	require.NoError(t, err)
+	defer publisher.Close()
+	go func() { _ = publisher.Start(ctx, nil) }()
+
+	subscriber, err := p2p.NewConfirmsPubSub(ctx, network.DHTs[1].Host(), nil, tmlog.NewNopLogger())
+	require.NoError(t, err)
+	defer subscriber.Close()
+	pool := p2p.NewConfirmPool(tmlog.NewNopLogger())
+	pool.SetValsetMembers([]common.Address{ethAddr1, ethAddr2})
+	pool.SetLatestNonce(10)
+	go func() { _ = subscriber.Start(ctx, pool) }()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.ImportECDSA(privateKey1, "123")
+	require.NoError(t, err)
+	require.NoError(t, ks.Unlock(acc, "123"))
+
+	nonce := uint64(10)
+	bCommitment, _ := hex.DecodeString("1234")
+	dataRootHash := types.DataCommitmentTupleRootSignBytes(big.NewInt(int64(nonce)), bCommitment)
+	signature, err := evm.NewEthereumSignature(dataRootHash.Bytes(), ks, acc)
+	require.NoError(t, err)
+	confirm := types.NewDataCommitmentConfirm(hex.EncodeToString(signature), ethAddr1)
+	key := p2p.GetDataCommitmentConfirmKey(nonce, ethAddr1.Hex(), dataRootHash.Hex())
+
+	// an invalid confirm is rejected by the topic validator
+	invalidConfirm := types.NewDataCommitmentConfirm(hex.EncodeToString(signature), ethAddr2)
+	invalidKey := p2p.GetDataCommitmentConfirmKey(nonce, ethAddr2.Hex(), dataRootHash.Hex())
+	assert.Error(t, publisher.PublishDataCommitmentConfirm(ctx, invalidKey, *invalidConfirm))
+
+	// the gossip mesh takes some time to be formed
+	assert.Eventually(t, func() bool {
+		require.NoError(t, publisher.PublishDataCommitmentConfirm(ctx, key, *confirm))
+		_, has := pool.GetDataCommitmentConfirm(key)
+		return has
+	}, 30*time.Second, 500*time.Millisecond)
+
+	received, has := pool.GetDataCommitmentConfirm(key)
+	require.True(t, has)
+	assert.Equal(t, *confirm, received)
+	_, has = pool.GetDataCommitmentConfirm(invalidKey)
+	assert.False(t, has)
+}
+
+func TestQueryDataCommitmentConfirmsFromPool(t *testing.T) {
+	ctx := context.Background()
+	network := blobstreamtesting.NewDHTNetwork(ctx, 2)
+	defer network.Stop()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc1, err := ks.ImportECDSA(privateKey1, "123")
+	require.NoError(t, err)
+	require.NoError(t, ks.Unlock(acc1, "123"))
+	acc2, err := ks.ImportECDSA(privateKey2, "123")
+	require.NoError(t, err)
+	require.NoError(t, ks.Unlock(acc2, "123"))
+
+	nonce := uint64(2)
+	bCommitment, _ := hex.DecodeString("1234")
+	dataRootHash := types.DataCommitmentTupleRootSignBytes(big.NewInt(int64(nonce)), bCommitment)
+
+	// the first confirm is only in the pool
+	signature1, err := evm.NewEthereumSignature(dataRootHash.Bytes(), ks, acc1)
+	require.NoError(t, err)
+	dc1 := types.NewDataCommitmentConfirm(hex.EncodeToString(signature1), ethAddr1)
+	encodedDc1, err := types.MarshalDataCommitmentConfirm(*dc1)
+	require.NoError(t, err)
+	pool := p2p.NewConfirmPool(tmlog.NewNopLogger())
+	pool.SetValsetMembers([]common.Address{ethAddr1})
+	pool.SetLatestNonce(nonce)
+	require.NoError(t, pool.Add(p2p.GetDataCommitmentConfirmKey(nonce, ethAddr1.String(), dataRootHash.Hex()), encodedDc1))
+
+	// the second confirm is only in the DHT
+	signature2, err := evm.NewEthereumSignature(dataRootHash.Bytes(), ks, acc2)
+	require.NoError(t, err)
+	dc2 := types.NewDataCommitmentConfirm(hex.EncodeToString(signature2), ethAddr2)
+	err = network.DHTs[0].PutDataCommitmentConfirm(
+		ctx,
+		p2p.GetDataCommitmentConfirmKey(nonce, ethAddr2.String(), dataRootHash.Hex()),
+		*dc2,
+	)
+	require.NoError(t, err)
+
+	querier := p2p.NewQuerier(network.DHTs[0], tmlog.NewNopLogger())
+	querier.ConfirmPool = pool
+
+	valset := celestiatypes.Valset{
+		Nonce: 10,
+		Members: []celestiatypes.BridgeValidator{
+			{Power: 10, EvmAddress: ethAddr1.String()},
+			{Power: 15, EvmAddress: ethAddr2.String()},
+			{Power: 10, EvmAddress: ethAddr3.String()},
+		},
+		Height: 10,
+	}
+	confirms, err := querier.QueryDataCommitmentConfirms(ctx, valset, nonce, dataRootHash.Hex())
+	require.NoError(t, err)
+	assert.Len(t, confirms, 2)
+	assert.Contains(t, confirms, *dc1)
+	assert.Contains(t, confirms, *dc2)
+}
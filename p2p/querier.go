@@ -4,20 +4,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/routing"
 	pkgerrors "github.com/pkg/errors"
 	tmlog "github.com/tendermint/tendermint/libs/log"
 
 	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
 	"github.com/celestiaorg/orchestrator-relayer/types"
 )
 
 // Querier used to query the DHT for confirms.
 type Querier struct {
 	BlobstreamDHT *BlobstreamDHT
-	logger        tmlog.Logger
+	// ConfirmPool the confirms received via the confirms gossip topic. If set, the confirms
+	// are looked up in the pool first, and the DHT is only queried for the missing ones.
+	ConfirmPool *ConfirmPool
+	// SignatureStore the local store of the previously collected confirms, e.g. the relayer
+	// signature store. If set, the confirms are looked up in the store first, and the DHT is
+	// only queried for the missing ones.
+	SignatureStore datastore.Read
+	logger         tmlog.Logger
 }
 
 func NewQuerier(blobStreamDht *BlobstreamDHT, logger tmlog.Logger) *Querier {
@@ -27,6 +37,13 @@ func NewQuerier(blobStreamDht *BlobstreamDHT, logger tmlog.Logger) *Querier {
 	}
 }
 
+// WithoutSignatureStore returns a copy of the querier that doesn't look up the confirms in the
+// signature store, e.g. to re-fetch confirms that turned out to be insufficient.
+func (q Querier) WithoutSignatureStore() *Querier {
+	q.SignatureStore = nil
+	return &q
+}
+
 // QueryTwoThirdsDataCommitmentConfirms queries two thirds or more of data commitment confirms from the
 // P2P network. The method will not return unless it finds more than two thirds, or it times out.
 // No validation is required to be done at this level because the P2P validators defined at
@@ -42,7 +59,10 @@ func (q Querier) QueryTwoThirdsDataCommitmentConfirms(
 	previousValset celestiatypes.Valset,
 	nonce uint64,
 	dataRootTupleRoot string,
-) ([]types.DataCommitmentConfirm, error) {
+) (_ []types.DataCommitmentConfirm, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "Querier.QueryTwoThirdsDataCommitmentConfirms", telemetry.Nonce(nonce))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	// create a map to easily search for power
 	vals := make(map[string]celestiatypes.BridgeValidator)
 	for _, val := range previousValset.Members {
@@ -51,12 +71,38 @@ func (q Querier) QueryTwoThirdsDataCommitmentConfirms(
 
 	majThreshHold := previousValset.TwoThirdsThreshold()
 
+	if q.SignatureStore != nil {
+		// the DHT is not queried if the signature store already contains enough confirms,
+		// so that the attestation can be relayed even if the P2P network is unavailable.
+		localConfirms := q.queryLocalDataCommitmentConfirms(ctx, previousValset, nonce, dataRootTupleRoot)
+		addresses := make([]string, len(localConfirms))
+		for i, confirm := range localConfirms {
+			addresses[i] = confirm.EthAddress
+		}
+		if power := membersPower(vals, addresses); power >= majThreshHold {
+			q.logger.Info("found enough data commitment confirms in the signature store to be relayed", "nonce", nonce, "majThreshHold", majThreshHold, "currThreshold", power)
+			return localConfirms, nil
+		}
+	}
+
 	var validConfirms []types.DataCommitmentConfirm
 	queryFunc := func() error {
-		confirms, err := q.QueryDataCommitmentConfirms(ctx, previousValset, nonce, dataRootTupleRoot)
+		// the aggregated confirms are looked up first, as they can be retrieved in a single lookup,
+		// and the confirms are only queried one by one if they don't hold enough signatures.
+		confirms, err := q.QueryAggregatedDataCommitmentConfirms(ctx, previousValset, nonce, dataRootTupleRoot)
 		if err != nil {
 			return err
 		}
+		addresses := make([]string, len(confirms))
+		for i, confirm := range confirms {
+			addresses[i] = confirm.EthAddress
+		}
+		if membersPower(vals, addresses) < majThreshHold {
+			confirms, err = q.QueryDataCommitmentConfirms(ctx, previousValset, nonce, dataRootTupleRoot)
+			if err != nil {
+				return err
+			}
+		}
 
 		currThreshold := uint64(0)
 		for _, dataCommitmentConfirm := range confirms {
@@ -97,7 +143,7 @@ func (q Querier) QueryTwoThirdsDataCommitmentConfirms(
 	// because the ticker waits for the period to pass to return for the first time, we will execute
 	// the query func here to get the confirms if they're already ready instead of waiting for the first
 	// duration to elapse.
-	err := queryFunc()
+	err = queryFunc()
 	if err != nil {
 		return nil, err
 	}
@@ -146,7 +192,10 @@ func (q Querier) QueryTwoThirdsValsetConfirms(
 	valsetNonce uint64,
 	previousValset celestiatypes.Valset,
 	signBytes string,
-) ([]types.ValsetConfirm, error) {
+) (_ []types.ValsetConfirm, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "Querier.QueryTwoThirdsValsetConfirms", telemetry.Nonce(valsetNonce))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	// create a map to easily search for power
 	vals := make(map[string]celestiatypes.BridgeValidator)
 	for _, val := range previousValset.Members {
@@ -155,12 +204,38 @@ func (q Querier) QueryTwoThirdsValsetConfirms(
 
 	majThreshHold := previousValset.TwoThirdsThreshold()
 
+	if q.SignatureStore != nil {
+		// the DHT is not queried if the signature store already contains enough confirms,
+		// so that the attestation can be relayed even if the P2P network is unavailable.
+		localConfirms := q.queryLocalValsetConfirms(ctx, valsetNonce, previousValset, signBytes)
+		addresses := make([]string, len(localConfirms))
+		for i, confirm := range localConfirms {
+			addresses[i] = confirm.EthAddress
+		}
+		if power := membersPower(vals, addresses); power >= majThreshHold {
+			q.logger.Info("found enough valset confirms in the signature store to be relayed", "nonce", valsetNonce, "majThreshHold", majThreshHold, "currThreshold", power)
+			return localConfirms, nil
+		}
+	}
+
 	var validConfirms []types.ValsetConfirm
 	queryFunc := func() error {
-		confirms, err := q.QueryValsetConfirms(ctx, valsetNonce, previousValset, signBytes)
+		// the aggregated confirms are looked up first, as they can be retrieved in a single lookup,
+		// and the confirms are only queried one by one if they don't hold enough signatures.
+		confirms, err := q.QueryAggregatedValsetConfirms(ctx, valsetNonce, previousValset, signBytes)
 		if err != nil {
 			return err
 		}
+		addresses := make([]string, len(confirms))
+		for i, confirm := range confirms {
+			addresses[i] = confirm.EthAddress
+		}
+		if membersPower(vals, addresses) < majThreshHold {
+			confirms, err = q.QueryValsetConfirms(ctx, valsetNonce, previousValset, signBytes)
+			if err != nil {
+				return err
+			}
+		}
 
 		currThreshold := uint64(0)
 		for _, valsetConfirm := range confirms {
@@ -204,7 +279,7 @@ func (q Querier) QueryTwoThirdsValsetConfirms(
 	// because the ticker waits for the period to pass to return for the first time, we will execute
 	// the query func here to get the confirms if they're already ready instead of waiting for the first
 	// duration to elapse.
-	err := queryFunc()
+	err = queryFunc()
 	if err != nil {
 		return nil, err
 	}
@@ -278,15 +353,24 @@ func (q Querier) QueryDataCommitmentConfirmByEVMAddress(ctx context.Context, non
 	return &confirm, nil
 }
 
+// QueryConfirmHoldersCount returns the number of peers, excluding the local node, from which the
+// confirm referenced by the provided key can be retrieved.
+func (q Querier) QueryConfirmHoldersCount(ctx context.Context, key string) (int, error) {
+	return q.BlobstreamDHT.CountPeersWithValue(ctx, key)
+}
+
 // QueryDataCommitmentConfirms get all the data commitment confirms in store for a certain nonce.
 // It goes over the valset members and looks if they submitted any confirms.
+// If a signature store or a confirm pool is set, the DHT is only queried for the confirms missing from them.
 func (q Querier) QueryDataCommitmentConfirms(ctx context.Context, valset celestiatypes.Valset, nonce uint64, dataRootTupleRoot string) ([]types.DataCommitmentConfirm, error) {
 	confirms := make([]types.DataCommitmentConfirm, 0)
 	for _, member := range valset.Members {
-		confirm, err := q.BlobstreamDHT.GetDataCommitmentConfirm(
-			ctx,
-			GetDataCommitmentConfirmKey(nonce, member.EvmAddress, dataRootTupleRoot),
-		)
+		key := GetDataCommitmentConfirmKey(nonce, member.EvmAddress, dataRootTupleRoot)
+		if confirm, has := q.getLocalDataCommitmentConfirm(ctx, key); has {
+			confirms = append(confirms, confirm)
+			continue
+		}
+		confirm, err := q.BlobstreamDHT.GetDataCommitmentConfirm(ctx, key)
 		if err == nil {
 			confirms = append(confirms, confirm)
 		} else if errors.Is(err, routing.ErrNotFound) {
@@ -301,13 +385,16 @@ func (q Querier) QueryDataCommitmentConfirms(ctx context.Context, valset celesti
 // QueryValsetConfirms get all the valset confirms in store for a certain nonce.
 // It goes over the specified valset members and looks if they submitted any confirms
 // for the provided nonce.
+// If a signature store or a confirm pool is set, the DHT is only queried for the confirms missing from them.
 func (q Querier) QueryValsetConfirms(ctx context.Context, nonce uint64, valset celestiatypes.Valset, signBytes string) ([]types.ValsetConfirm, error) {
 	confirms := make([]types.ValsetConfirm, 0)
 	for _, member := range valset.Members {
-		confirm, err := q.BlobstreamDHT.GetValsetConfirm(
-			ctx,
-			GetValsetConfirmKey(nonce, member.EvmAddress, signBytes),
-		)
+		key := GetValsetConfirmKey(nonce, member.EvmAddress, signBytes)
+		if confirm, has := q.getLocalValsetConfirm(ctx, key); has {
+			confirms = append(confirms, confirm)
+			continue
+		}
+		confirm, err := q.BlobstreamDHT.GetValsetConfirm(ctx, key)
 		if err == nil {
 			confirms = append(confirms, confirm)
 		} else if errors.Is(err, routing.ErrNotFound) {
@@ -319,6 +406,157 @@ func (q Querier) QueryValsetConfirms(ctx context.Context, nonce uint64, valset c
 	return confirms, nil
 }
 
+// QueryAggregatedDataCommitmentConfirms gets the data commitment confirms of the valset members for a certain
+// nonce from its aggregated confirms record, in a single DHT lookup.
+// Returns an empty slice if the record is not found.
+func (q Querier) QueryAggregatedDataCommitmentConfirms(ctx context.Context, valset celestiatypes.Valset, nonce uint64, dataRootTupleRoot string) ([]types.DataCommitmentConfirm, error) {
+	confirms := make([]types.DataCommitmentConfirm, 0)
+	ac, err := q.queryAggregatedConfirms(ctx, valset, nonce, dataRootTupleRoot)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range valset.Members {
+		for _, signature := range ac.Signatures {
+			if strings.EqualFold(signature.EthAddress, member.EvmAddress) {
+				confirms = append(confirms, types.DataCommitmentConfirm{
+					Signature:  signature.Signature,
+					EthAddress: member.EvmAddress,
+				})
+				break
+			}
+		}
+	}
+	return confirms, nil
+}
+
+// QueryAggregatedValsetConfirms gets the valset confirms of the valset members for a certain nonce
+// from its aggregated confirms record, in a single DHT lookup.
+// Returns an empty slice if the record is not found.
+func (q Querier) QueryAggregatedValsetConfirms(ctx context.Context, nonce uint64, valset celestiatypes.Valset, signBytes string) ([]types.ValsetConfirm, error) {
+	confirms := make([]types.ValsetConfirm, 0)
+	ac, err := q.queryAggregatedConfirms(ctx, valset, nonce, signBytes)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range valset.Members {
+		for _, signature := range ac.Signatures {
+			if strings.EqualFold(signature.EthAddress, member.EvmAddress) {
+				confirms = append(confirms, types.ValsetConfirm{
+					EthAddress: member.EvmAddress,
+					Signature:  signature.Signature,
+				})
+				break
+			}
+		}
+	}
+	return confirms, nil
+}
+
+// queryAggregatedConfirms gets the aggregated confirms records of the nonce and digest from the DHT,
+// and selects the one holding the most voting power of the provided valset.
+// The selection is done locally, instead of by the DHT validator, as the provided valset might not
+// be the one the DHT validator knows of.
+// Returns an empty record if no record holding a signature of the valset members is found.
+func (q Querier) queryAggregatedConfirms(ctx context.Context, valset celestiatypes.Valset, nonce uint64, digest string) (types.AggregatedConfirms, error) {
+	key := GetAggregatedConfirmsKey(nonce, digest)
+	values, err := q.BlobstreamDHT.GetValues(ctx, key)
+	if err != nil {
+		if errors.Is(err, routing.ErrNotFound) {
+			return *types.NewAggregatedConfirms(nonce, digest), nil
+		}
+		return types.AggregatedConfirms{}, err
+	}
+	validator := NewAggregatedConfirmsValidator()
+	validator.SetValsetMembers(valset.Members)
+	index, err := validator.Select(key, values)
+	if err != nil {
+		if errors.Is(err, ErrNoValidValueFound) {
+			return *types.NewAggregatedConfirms(nonce, digest), nil
+		}
+		return types.AggregatedConfirms{}, err
+	}
+	return types.UnmarshalAggregatedConfirms(values[index])
+}
+
+// queryLocalDataCommitmentConfirms gets the data commitment confirms of the valset members for a certain nonce
+// from the signature store and the confirm pool, without querying the DHT.
+func (q Querier) queryLocalDataCommitmentConfirms(ctx context.Context, valset celestiatypes.Valset, nonce uint64, dataRootTupleRoot string) []types.DataCommitmentConfirm {
+	confirms := make([]types.DataCommitmentConfirm, 0)
+	for _, member := range valset.Members {
+		if confirm, has := q.getLocalDataCommitmentConfirm(ctx, GetDataCommitmentConfirmKey(nonce, member.EvmAddress, dataRootTupleRoot)); has {
+			confirms = append(confirms, confirm)
+		}
+	}
+	return confirms
+}
+
+// queryLocalValsetConfirms gets the valset confirms of the valset members for a certain nonce
+// from the signature store and the confirm pool, without querying the DHT.
+func (q Querier) queryLocalValsetConfirms(ctx context.Context, nonce uint64, valset celestiatypes.Valset, signBytes string) []types.ValsetConfirm {
+	confirms := make([]types.ValsetConfirm, 0)
+	for _, member := range valset.Members {
+		if confirm, has := q.getLocalValsetConfirm(ctx, GetValsetConfirmKey(nonce, member.EvmAddress, signBytes)); has {
+			confirms = append(confirms, confirm)
+		}
+	}
+	return confirms
+}
+
+// getLocalDataCommitmentConfirm looks up the data commitment confirm in the signature store, then in
+// the confirm pool.
+// Failing to read the signature store is not fatal as the confirm can still be queried from the DHT.
+func (q Querier) getLocalDataCommitmentConfirm(ctx context.Context, key string) (types.DataCommitmentConfirm, bool) {
+	if q.SignatureStore != nil {
+		value, err := q.SignatureStore.Get(ctx, datastore.NewKey(key))
+		if err == nil {
+			confirm, err := types.UnmarshalDataCommitmentConfirm(value)
+			if err == nil {
+				return confirm, true
+			}
+			q.logger.Debug("failed to unmarshal stored data commitment confirm", "key", key, "err", err.Error())
+		} else if !errors.Is(err, datastore.ErrNotFound) {
+			q.logger.Debug("failed to read the signature store", "key", key, "err", err.Error())
+		}
+	}
+	if q.ConfirmPool != nil {
+		return q.ConfirmPool.GetDataCommitmentConfirm(key)
+	}
+	return types.DataCommitmentConfirm{}, false
+}
+
+// getLocalValsetConfirm looks up the valset confirm in the signature store, then in the confirm pool.
+// Failing to read the signature store is not fatal as the confirm can still be queried from the DHT.
+func (q Querier) getLocalValsetConfirm(ctx context.Context, key string) (types.ValsetConfirm, bool) {
+	if q.SignatureStore != nil {
+		value, err := q.SignatureStore.Get(ctx, datastore.NewKey(key))
+		if err == nil {
+			confirm, err := types.UnmarshalValsetConfirm(value)
+			if err == nil {
+				return confirm, true
+			}
+			q.logger.Debug("failed to unmarshal stored valset confirm", "key", key, "err", err.Error())
+		} else if !errors.Is(err, datastore.ErrNotFound) {
+			q.logger.Debug("failed to read the signature store", "key", key, "err", err.Error())
+		}
+	}
+	if q.ConfirmPool != nil {
+		return q.ConfirmPool.GetValsetConfirm(key)
+	}
+	return types.ValsetConfirm{}, false
+}
+
+// membersPower returns the total power of the validators whose EVM addresses are provided.
+// The addresses that don't belong to a validator are ignored.
+func membersPower(vals map[string]celestiatypes.BridgeValidator, evmAddresses []string) uint64 {
+	power := uint64(0)
+	for _, address := range evmAddresses {
+		if val, has := vals[address]; has {
+			power += val.Power
+		}
+	}
+	return power
+}
+
 // QueryLatestValset get the latest valset from the p2p network.
 func (q Querier) QueryLatestValset(
 	ctx context.Context,
@@ -329,3 +567,26 @@ func (q Querier) QueryLatestValset(
 	}
 	return &latestValset, nil
 }
+
+// QueryRelayAnnouncements get the relay announcements in store for a certain nonce and EVM chain.
+// It goes over the provided relayers and looks if they announced relaying the attestation.
+// Failing to query an announcement is treated as the relayer not having announced it, so that
+// an unavailable DHT results in relaying the attestation rather than not relaying it at all.
+// Returns an error only if the context is done.
+func (q Querier) QueryRelayAnnouncements(ctx context.Context, nonce uint64, evmChainID uint64, relayers []string) ([]types.RelayAnnouncement, error) {
+	announcements := make([]types.RelayAnnouncement, 0)
+	for _, relayer := range relayers {
+		announcement, err := q.BlobstreamDHT.GetRelayAnnouncement(
+			ctx,
+			GetRelayAnnouncementKey(nonce, relayer, evmChainID),
+		)
+		if err == nil {
+			announcements = append(announcements, announcement)
+		} else if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if !errors.Is(err, routing.ErrNotFound) {
+			q.logger.Error("failed to query relay announcement", "nonce", nonce, "relayer", relayer, "err", err.Error())
+		}
+	}
+	return announcements, nil
+}
@@ -17,6 +17,7 @@ import (
 	blobstreamtesting "github.com/celestiaorg/orchestrator-relayer/testing"
 	"github.com/celestiaorg/orchestrator-relayer/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ipfs/go-datastore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	tmlog "github.com/tendermint/tendermint/libs/log"
@@ -524,3 +525,151 @@ func TestQueryDataCommitmentConfirms(t *testing.T) {
 	assert.Contains(t, confirms, *dc2)
 	assert.Contains(t, confirms, *dc3)
 }
+
+func TestQueryTwoThirdsConfirmsFromSignatureStore(t *testing.T) {
+	ctx := context.Background()
+	network := blobstreamtesting.NewDHTNetwork(ctx, 2)
+	defer network.Stop()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc1, err := ks.ImportECDSA(privateKey1, "123")
+	require.NoError(t, err)
+	require.NoError(t, ks.Unlock(acc1, "123"))
+	acc2, err := ks.ImportECDSA(privateKey2, "123")
+	require.NoError(t, err)
+	require.NoError(t, ks.Unlock(acc2, "123"))
+
+	previousValset := celestiatypes.Valset{
+		Nonce: 2,
+		Members: []celestiatypes.BridgeValidator{
+			{Power: 10, EvmAddress: ethAddr1.String()},
+			{Power: 15, EvmAddress: ethAddr2.String()},
+			{Power: 10, EvmAddress: ethAddr3.String()},
+		},
+		Height: 10,
+	}
+	nonce := uint64(4)
+	bCommitment, _ := hex.DecodeString("1234")
+	dataRootHash := types.DataCommitmentTupleRootSignBytes(big.NewInt(int64(nonce)), bCommitment)
+
+	// the first confirm is only in the signature store
+	signature1, err := evm.NewEthereumSignature(dataRootHash.Bytes(), ks, acc1)
+	require.NoError(t, err)
+	dc1 := types.NewDataCommitmentConfirm(hex.EncodeToString(signature1), ethAddr1)
+	encodedDc1, err := types.MarshalDataCommitmentConfirm(*dc1)
+	require.NoError(t, err)
+	sigStore := datastore.NewMapDatastore()
+	require.NoError(t, sigStore.Put(ctx, datastore.NewKey(p2p.GetDataCommitmentConfirmKey(nonce, ethAddr1.String(), dataRootHash.Hex())), encodedDc1))
+
+	// the second confirm is only in the DHT
+	signature2, err := evm.NewEthereumSignature(dataRootHash.Bytes(), ks, acc2)
+	require.NoError(t, err)
+	dc2 := types.NewDataCommitmentConfirm(hex.EncodeToString(signature2), ethAddr2)
+	err = network.DHTs[0].PutDataCommitmentConfirm(
+		ctx,
+		p2p.GetDataCommitmentConfirmKey(nonce, ethAddr2.String(), dataRootHash.Hex()),
+		*dc2,
+	)
+	require.NoError(t, err)
+
+	querier := p2p.NewQuerier(network.DHTs[0], tmlog.NewNopLogger())
+	querier.SignatureStore = sigStore
+
+	// the store confirm is not enough, so the missing ones are queried from the DHT.
+	confirms, err := querier.QueryTwoThirdsDataCommitmentConfirms(ctx, 20*time.Second, time.Millisecond, previousValset, nonce, dataRootHash.Hex())
+	require.NoError(t, err)
+	assert.Len(t, confirms, 2)
+	assert.Contains(t, confirms, *dc1)
+	assert.Contains(t, confirms, *dc2)
+
+	// without the signature store, the DHT confirm is not enough.
+	_, err = querier.WithoutSignatureStore().QueryTwoThirdsDataCommitmentConfirms(ctx, time.Second, time.Millisecond, previousValset, nonce, dataRootHash.Hex())
+	require.Error(t, err)
+
+	// once the store contains enough confirms, the DHT is not queried.
+	encodedDc2, err := types.MarshalDataCommitmentConfirm(*dc2)
+	require.NoError(t, err)
+	require.NoError(t, sigStore.Put(ctx, datastore.NewKey(p2p.GetDataCommitmentConfirmKey(nonce, ethAddr2.String(), dataRootHash.Hex())), encodedDc2))
+	unavailableDHTQuerier := p2p.NewQuerier(nil, tmlog.NewNopLogger())
+	unavailableDHTQuerier.SignatureStore = sigStore
+	confirms, err = unavailableDHTQuerier.QueryTwoThirdsDataCommitmentConfirms(ctx, time.Second, time.Millisecond, previousValset, nonce, dataRootHash.Hex())
+	require.NoError(t, err)
+	assert.Len(t, confirms, 2)
+	assert.Contains(t, confirms, *dc1)
+	assert.Contains(t, confirms, *dc2)
+}
+
+func TestQueryTwoThirdsConfirmsFromAggregatedConfirms(t *testing.T) {
+	ctx := context.Background()
+	network := blobstreamtesting.NewDHTNetwork(ctx, 3)
+	defer network.Stop()
+
+	previousValset := celestiatypes.Valset{
+		Nonce: 2,
+		Members: []celestiatypes.BridgeValidator{
+			{Power: 10, EvmAddress: ethAddr1.String()},
+			{Power: 15, EvmAddress: ethAddr2.String()},
+			{Power: 10, EvmAddress: ethAddr3.String()},
+		},
+		Height: 10,
+	}
+	dcNonce := uint64(4)
+	bCommitment, _ := hex.DecodeString("1234")
+	dataRootHash := types.DataCommitmentTupleRootSignBytes(big.NewInt(int64(dcNonce)), bCommitment)
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc1, err := ks.ImportECDSA(privateKey1, "123")
+	require.NoError(t, err)
+	err = ks.Unlock(acc1, "123")
+	require.NoError(t, err)
+	acc2, err := ks.ImportECDSA(privateKey2, "123")
+	require.NoError(t, err)
+	err = ks.Unlock(acc2, "123")
+	require.NoError(t, err)
+	signature1, err := evm.NewEthereumSignature(dataRootHash.Bytes(), ks, acc1)
+	require.NoError(t, err)
+	signature2, err := evm.NewEthereumSignature(dataRootHash.Bytes(), ks, acc2)
+	require.NoError(t, err)
+
+	// the signatures are only aggregated, by different peers, without putting the single confirms
+	err = network.DHTs[0].AggregateConfirm(ctx, dcNonce, dataRootHash.Hex(), ethAddr1.Hex(), hex.EncodeToString(signature1))
+	require.NoError(t, err)
+	err = network.DHTs[1].AggregateConfirm(ctx, dcNonce, dataRootHash.Hex(), ethAddr2.Hex(), hex.EncodeToString(signature2))
+	require.NoError(t, err)
+
+	ac, err := network.DHTs[2].GetAggregatedConfirms(ctx, p2p.GetAggregatedConfirmsKey(dcNonce, dataRootHash.Hex()))
+	require.NoError(t, err)
+	assert.Len(t, ac.Signatures, 2)
+
+	querier := p2p.NewQuerier(network.DHTs[2], tmlog.NewNopLogger())
+	confirms, err := querier.QueryTwoThirdsDataCommitmentConfirms(
+		ctx,
+		time.Second,
+		time.Millisecond,
+		previousValset,
+		dcNonce,
+		dataRootHash.Hex(),
+	)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []types.DataCommitmentConfirm{
+		{Signature: hex.EncodeToString(signature1), EthAddress: ethAddr1.String()},
+		{Signature: hex.EncodeToString(signature2), EthAddress: ethAddr2.String()},
+	}, confirms)
+}
+
+func TestQueryRelayAnnouncementsWithUnavailableDHT(t *testing.T) {
+	ctx := context.Background()
+	// a DHT without peers fails the lookups
+	_, _, dht := blobstreamtesting.NewTestDHT(ctx, nil)
+	defer dht.Close()
+	querier := p2p.NewQuerier(dht, tmlog.NewNopLogger())
+
+	announcements, err := querier.QueryRelayAnnouncements(ctx, 10, 5, []string{ethAddr1.Hex(), ethAddr2.Hex()})
+	require.NoError(t, err)
+	assert.Empty(t, announcements)
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = querier.QueryRelayAnnouncements(cancelledCtx, 10, 5, []string{ethAddr1.Hex()})
+	assert.Error(t, err)
+}
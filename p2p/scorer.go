@@ -0,0 +1,324 @@
+package p2p
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	// DefaultBanThreshold the default score under which a peer is banned.
+	DefaultBanThreshold = -100
+	// DefaultBanDuration the default time a peer stays banned.
+	DefaultBanDuration = time.Hour
+
+	// invalidRecordPenalty the score removed from a peer for every invalid record it returns.
+	invalidRecordPenalty = 25
+	// validRecordReward the score added to a peer for every valid record it returns.
+	validRecordReward = 1
+	// maxPeerScore the maximum score of a peer, so that a peer can't build up a score allowing it
+	// to serve a large number of invalid records before being banned.
+	maxPeerScore = 100
+	// peerScoreTag the tag used to report the peers scores to the connection manager, so that the
+	// connections to the peers with the lowest scores are trimmed first.
+	peerScoreTag = "blobstream-score"
+	// scoresPruneInterval the time between two prunings of the expired bans and of the decayed scores.
+	scoresPruneInterval = 10 * time.Minute
+	// maxDisconnectedPeers the maximum number of disconnected peers whose score is kept. Once reached,
+	// the score of the least recently disconnected peer is dropped.
+	maxDisconnectedPeers = 1000
+	// scoreDecayInterval the time after which a disconnected peer recovers from one invalid record, so
+	// that a peer can't reset its score by reconnecting, but still recovers from occasional invalid records.
+	scoreDecayInterval = time.Hour
+)
+
+// ScoringConfig the configuration of the P2P peer scoring.
+type ScoringConfig struct {
+	// Enable scores the peers depending on the validity of the DHT records they return, and bans
+	// the ones returning too many invalid records.
+	Enable bool `mapstructure:"enable" json:"enable"`
+}
+
+// PeerScore the score of a peer, as returned by the scorer.
+type PeerScore struct {
+	PeerID         string     `json:"peer_id"`
+	Score          int        `json:"score"`
+	InvalidRecords uint64     `json:"invalid_records"`
+	BannedUntil    *time.Time `json:"banned_until,omitempty"`
+}
+
+type peerScore struct {
+	score          int
+	invalidRecords uint64
+	bannedUntil    time.Time
+	// disconnectedAt the time the peer disconnected. Zero if the peer is connected.
+	disconnectedAt time.Time
+}
+
+// decayedScore returns the score of the peer, recovered by one invalid record penalty for every
+// scoreDecayInterval elapsed since it disconnected, up to 0.
+func (ps *peerScore) decayedScore(now time.Time) int {
+	if ps.score >= 0 || ps.disconnectedAt.IsZero() {
+		return ps.score
+	}
+	score := ps.score + int(now.Sub(ps.disconnectedAt)/scoreDecayInterval)*invalidRecordPenalty
+	if score > 0 {
+		return 0
+	}
+	return score
+}
+
+var _ connmgr.ConnectionGater = &PeerScorer{}
+
+// PeerScorer scores the peers depending on the validity of the DHT records they return.
+// The peers whose score drops below the ban threshold are banned for the ban duration:
+// their connections are rejected, and their score is reset once the ban expires.
+// Once the scorer is started, the scores of the disconnected peers are moved to a bounded cache, where
+// they decay over time, so that reconnecting doesn't reset the score of a peer.
+type PeerScorer struct {
+	logger       tmlog.Logger
+	banThreshold int
+	banDuration  time.Duration
+
+	// Meters if not nil, the invalid records and bans are recorded to them.
+	Meters *telemetry.PeerMeters
+
+	mu sync.Mutex
+	// peers the scores of the connected peers, and of the banned ones.
+	peers map[peer.ID]*peerScore
+	// disconnected the scores of the disconnected peers, restored when they return records again.
+	disconnected *lru.Cache[peer.ID, *peerScore]
+}
+
+// NewPeerScorer creates a new peer scorer.
+func NewPeerScorer(logger tmlog.Logger, banThreshold int, banDuration time.Duration) *PeerScorer {
+	// the size is a positive constant, so creating the cache can't fail.
+	disconnected, _ := lru.New[peer.ID, *peerScore](maxDisconnectedPeers)
+	return &PeerScorer{
+		logger:       logger,
+		banThreshold: banThreshold,
+		banDuration:  banDuration,
+		peers:        make(map[peer.ID]*peerScore),
+		disconnected: disconnected,
+	}
+}
+
+// RecordValidRecord increases the score of the peer that returned a valid record.
+// Returns the new score of the peer.
+func (s *PeerScorer) RecordValidRecord(id peer.ID) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ps := s.get(id)
+	if ps.score < maxPeerScore {
+		ps.score += validRecordReward
+	}
+	return ps.score
+}
+
+// RecordInvalidRecord decreases the score of the peer that returned an invalid record, and bans
+// it if its score drops below the ban threshold.
+// Returns the new score of the peer, and true if it just got banned.
+func (s *PeerScorer) RecordInvalidRecord(id peer.ID) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ps := s.get(id)
+	ps.score -= invalidRecordPenalty
+	ps.invalidRecords++
+	if s.Meters != nil {
+		s.Meters.InvalidRecords.Add(context.Background(), 1)
+	}
+	if ps.score > s.banThreshold || s.isBanned(ps) {
+		return ps.score, false
+	}
+	ps.bannedUntil = time.Now().Add(s.banDuration)
+	if s.Meters != nil {
+		s.Meters.BannedPeers.Add(context.Background(), 1)
+	}
+	s.logger.Info("banned peer returning invalid records", "peer", id.String(), "score", ps.score, "until", ps.bannedUntil)
+	return ps.score, true
+}
+
+// Score returns the current score of the peer.
+func (s *PeerScorer) Score(id peer.ID) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ps, ok := s.peers[id]; ok {
+		return ps.score
+	}
+	if ps, ok := s.disconnected.Peek(id); ok {
+		return ps.decayedScore(time.Now())
+	}
+	return 0
+}
+
+// IsBanned returns true if the peer is currently banned.
+// If the peer ban expired, its score is reset.
+func (s *PeerScorer) IsBanned(id peer.ID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ps, ok := s.peers[id]
+	if !ok {
+		return false
+	}
+	if s.isBanned(ps) {
+		return true
+	}
+	if !ps.bannedUntil.IsZero() {
+		ps.bannedUntil = time.Time{}
+		ps.score = 0
+	}
+	return false
+}
+
+// Scores returns the scores of the peers that returned records, including the disconnected ones,
+// ordered from the lowest to the highest.
+func (s *PeerScorer) Scores() []PeerScore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	scores := make([]PeerScore, 0, len(s.peers)+s.disconnected.Len())
+	for id, ps := range s.peers {
+		score := PeerScore{
+			PeerID:         id.String(),
+			Score:          ps.score,
+			InvalidRecords: ps.invalidRecords,
+		}
+		if s.isBanned(ps) {
+			bannedUntil := ps.bannedUntil
+			score.BannedUntil = &bannedUntil
+		}
+		scores = append(scores, score)
+	}
+	for _, id := range s.disconnected.Keys() {
+		ps, ok := s.disconnected.Peek(id)
+		if !ok {
+			continue
+		}
+		scores = append(scores, PeerScore{
+			PeerID:         id.String(),
+			Score:          ps.decayedScore(now),
+			InvalidRecords: ps.invalidRecords,
+		})
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score < scores[j].Score
+		}
+		return scores[i].PeerID < scores[j].PeerID
+	})
+	return scores
+}
+
+// get returns the score of the peer, restoring it from the disconnected peers if needed.
+func (s *PeerScorer) get(id peer.ID) *peerScore {
+	if ps, ok := s.peers[id]; ok {
+		return ps
+	}
+	ps, ok := s.disconnected.Peek(id)
+	if ok {
+		s.disconnected.Remove(id)
+		ps.score = ps.decayedScore(time.Now())
+		ps.disconnectedAt = time.Time{}
+	} else {
+		ps = &peerScore{}
+	}
+	s.peers[id] = ps
+	return ps
+}
+
+func (s *PeerScorer) isBanned(ps *peerScore) bool {
+	return !ps.bannedUntil.IsZero() && time.Now().Before(ps.bannedUntil)
+}
+
+// RecordDisconnected moves the score of the provided peer to the disconnected peers, where it decays
+// over time, unless it's banned. Should be called once the peer is disconnected.
+func (s *PeerScorer) RecordDisconnected(id peer.ID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ps, ok := s.peers[id]
+	if !ok || s.isBanned(ps) {
+		return
+	}
+	delete(s.peers, id)
+	ps.disconnectedAt = time.Now()
+	s.disconnected.Add(id, ps)
+}
+
+// Prune removes the peers whose ban expired, and the disconnected peers whose score fully decayed.
+// As the banned peers are disconnected, their score doesn't need to be kept once their ban expires.
+func (s *PeerScorer) Prune() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, ps := range s.peers {
+		if !ps.bannedUntil.IsZero() && !s.isBanned(ps) {
+			delete(s.peers, id)
+		}
+	}
+	now := time.Now()
+	for _, id := range s.disconnected.Keys() {
+		if ps, ok := s.disconnected.Peek(id); ok && ps.decayedScore(now) == 0 {
+			s.disconnected.Remove(id)
+		}
+	}
+}
+
+// Start moves the scores of the peers disconnecting from the provided host to the disconnected peers,
+// and periodically prunes the expired bans and the decayed scores, until the context is canceled.
+func (s *PeerScorer) Start(ctx context.Context, h host.Host) {
+	notifiee := &network.NotifyBundle{
+		DisconnectedF: func(n network.Network, conn network.Conn) {
+			if n.Connectedness(conn.RemotePeer()) != network.Connected {
+				s.RecordDisconnected(conn.RemotePeer())
+			}
+		},
+	}
+	h.Network().Notify(notifiee)
+	defer h.Network().StopNotify(notifiee)
+
+	ticker := time.NewTicker(scoresPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Prune()
+		}
+	}
+}
+
+// InterceptPeerDial rejects dialing the banned peers.
+func (s *PeerScorer) InterceptPeerDial(id peer.ID) bool {
+	return !s.IsBanned(id)
+}
+
+// InterceptAddrDial accepts all the addresses, as the peer is already checked when dialed.
+func (s *PeerScorer) InterceptAddrDial(_ peer.ID, _ ma.Multiaddr) bool {
+	return true
+}
+
+// InterceptAccept accepts all the inbound connections, as their peer is only known once secured.
+func (s *PeerScorer) InterceptAccept(_ network.ConnMultiaddrs) bool {
+	return true
+}
+
+// InterceptSecured rejects the connections of the banned peers.
+func (s *PeerScorer) InterceptSecured(_ network.Direction, id peer.ID, _ network.ConnMultiaddrs) bool {
+	return !s.IsBanned(id)
+}
+
+// InterceptUpgraded accepts all the upgraded connections, as they were already checked once secured.
+func (s *PeerScorer) InterceptUpgraded(_ network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
@@ -0,0 +1,258 @@
+package p2p_test
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	blobstreamtesting "github.com/celestiaorg/orchestrator-relayer/testing"
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	dhtpb "github.com/libp2p/go-libp2p-kad-dht/pb"
+	recpb "github.com/libp2p/go-libp2p-record/pb"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/routing"
+	"github.com/libp2p/go-msgio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+func TestPeerScorer(t *testing.T) {
+	_, honest := newTestPeerKey(t)
+	_, abusive := newTestPeerKey(t)
+
+	banDuration := 50 * time.Millisecond
+	scorer := p2p.NewPeerScorer(tmlog.NewNopLogger(), -50, banDuration)
+
+	assert.Equal(t, 1, scorer.RecordValidRecord(honest))
+	assert.Equal(t, 1, scorer.Score(honest))
+	assert.False(t, scorer.IsBanned(honest))
+
+	// the peer is banned once its score drops below the threshold
+	score, banned := scorer.RecordInvalidRecord(abusive)
+	assert.Equal(t, -25, score)
+	assert.False(t, banned)
+	score, banned = scorer.RecordInvalidRecord(abusive)
+	assert.Equal(t, -50, score)
+	assert.True(t, banned)
+	assert.True(t, scorer.IsBanned(abusive))
+	assert.False(t, scorer.InterceptPeerDial(abusive))
+	assert.True(t, scorer.InterceptPeerDial(honest))
+
+	// a banned peer is not banned again
+	_, banned = scorer.RecordInvalidRecord(abusive)
+	assert.False(t, banned)
+
+	scores := scorer.Scores()
+	require.Len(t, scores, 2)
+	assert.Equal(t, abusive.String(), scores[0].PeerID)
+	assert.Equal(t, uint64(3), scores[0].InvalidRecords)
+	assert.NotNil(t, scores[0].BannedUntil)
+	assert.Equal(t, honest.String(), scores[1].PeerID)
+	assert.Nil(t, scores[1].BannedUntil)
+
+	// the score is reset once the ban expires
+	time.Sleep(2 * banDuration)
+	assert.False(t, scorer.IsBanned(abusive))
+	assert.Equal(t, 0, scorer.Score(abusive))
+	assert.True(t, scorer.InterceptPeerDial(abusive))
+}
+
+func TestPeerScorerDisconnected(t *testing.T) {
+	_, honest := newTestPeerKey(t)
+	_, abusive := newTestPeerKey(t)
+
+	banDuration := 50 * time.Millisecond
+	scorer := p2p.NewPeerScorer(tmlog.NewNopLogger(), -25, banDuration)
+	scorer.RecordValidRecord(honest)
+	_, banned := scorer.RecordInvalidRecord(abusive)
+	require.True(t, banned)
+
+	// the scores of the disconnected peers are kept
+	scorer.RecordDisconnected(honest)
+	scorer.RecordDisconnected(abusive)
+	assert.Len(t, scorer.Scores(), 2)
+	assert.Equal(t, 1, scorer.Score(honest))
+
+	// the banned peers are pruned once their ban expires
+	scorer.Prune()
+	assert.Len(t, scorer.Scores(), 2)
+	time.Sleep(2 * banDuration)
+	scorer.Prune()
+	scores := scorer.Scores()
+	require.Len(t, scores, 1)
+	assert.Equal(t, honest.String(), scores[0].PeerID)
+}
+
+func TestPeerScorerReconnect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dhtNetwork := blobstreamtesting.NewDHTNetwork(ctx, 2)
+	defer dhtNetwork.Stop()
+	h, abusive := dhtNetwork.Hosts[0], dhtNetwork.Hosts[1]
+
+	scorer := p2p.NewPeerScorer(tmlog.NewNopLogger(), p2p.DefaultBanThreshold, time.Hour)
+	go scorer.Start(ctx, h)
+
+	// the abusive peer reconnects after every invalid record, without resetting its score
+	for i := 1; i < 4; i++ {
+		score, banned := scorer.RecordInvalidRecord(abusive.ID())
+		require.False(t, banned)
+		require.NoError(t, h.Network().ClosePeer(abusive.ID()))
+		require.Eventually(t, func() bool {
+			return h.Network().Connectedness(abusive.ID()) != network.Connected
+		}, time.Second, 10*time.Millisecond)
+		// giving time for the disconnection to be notified
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, score, scorer.Score(abusive.ID()))
+		require.NoError(t, h.Connect(ctx, peer.AddrInfo{ID: abusive.ID(), Addrs: abusive.Addrs()}))
+	}
+
+	score, banned := scorer.RecordInvalidRecord(abusive.ID())
+	assert.Equal(t, p2p.DefaultBanThreshold, score)
+	assert.True(t, banned)
+}
+
+func TestGetValueScoresPeers(t *testing.T) {
+	dhtNetwork := blobstreamtesting.NewDHTNetwork(context.Background(), 3)
+	defer dhtNetwork.Stop()
+	ctx := context.Background()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.ImportECDSA(privateKey, "123")
+	require.NoError(t, err)
+	err = ks.Unlock(acc, "123")
+	require.NoError(t, err)
+
+	nonce := uint64(10)
+	bCommitment, _ := hex.DecodeString("1234")
+	dataRootHash := types.DataCommitmentTupleRootSignBytes(big.NewInt(int64(nonce)), bCommitment)
+	signature, err := evm.NewEthereumSignature(dataRootHash.Bytes(), ks, acc)
+	require.NoError(t, err)
+	testKey := p2p.GetDataCommitmentConfirmKey(nonce, evmAddress, dataRootHash.Hex())
+
+	err = dhtNetwork.DHTs[1].PutDataCommitmentConfirm(ctx, testKey, types.DataCommitmentConfirm{
+		EthAddress: evmAddress,
+		Signature:  hex.EncodeToString(signature),
+	})
+	require.NoError(t, err)
+
+	// the last peer is replaced with a malicious one, answering all the DHT requests with an invalid confirm.
+	invalidConfirm, err := types.MarshalDataCommitmentConfirm(types.DataCommitmentConfirm{
+		EthAddress: evmAddress,
+		Signature:  "1234",
+	})
+	require.NoError(t, err)
+	serveRecord(dhtNetwork.Hosts[2], testKey, invalidConfirm, 0)
+
+	scorer := p2p.NewPeerScorer(tmlog.NewNopLogger(), -25, time.Hour)
+	dhtNetwork.DHTs[0].Scorer = scorer
+
+	confirm, err := dhtNetwork.DHTs[0].GetDataCommitmentConfirm(ctx, testKey)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(signature), confirm.Signature)
+
+	assert.Equal(t, 1, scorer.Score(dhtNetwork.Hosts[1].ID()))
+	assert.Equal(t, -25, scorer.Score(dhtNetwork.Hosts[2].ID()))
+	assert.True(t, scorer.IsBanned(dhtNetwork.Hosts[2].ID()))
+}
+
+func TestGetValueWaitsForSlowLatestValset(t *testing.T) {
+	dhtNetwork := blobstreamtesting.NewDHTNetwork(context.Background(), 4)
+	defer dhtNetwork.Stop()
+	ctx := context.Background()
+
+	staleValset := celestiatypes.Valset{
+		Nonce:   10,
+		Time:    time.UnixMicro(10),
+		Height:  5,
+		Members: []celestiatypes.BridgeValidator{{Power: 100, EvmAddress: evmAddress}},
+	}
+	freshValset := staleValset
+	freshValset.Nonce = 11
+
+	// the stale valset is held by the local node and the fast peers.
+	err := dhtNetwork.DHTs[0].PutLatestValset(ctx, *types.ToLatestValset(staleValset))
+	require.NoError(t, err)
+
+	// the last peer is replaced with a slow one, answering the DHT requests with the fresh valset.
+	freshRecord, err := types.MarshalLatestValset(*types.ToLatestValset(freshValset))
+	require.NoError(t, err)
+	serveRecord(dhtNetwork.Hosts[3], p2p.GetLatestValsetKey(), freshRecord, 500*time.Millisecond)
+
+	dhtNetwork.DHTs[0].Scorer = p2p.NewPeerScorer(tmlog.NewNopLogger(), p2p.DefaultBanThreshold, time.Hour)
+
+	latestValset, err := dhtNetwork.DHTs[0].GetLatestValset(ctx)
+	require.NoError(t, err)
+	assert.True(t, types.IsValsetEqualToLatestValset(freshValset, latestValset))
+}
+
+func TestGetValueDoesNotPenalizeStatefulRejections(t *testing.T) {
+	dhtNetwork := blobstreamtesting.NewDHTNetwork(context.Background(), 3)
+	defer dhtNetwork.Stop()
+	ctx := context.Background()
+
+	nonce := uint64(10)
+	digest := "0x1234"
+	testKey := p2p.GetAggregatedConfirmsKey(nonce, digest)
+
+	// the first peer returns a record holding more signatures than the valset members known locally,
+	// which might be valid for a peer with a more recent view of the valset.
+	tooManySignatures := types.NewAggregatedConfirms(nonce, digest)
+	tooManySignatures.Add(evmAddress, "1234")
+	tooManySignatures.Add("0x7E0a1C3E6D1Bc0A2E2bF7b1bD1a0a3D5d4c1F2e3", "5678")
+	encodedTooManySignatures, err := types.MarshalAggregatedConfirms(*tooManySignatures)
+	require.NoError(t, err)
+	serveRecord(dhtNetwork.Hosts[1], testKey, encodedTooManySignatures, 0)
+
+	// the second peer returns a record with an invalid signature, whatever the local state.
+	invalidSignature := types.NewAggregatedConfirms(nonce, digest)
+	invalidSignature.Add(evmAddress, "1234")
+	encodedInvalidSignature, err := types.MarshalAggregatedConfirms(*invalidSignature)
+	require.NoError(t, err)
+	serveRecord(dhtNetwork.Hosts[2], testKey, encodedInvalidSignature, 0)
+
+	scorer := p2p.NewPeerScorer(tmlog.NewNopLogger(), p2p.DefaultBanThreshold, time.Hour)
+	dhtNetwork.DHTs[0].Scorer = scorer
+	dhtNetwork.DHTs[0].SetValsetMembers([]celestiatypes.BridgeValidator{{Power: 100, EvmAddress: evmAddress}})
+
+	_, err = dhtNetwork.DHTs[0].GetValues(ctx, testKey)
+	assert.ErrorIs(t, err, routing.ErrNotFound)
+
+	assert.Equal(t, 0, scorer.Score(dhtNetwork.Hosts[1].ID()))
+	assert.Equal(t, -25, scorer.Score(dhtNetwork.Hosts[2].ID()))
+}
+
+// serveRecord replaces the DHT protocol handler of the provided host with one answering all the requests
+// with the provided record, after the delay for the GET_VALUE requests.
+func serveRecord(h host.Host, key string, value []byte, delay time.Duration) {
+	h.SetStreamHandler(p2p.ProtocolPrefix+"/kad/1.0.0", func(stream network.Stream) {
+		defer stream.Close()
+		encodedRequest, err := msgio.NewVarintReader(stream).ReadMsg()
+		if err != nil {
+			return
+		}
+		request := new(dhtpb.Message)
+		if err := request.Unmarshal(encodedRequest); err != nil {
+			return
+		}
+		if request.GetType() == dhtpb.Message_GET_VALUE {
+			time.Sleep(delay)
+		}
+		response := dhtpb.NewMessage(request.GetType(), request.GetKey(), 0)
+		response.Record = &recpb.Record{Key: []byte(key), Value: value}
+		encodedResponse, err := response.Marshal()
+		if err != nil {
+			return
+		}
+		_ = msgio.NewVarintWriter(stream).WriteMsg(encodedResponse)
+	})
+}
@@ -1,9 +1,13 @@
 package p2p
 
 import (
+	"bytes"
 	"encoding/hex"
+	"strconv"
 	"strings"
+	"sync"
 
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
 	"github.com/celestiaorg/orchestrator-relayer/evm"
 	"github.com/celestiaorg/orchestrator-relayer/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -205,3 +209,275 @@ func (dcv DataCommitmentConfirmValidator) Select(key string, values [][]byte) (i
 	}
 	return 0, ErrNoValidValueFound
 }
+
+// RelayAnnouncementValidator runs stateless checks on relay announcements when submitting them to the DHT.
+type RelayAnnouncementValidator struct{}
+
+// Validate runs stateless checks on the provided announcement key and value.
+func (rav RelayAnnouncementValidator) Validate(key string, value []byte) error {
+	namespace, nonce, relayerAddr, evmChainIDHex, err := ParseKey(key)
+	if err != nil {
+		return err
+	}
+
+	// check if namespace is of relay announcements
+	if namespace != RelayAnnouncementNamespace {
+		return ErrInvalidConfirmNamespace
+	}
+
+	// check if the evm address is a valid eth address
+	if !common.IsHexAddress(relayerAddr) {
+		return ErrInvalidEVMAddress
+	}
+
+	evmChainID, err := strconv.ParseUint(evmChainIDHex, 16, 64)
+	if err != nil {
+		return ErrInvalidEVMChainID
+	}
+
+	ra, err := types.UnmarshalRelayAnnouncement(value)
+	if err != nil {
+		return err
+	}
+
+	// check if the relayer address in the key is the same as the one in the announcement
+	if !strings.EqualFold(ra.RelayerAddress, relayerAddr) {
+		return ErrNotTheSameEVMAddress
+	}
+
+	// check if the announcement is for the nonce and chain referenced in the key
+	if ra.Nonce != nonce || ra.EVMChainID != evmChainID {
+		return ErrAnnouncementKeyMismatch
+	}
+
+	// strip the 0x from the signature, if exists, to create its corresponding byte slice
+	signature := ra.Signature
+	// we want to make sure that len(signature) > 2 to avoid slice bounds out of range
+	// however, we don't care at this level if the signature is invalid as it will be checked below.
+	if len(signature) > 2 && signature[:2] == "0x" {
+		signature = signature[2:]
+	}
+	bSignature, err := hex.DecodeString(signature)
+	if err != nil {
+		return err
+	}
+
+	// check that the provided signature was created by the relayer
+	signBytes := types.RelayAnnouncementSignBytes(ra.Nonce, ra.EVMChainID, common.HexToHash(ra.TxHash), ra.Timestamp)
+	err = evm.ValidateEthereumSignature(signBytes.Bytes(), bSignature, common.HexToAddress(relayerAddr))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Select selects a valid dht relay announcement from multiple ones.
+// returns the most recent valid one, i.e. the one referencing the latest version of the transaction.
+// returns an error of no valid value is found.
+func (rav RelayAnnouncementValidator) Select(key string, values [][]byte) (int, error) {
+	if len(values) == 0 {
+		return 0, ErrNoValues
+	}
+	latestIndex := -1
+	latestTimestamp := int64(0)
+	for index, value := range values {
+		if err := rav.Validate(key, value); err != nil {
+			continue
+		}
+		// the validation above makes sure the value can be unmarshalled
+		ra, _ := types.UnmarshalRelayAnnouncement(value)
+		if latestIndex == -1 || ra.Timestamp > latestTimestamp {
+			latestIndex = index
+			latestTimestamp = ra.Timestamp
+		}
+	}
+	if latestIndex == -1 {
+		return 0, ErrNoValidValueFound
+	}
+	return latestIndex, nil
+}
+
+// MaxAggregatedSignatures the maximum number of signatures an aggregated confirms record can hold
+// when the valset members are not known.
+const MaxAggregatedSignatures = 300
+
+// AggregatedConfirmsValidator runs stateless checks on the aggregated confirms records when submitting
+// them to the DHT.
+// The valset members, if set, bound the number of signatures a record can hold, and their voting powers
+// are used to select the best record from multiple valid ones. The signatures of the other addresses
+// don't count towards the selection.
+type AggregatedConfirmsValidator struct {
+	mu sync.RWMutex
+	// powers the voting powers of the valset members referenced by their lower case EVM address.
+	powers map[string]uint64
+}
+
+// NewAggregatedConfirmsValidator creates a new aggregated confirms validator.
+func NewAggregatedConfirmsValidator() *AggregatedConfirmsValidator {
+	return &AggregatedConfirmsValidator{powers: make(map[string]uint64)}
+}
+
+// SetValsetMembers sets the valset members whose voting powers are used to select between multiple
+// aggregated confirms records.
+func (acv *AggregatedConfirmsValidator) SetValsetMembers(members []celestiatypes.BridgeValidator) {
+	powers := make(map[string]uint64, len(members))
+	for _, member := range members {
+		powers[strings.ToLower(member.EvmAddress)] = member.Power
+	}
+	acv.mu.Lock()
+	defer acv.mu.Unlock()
+	acv.powers = powers
+}
+
+// VotingPower returns the voting power of the valset members that signed the aggregated confirms,
+// along with the number of their signatures.
+// If the valset members are not set, every signature counts for one.
+func (acv *AggregatedConfirmsValidator) VotingPower(ac types.AggregatedConfirms) (uint64, int) {
+	acv.mu.RLock()
+	defer acv.mu.RUnlock()
+	if len(acv.powers) == 0 {
+		return uint64(len(ac.Signatures)), len(ac.Signatures)
+	}
+	power := uint64(0)
+	count := 0
+	for _, signature := range ac.Signatures {
+		if memberPower, ok := acv.powers[strings.ToLower(signature.EthAddress)]; ok {
+			power += memberPower
+			count++
+		}
+	}
+	return power, count
+}
+
+// FilterMembers returns a copy of the aggregated confirms holding only the signatures of the valset members.
+// If the valset members are not set, all the signatures are kept.
+func (acv *AggregatedConfirmsValidator) FilterMembers(ac types.AggregatedConfirms) types.AggregatedConfirms {
+	acv.mu.RLock()
+	defer acv.mu.RUnlock()
+	filtered := types.NewAggregatedConfirms(ac.Nonce, ac.Digest)
+	for _, signature := range ac.Signatures {
+		if _, ok := acv.powers[strings.ToLower(signature.EthAddress)]; ok || len(acv.powers) == 0 {
+			filtered.Add(signature.EthAddress, signature.Signature)
+		}
+	}
+	return *filtered
+}
+
+// maxSignatures returns the maximum number of signatures a record can hold, i.e. the number of
+// valset members if they're set, or MaxAggregatedSignatures otherwise.
+func (acv *AggregatedConfirmsValidator) maxSignatures() int {
+	acv.mu.RLock()
+	defer acv.mu.RUnlock()
+	if len(acv.powers) == 0 {
+		return MaxAggregatedSignatures
+	}
+	return len(acv.powers)
+}
+
+// Validate runs stateless checks on the provided aggregated confirms key and value.
+// All the signatures held in the record are checked against the digest referenced in the key.
+func (acv *AggregatedConfirmsValidator) Validate(key string, value []byte) error {
+	nonce, digest, err := ParseAggregatedConfirmsKey(key)
+	if err != nil {
+		return err
+	}
+
+	ac, err := types.UnmarshalAggregatedConfirms(value)
+	if err != nil {
+		return err
+	}
+
+	// strip the 0x from the digests, if exists, to compare their corresponding byte slices.
+	if len(digest) > 2 && digest[:2] == "0x" {
+		digest = digest[2:]
+	}
+	bDigest, err := hex.DecodeString(digest)
+	if err != nil {
+		return err
+	}
+	recordDigest := ac.Digest
+	if len(recordDigest) > 2 && recordDigest[:2] == "0x" {
+		recordDigest = recordDigest[2:]
+	}
+	bRecordDigest, err := hex.DecodeString(recordDigest)
+	if err != nil {
+		return err
+	}
+
+	// check if the record is for the nonce and digest referenced in the key
+	if ac.Nonce != nonce || !bytes.Equal(bDigest, bRecordDigest) {
+		return ErrAggregatedConfirmsKeyMismatch
+	}
+
+	if len(ac.Signatures) == 0 {
+		return ErrEmptyAggregatedConfirms
+	}
+	if len(ac.Signatures) > acv.maxSignatures() {
+		return ErrTooManyAggregatedSignatures
+	}
+
+	signers := make(map[string]struct{}, len(ac.Signatures))
+	for _, aggregatedSignature := range ac.Signatures {
+		// check if the evm address is a valid eth address
+		if !common.IsHexAddress(aggregatedSignature.EthAddress) {
+			return ErrInvalidEVMAddress
+		}
+		signer := strings.ToLower(aggregatedSignature.EthAddress)
+		if _, has := signers[signer]; has {
+			return ErrDuplicateSignature
+		}
+		signers[signer] = struct{}{}
+
+		// strip the 0x from the signature, if exists, to create its corresponding byte slice
+		signature := aggregatedSignature.Signature
+		if len(signature) > 2 && signature[:2] == "0x" {
+			signature = signature[2:]
+		}
+		bSignature, err := hex.DecodeString(signature)
+		if err != nil {
+			return err
+		}
+
+		// check that the provided signature was created by the provided evm address
+		err = evm.ValidateEthereumSignature(bDigest, bSignature, common.HexToAddress(aggregatedSignature.EthAddress))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Select selects a valid dht aggregated confirms value from multiple ones.
+// returns the one holding the signatures with the most voting power, then the most signatures. If the valset
+// members are set, only their signatures are counted, and the values without any of them are never selected.
+// returns an error of no valid value is found.
+func (acv *AggregatedConfirmsValidator) Select(key string, values [][]byte) (int, error) {
+	if len(values) == 0 {
+		return 0, ErrNoValues
+	}
+	bestIndex := -1
+	bestPower := uint64(0)
+	bestCount := 0
+	for index, value := range values {
+		if err := acv.Validate(key, value); err != nil {
+			continue
+		}
+		// the validation above makes sure the value can be unmarshalled
+		ac, _ := types.UnmarshalAggregatedConfirms(value)
+		power, count := acv.VotingPower(ac)
+		if count == 0 {
+			continue
+		}
+		if bestIndex == -1 || power > bestPower || (power == bestPower && count > bestCount) {
+			bestIndex = index
+			bestPower = power
+			bestCount = count
+		}
+	}
+	if bestIndex == -1 {
+		return 0, ErrNoValidValueFound
+	}
+	return bestIndex, nil
+}
@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"testing"
 
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
 	"github.com/celestiaorg/orchestrator-relayer/evm"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 
@@ -48,6 +49,20 @@ func TestValsetConfirmValidate(t *testing.T) {
 			}(),
 			wantErr: false,
 		},
+		{
+			name: "valid binary valset confirm",
+			key:  "/vc/b:" + evmAddress + ":" + signBytes.Hex(),
+			value: func() []byte {
+				signature, err := evm.NewEthereumSignature(signBytes.Bytes(), ks, acc)
+				require.NoError(t, err)
+				vsc, _ := types.EncodeValsetConfirm(*types.NewValsetConfirm(
+					common.HexToAddress(evmAddress),
+					hex.EncodeToString(signature),
+				), types.RecordFormatBinaryV1)
+				return vsc
+			}(),
+			wantErr: false,
+		},
 		{
 			name:    "invalid key format",
 			key:     "/vc/b/0xfA906e15C9Eaf338c4110f0E21983c6b3b2d622b:0x1234000000000000000000000000000000000000000000000000000000001234",
@@ -267,6 +282,18 @@ func TestDataCommitmentConfirmValidate(t *testing.T) {
 			}(),
 			wantErr: false,
 		},
+		{
+			name: "valid binary data commitment confirm",
+			key:  "/dcc/a:" + evmAddress + ":" + dataRootHash.Hex(),
+			value: func() []byte {
+				vsc, _ := types.EncodeDataCommitmentConfirm(*types.NewDataCommitmentConfirm(
+					hex.EncodeToString(signature),
+					common.HexToAddress(evmAddress),
+				), types.RecordFormatBinaryV1)
+				return vsc
+			}(),
+			wantErr: false,
+		},
 		{
 			name:    "invalid key format",
 			key:     "/dcc/b/0xfA906e15C9Eaf338c4110f0E21983c6b3b2d622b:0x1234000000000000000000000000000000000000000000000000000000001234",
@@ -470,6 +497,25 @@ func TestLatestValsetValidatorValidate(t *testing.T) {
 			value:   []byte(`{"nonce":10,"members":[{"power":100,"evm_address":"evm_addr1"}],"height":5}`),
 			wantErr: true,
 		},
+		{
+			name: "valid binary value",
+			key:  GetLatestValsetKey(),
+			value: func() []byte {
+				vs, _ := types.EncodeLatestValset(types.LatestValset{
+					Nonce:   10,
+					Members: []celestiatypes.BridgeValidator{{Power: 100, EvmAddress: "evm_addr1"}},
+					Height:  5,
+				}, types.RecordFormatBinaryV1)
+				return vs
+			}(),
+			wantErr: false,
+		},
+		{
+			name:    "unknown record format",
+			key:     GetLatestValsetKey(),
+			value:   []byte{0x7f, 0x08, 0x0a},
+			wantErr: true,
+		},
 		{
 			name:    "empty valset",
 			key:     GetLatestValsetKey(),
@@ -562,3 +608,336 @@ func TestLatestValsetValidatorSelect(t *testing.T) {
 		})
 	}
 }
+
+func TestRelayAnnouncementValidate(t *testing.T) {
+	validator := RelayAnnouncementValidator{}
+
+	evmAddress := "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488"
+	privateKey, _ := ethcrypto.HexToECDSA("da6ed55cb2894ac2c9c10209c09de8e8b9d109b910338d5bf3d747a7e1fc9eb9")
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.ImportECDSA(privateKey, "123")
+	require.NoError(t, err)
+	err = ks.Unlock(acc, "123")
+	require.NoError(t, err)
+
+	txHash := common.HexToHash("0x1234")
+	announcement := func(nonce uint64, evmChainID uint64, signedNonce uint64) []byte {
+		signBytes := types.RelayAnnouncementSignBytes(signedNonce, evmChainID, txHash, 100)
+		signature, err := evm.NewEthereumSignature(signBytes.Bytes(), ks, acc)
+		require.NoError(t, err)
+		ra, _ := types.MarshalRelayAnnouncement(*types.NewRelayAnnouncement(
+			nonce,
+			evmChainID,
+			common.HexToAddress(evmAddress),
+			txHash,
+			100,
+			hex.EncodeToString(signature),
+		))
+		return ra
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		value   []byte
+		wantErr bool
+	}{
+		{
+			name:    "valid relay announcement",
+			key:     "/ra/a:" + evmAddress + ":5",
+			value:   announcement(10, 5, 10),
+			wantErr: false,
+		},
+		{
+			name:    "invalid key namespace",
+			key:     "/dcc/a:" + evmAddress + ":5",
+			value:   announcement(10, 5, 10),
+			wantErr: true,
+		},
+		{
+			name:    "invalid relayer address",
+			key:     "/ra/a:0xinvalid:5",
+			value:   announcement(10, 5, 10),
+			wantErr: true,
+		},
+		{
+			name:    "invalid evm chain id",
+			key:     "/ra/a:" + evmAddress + ":xyz",
+			value:   announcement(10, 5, 10),
+			wantErr: true,
+		},
+		{
+			name:    "nonce mismatch between key and announcement",
+			key:     "/ra/b:" + evmAddress + ":5",
+			value:   announcement(10, 5, 10),
+			wantErr: true,
+		},
+		{
+			name:    "evm chain id mismatch between key and announcement",
+			key:     "/ra/a:" + evmAddress + ":6",
+			value:   announcement(10, 5, 10),
+			wantErr: true,
+		},
+		{
+			name:    "relayer address mismatch between key and announcement",
+			key:     "/ra/a:0xfA906e15C9Eaf338c4110f0E21983c6b3b2d622b:5",
+			value:   announcement(10, 5, 10),
+			wantErr: true,
+		},
+		{
+			name:    "signature over different fields",
+			key:     "/ra/a:" + evmAddress + ":5",
+			value:   announcement(10, 5, 11),
+			wantErr: true,
+		},
+		{
+			name:    "invalid announcement",
+			key:     "/ra/a:" + evmAddress + ":5",
+			value:   []byte("invalid"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.key, tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRelayAnnouncementSelect(t *testing.T) {
+	validator := RelayAnnouncementValidator{}
+
+	evmAddress := "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488"
+	privateKey, _ := ethcrypto.HexToECDSA("da6ed55cb2894ac2c9c10209c09de8e8b9d109b910338d5bf3d747a7e1fc9eb9")
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.ImportECDSA(privateKey, "123")
+	require.NoError(t, err)
+	err = ks.Unlock(acc, "123")
+	require.NoError(t, err)
+
+	key := "/ra/a:" + evmAddress + ":5"
+	announcement := func(txHash common.Hash, timestamp int64) []byte {
+		signBytes := types.RelayAnnouncementSignBytes(10, 5, txHash, timestamp)
+		signature, err := evm.NewEthereumSignature(signBytes.Bytes(), ks, acc)
+		require.NoError(t, err)
+		ra, _ := types.MarshalRelayAnnouncement(*types.NewRelayAnnouncement(
+			10,
+			5,
+			common.HexToAddress(evmAddress),
+			txHash,
+			timestamp,
+			hex.EncodeToString(signature),
+		))
+		return ra
+	}
+
+	tests := []struct {
+		name          string
+		values        [][]byte
+		expectedIndex int
+		wantErr       bool
+	}{
+		{
+			name:          "latest announcement is selected",
+			values:        [][]byte{announcement(common.HexToHash("0x1"), 100), announcement(common.HexToHash("0x2"), 200)},
+			expectedIndex: 1,
+		},
+		{
+			name:          "invalid announcements are skipped",
+			values:        [][]byte{announcement(common.HexToHash("0x1"), 100), []byte("invalid")},
+			expectedIndex: 0,
+		},
+		{
+			name:    "no valid announcement",
+			values:  [][]byte{[]byte("invalid")},
+			wantErr: true,
+		},
+		{
+			name:    "no announcements",
+			values:  [][]byte{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, err := validator.Select(key, tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedIndex, index)
+			}
+		})
+	}
+}
+
+func TestAggregatedConfirmsValidate(t *testing.T) {
+	validator := NewAggregatedConfirmsValidator()
+	digest := common.HexToHash("1234")
+	key := GetAggregatedConfirmsKey(10, digest.Hex())
+
+	evmAddress := "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488"
+	privateKey, _ := ethcrypto.HexToECDSA("da6ed55cb2894ac2c9c10209c09de8e8b9d109b910338d5bf3d747a7e1fc9eb9")
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.ImportECDSA(privateKey, "123")
+	require.NoError(t, err)
+	err = ks.Unlock(acc, "123")
+	require.NoError(t, err)
+	signature, err := evm.NewEthereumSignature(digest.Bytes(), ks, acc)
+	require.NoError(t, err)
+
+	aggregated := func(nonce uint64, digest string, signatures ...types.AggregatedSignature) []byte {
+		ac := types.AggregatedConfirms{Nonce: nonce, Digest: digest, Signatures: signatures}
+		encoded, _ := types.EncodeAggregatedConfirms(ac, types.RecordFormatBinaryV1)
+		return encoded
+	}
+	validSignature := types.AggregatedSignature{EthAddress: evmAddress, Signature: hex.EncodeToString(signature)}
+
+	tests := []struct {
+		name    string
+		key     string
+		value   []byte
+		wantErr bool
+	}{
+		{
+			name:    "valid aggregated confirms",
+			key:     key,
+			value:   aggregated(10, digest.Hex(), validSignature),
+			wantErr: false,
+		},
+		{
+			name:    "invalid key",
+			key:     "/ac/a:" + evmAddress + ":" + digest.Hex(),
+			value:   aggregated(10, digest.Hex(), validSignature),
+			wantErr: true,
+		},
+		{
+			name:    "different nonce",
+			key:     key,
+			value:   aggregated(11, digest.Hex(), validSignature),
+			wantErr: true,
+		},
+		{
+			name:    "different digest",
+			key:     key,
+			value:   aggregated(10, common.HexToHash("5678").Hex(), validSignature),
+			wantErr: true,
+		},
+		{
+			name:    "no signatures",
+			key:     key,
+			value:   aggregated(10, digest.Hex()),
+			wantErr: true,
+		},
+		{
+			name:    "duplicate signatures",
+			key:     key,
+			value:   aggregated(10, digest.Hex(), validSignature, validSignature),
+			wantErr: true,
+		},
+		{
+			name: "invalid signature",
+			key:  key,
+			value: aggregated(10, digest.Hex(), validSignature, types.AggregatedSignature{
+				EthAddress: "0x91DEd26b5f38B065FC0204c7929Da1b2A21877Ad",
+				Signature:  hex.EncodeToString(signature),
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			key:     key,
+			value:   []byte("invalid"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.key, tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAggregatedConfirmsSelect(t *testing.T) {
+	digest := common.HexToHash("1234")
+	key := GetAggregatedConfirmsKey(10, digest.Hex())
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	signatures := make([]types.AggregatedSignature, 5)
+	for i := range signatures {
+		privateKey, err := ethcrypto.GenerateKey()
+		require.NoError(t, err)
+		acc, err := ks.ImportECDSA(privateKey, "123")
+		require.NoError(t, err)
+		err = ks.Unlock(acc, "123")
+		require.NoError(t, err)
+		signature, err := evm.NewEthereumSignature(digest.Bytes(), ks, acc)
+		require.NoError(t, err)
+		signatures[i] = types.AggregatedSignature{EthAddress: acc.Address.Hex(), Signature: hex.EncodeToString(signature)}
+	}
+	aggregated := func(signatures ...types.AggregatedSignature) []byte {
+		ac := types.NewAggregatedConfirms(10, digest.Hex())
+		for _, signature := range signatures {
+			ac.Add(signature.EthAddress, signature.Signature)
+		}
+		encoded, _ := types.EncodeAggregatedConfirms(*ac, types.RecordFormatJSON)
+		return encoded
+	}
+
+	validator := NewAggregatedConfirmsValidator()
+
+	// without voting powers, the record with the most signatures is selected
+	index, err := validator.Select(key, [][]byte{aggregated(signatures[0]), aggregated(signatures[1], signatures[2])})
+	require.NoError(t, err)
+	assert.Equal(t, 1, index)
+
+	// with voting powers, the record with the most voting power is selected
+	validator.SetValsetMembers([]celestiatypes.BridgeValidator{
+		{Power: 100, EvmAddress: signatures[0].EthAddress},
+		{Power: 10, EvmAddress: signatures[1].EthAddress},
+		{Power: 10, EvmAddress: signatures[2].EthAddress},
+	})
+	index, err = validator.Select(key, [][]byte{aggregated(signatures[1], signatures[2]), aggregated(signatures[0])})
+	require.NoError(t, err)
+	assert.Equal(t, 1, index)
+
+	// the signatures of the addresses that are not valset members don't count
+	index, err = validator.Select(key, [][]byte{aggregated(signatures[1], signatures[3], signatures[4]), aggregated(signatures[1], signatures[2])})
+	require.NoError(t, err)
+	assert.Equal(t, 1, index)
+	_, err = validator.Select(key, [][]byte{aggregated(signatures[3])})
+	assert.ErrorIs(t, err, ErrNoValidValueFound)
+
+	// the records can't hold more signatures than the valset members
+	assert.NoError(t, validator.Validate(key, aggregated(signatures[0], signatures[1], signatures[3])))
+	assert.ErrorIs(t, validator.Validate(key, aggregated(signatures...)), ErrTooManyAggregatedSignatures)
+
+	ac, err := types.UnmarshalAggregatedConfirms(aggregated(signatures[0], signatures[3]))
+	require.NoError(t, err)
+	filtered := validator.FilterMembers(ac)
+	require.Len(t, filtered.Signatures, 1)
+	assert.Equal(t, signatures[0], filtered.Signatures[0])
+
+	// invalid records are skipped
+	index, err = validator.Select(key, [][]byte{[]byte("invalid"), aggregated(signatures[1])})
+	require.NoError(t, err)
+	assert.Equal(t, 1, index)
+
+	_, err = validator.Select(key, [][]byte{[]byte("invalid")})
+	assert.ErrorIs(t, err, ErrNoValidValueFound)
+	_, err = validator.Select(key, [][]byte{})
+	assert.ErrorIs(t, err, ErrNoValues)
+}
@@ -0,0 +1,215 @@
+package pruner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/rpc"
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
+	"github.com/ipfs/go-datastore"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// DataStoreName the name of the store where the DHT saves its records.
+	DataStoreName = "data"
+	// SignatureStoreName the name of the relayer signature store.
+	SignatureStoreName = "signatures"
+)
+
+// RetentionConfig the configuration of the retention policy of the confirms saved to the stores.
+type RetentionConfig struct {
+	// Nonces the number of latest attestation nonces whose confirms are kept.
+	// If 0, the confirms are not pruned based on the number of nonces.
+	Nonces uint64 `mapstructure:"nonces" json:"nonces"`
+	// Days the number of days the confirms of an attestation are kept after it was created.
+	// If 0, the confirms are not pruned based on their age.
+	Days uint64 `mapstructure:"days" json:"days"`
+	// Interval the time, in minutes, between two prunings of the stores.
+	Interval uint64 `mapstructure:"interval" json:"interval"`
+}
+
+// Enabled returns true if the confirms outside the retention window should be pruned.
+func (cfg RetentionConfig) Enabled() bool {
+	return cfg.Nonces != 0 || cfg.Days != 0
+}
+
+// CutoffNonce returns the nonce before which the confirms are outside the retention window,
+// given the latest attestation nonce and the first nonce created within the retention days.
+// If both the nonces and the days are set, the confirms are kept as long as either of them retains them.
+// The confirms of the latest nonce are always kept. Returns 0 if no confirm should be pruned.
+func (cfg RetentionConfig) CutoffNonce(latestNonce uint64, firstRecentNonce uint64) uint64 {
+	if !cfg.Enabled() {
+		return 0
+	}
+	cutoff := latestNonce
+	if cfg.Nonces != 0 {
+		if latestNonce < cfg.Nonces {
+			return 0
+		}
+		cutoff = latestNonce - cfg.Nonces + 1
+	}
+	if cfg.Days != 0 && firstRecentNonce < cutoff {
+		cutoff = firstRecentNonce
+	}
+	return cutoff
+}
+
+// Store a store whose confirms are pruned.
+type Store struct {
+	// Name the name used to reference the store in the logs and metrics.
+	Name      string
+	Datastore datastore.Batching
+}
+
+// Pruner periodically deletes the confirms outside the retention window from the stores, and
+// runs their garbage collection so that the disk space is reclaimed.
+type Pruner struct {
+	Logger     tmlog.Logger
+	AppQuerier *rpc.AppQuerier
+	Config     RetentionConfig
+	Meters     *telemetry.StoreMeters
+	Stores     []Store
+}
+
+func New(
+	logger tmlog.Logger,
+	appQuerier *rpc.AppQuerier,
+	config RetentionConfig,
+	meters *telemetry.StoreMeters,
+	stores ...Store,
+) *Pruner {
+	return &Pruner{
+		Logger:     logger,
+		AppQuerier: appQuerier,
+		Config:     config,
+		Meters:     meters,
+		Stores:     stores,
+	}
+}
+
+// Start records the stores sizes, and prunes them if the retention policy is enabled,
+// every interval until the context is canceled.
+func (p *Pruner) Start(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(p.Config.Interval) * time.Minute)
+	defer ticker.Stop()
+	for {
+		if p.Config.Enabled() {
+			err := p.Prune(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				p.Logger.Error("couldn't prune the stores", "err", err.Error())
+			}
+		} else {
+			p.RecordSizes(ctx)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune deletes the confirms outside the retention window from the stores.
+func (p *Pruner) Prune(ctx context.Context) error {
+	cutoff, err := p.QueryCutoffNonce(ctx)
+	if err != nil {
+		return err
+	}
+	return p.PruneBefore(ctx, cutoff)
+}
+
+// QueryCutoffNonce queries the attestations to find the nonce before which the confirms are
+// outside the retention window.
+func (p *Pruner) QueryCutoffNonce(ctx context.Context) (uint64, error) {
+	latestNonce, err := p.AppQuerier.QueryLatestAttestationNonce(ctx)
+	if err != nil {
+		return 0, err
+	}
+	firstRecentNonce := latestNonce
+	if p.Config.Days != 0 {
+		since := time.Now().Add(-time.Duration(p.Config.Days) * 24 * time.Hour)
+		firstRecentNonce, err = p.queryFirstNonceSince(ctx, since, latestNonce)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return p.Config.CutoffNonce(latestNonce, firstRecentNonce), nil
+}
+
+// queryFirstNonceSince returns the first attestation nonce created after the provided time.
+// If all the attestations were created before it, the latest nonce is returned.
+// The attestations that were pruned from the Celestia state are considered older than the provided time.
+func (p *Pruner) queryFirstNonceSince(ctx context.Context, since time.Time, latestNonce uint64) (uint64, error) {
+	earliestNonce, err := p.AppQuerier.QueryEarliestAttestationNonce(ctx)
+	if err != nil {
+		return 0, err
+	}
+	low := uint64(1)
+	if earliestNonce > 1 {
+		low = uint64(earliestNonce)
+	}
+	high := latestNonce
+	// the attestations are created in order, so their creation times are sorted by nonce.
+	for low < high {
+		mid := low + (high-low)/2
+		att, err := p.AppQuerier.QueryAttestationByNonce(ctx, mid)
+		if err != nil {
+			return 0, err
+		}
+		if att != nil && !att.BlockTime().Before(since) {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	return low, nil
+}
+
+// PruneBefore deletes the confirms whose nonce is lower than the provided one from the stores,
+// then runs their garbage collection so that the disk space is reclaimed.
+func (p *Pruner) PruneBefore(ctx context.Context, nonce uint64) error {
+	for _, store := range p.Stores {
+		count, err := p2p.PruneConfirms(ctx, store.Datastore, nonce)
+		if err != nil {
+			return fmt.Errorf("%s: %w", store.Name, err)
+		}
+		if count == 0 {
+			continue
+		}
+		p.Logger.Info("pruned store", "store", store.Name, "before_nonce", nonce, "count", count)
+		if p.Meters != nil {
+			p.Meters.PrunedEntries.Add(ctx, int64(count), metric.WithAttributes(attribute.String(telemetry.StoreAttribute, store.Name)))
+		}
+		if gcStore, ok := store.Datastore.(datastore.GCDatastore); ok {
+			err := gcStore.CollectGarbage(ctx)
+			if err != nil {
+				return fmt.Errorf("%s: %w", store.Name, err)
+			}
+		}
+	}
+	p.RecordSizes(ctx)
+	return nil
+}
+
+// RecordSizes records the stores sizes on disk.
+func (p *Pruner) RecordSizes(ctx context.Context) {
+	if p.Meters == nil {
+		return
+	}
+	for _, store := range p.Stores {
+		size, err := datastore.DiskUsage(ctx, store.Datastore)
+		if err != nil {
+			p.Logger.Debug("couldn't get the store size", "store", store.Name, "err", err.Error())
+			continue
+		}
+		p.Meters.RecordSize(store.Name, size)
+	}
+}
@@ -0,0 +1,101 @@
+package pruner_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/pruner"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+func TestCutoffNonce(t *testing.T) {
+	tests := []struct {
+		name             string
+		config           pruner.RetentionConfig
+		latestNonce      uint64
+		firstRecentNonce uint64
+		expected         uint64
+	}{
+		{
+			name:             "retention disabled",
+			config:           pruner.RetentionConfig{},
+			latestNonce:      100,
+			firstRecentNonce: 100,
+			expected:         0,
+		},
+		{
+			name:             "nonces",
+			config:           pruner.RetentionConfig{Nonces: 10},
+			latestNonce:      100,
+			firstRecentNonce: 100,
+			expected:         91,
+		},
+		{
+			name:             "less nonces than retained",
+			config:           pruner.RetentionConfig{Nonces: 10},
+			latestNonce:      9,
+			firstRecentNonce: 9,
+			expected:         0,
+		},
+		{
+			name:             "days",
+			config:           pruner.RetentionConfig{Days: 7},
+			latestNonce:      100,
+			firstRecentNonce: 40,
+			expected:         40,
+		},
+		{
+			name:             "days retaining more nonces",
+			config:           pruner.RetentionConfig{Nonces: 10, Days: 7},
+			latestNonce:      100,
+			firstRecentNonce: 40,
+			expected:         40,
+		},
+		{
+			name:             "nonces retaining more nonces",
+			config:           pruner.RetentionConfig{Nonces: 80, Days: 7},
+			latestNonce:      100,
+			firstRecentNonce: 40,
+			expected:         21,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.CutoffNonce(tt.latestNonce, tt.firstRecentNonce))
+		})
+	}
+}
+
+func TestPruneBefore(t *testing.T) {
+	ctx := context.Background()
+	dataStore := datastore.NewMapDatastore()
+	sigStore := datastore.NewMapDatastore()
+	for nonce := uint64(1); nonce <= 5; nonce++ {
+		key := datastore.NewKey(p2p.GetDataCommitmentConfirmKey(nonce, "0xfA906e15C9Eaf338c4110f0E21983c6b3b2d622b", "0x1234"))
+		require.NoError(t, dataStore.Put(ctx, key, []byte("value")))
+		require.NoError(t, sigStore.Put(ctx, key, []byte("value")))
+	}
+
+	storePruner := pruner.New(
+		tmlog.NewNopLogger(),
+		nil,
+		pruner.RetentionConfig{Nonces: 2},
+		nil,
+		pruner.Store{Name: pruner.DataStoreName, Datastore: dataStore},
+		pruner.Store{Name: pruner.SignatureStoreName, Datastore: sigStore},
+	)
+	require.NoError(t, storePruner.PruneBefore(ctx, 4))
+
+	for _, store := range []datastore.Batching{dataStore, sigStore} {
+		has, err := store.Has(ctx, datastore.NewKey(p2p.GetDataCommitmentConfirmKey(3, "0xfA906e15C9Eaf338c4110f0E21983c6b3b2d622b", "0x1234")))
+		require.NoError(t, err)
+		assert.False(t, has)
+		has, err = store.Has(ctx, datastore.NewKey(p2p.GetDataCommitmentConfirmKey(4, "0xfA906e15C9Eaf338c4110f0E21983c6b3b2d622b", "0x1234")))
+		require.NoError(t, err)
+		assert.True(t, has)
+	}
+}
@@ -0,0 +1,147 @@
+package relayer
+
+import (
+	"context"
+
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	coregethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// BatchConfig the configuration of the relayer batching mode, where consecutive attestations
+// are relayed in a single transaction through the multicall contract.
+type BatchConfig struct {
+	// MulticallAddress the address of the multicall contract. Batching is disabled if not set.
+	MulticallAddress ethcmn.Address
+	// MaxBatchSize the maximum number of attestations to relay in a single transaction.
+	MaxBatchSize uint64
+	// GasCap the maximum gas to use for a batch transaction. If 0, the batch is only limited by its size.
+	GasCap uint64
+}
+
+// Enabled returns true if the relayer should batch attestations.
+func (cfg BatchConfig) Enabled() bool {
+	return cfg.MulticallAddress != (ethcmn.Address{}) && cfg.MaxBatchSize > 1
+}
+
+// ProcessBatch relays the provided consecutive attestations in a single transaction through the multicall contract.
+// The attestations are added to the batch as long as the estimated gas is lower than the gas cap, and
+// the batch contains at least the first attestation.
+// If an attestation, other than the first one, cannot be added to the batch, the batch is submitted without it and
+// the subsequent ones.
+// Returns the submitted transaction along with the number of attestations it relays.
+func (r *Relayer) ProcessBatch(
+	ctx context.Context,
+	opts *bind.TransactOpts,
+	backend bind.ContractBackend,
+	atts []celestiatypes.AttestationRequestI,
+) (*coregethtypes.Transaction, int, error) {
+	if len(atts) == 0 {
+		return nil, 0, ErrEmptyBatch
+	}
+	calls := make([][]byte, 0, len(atts))
+	var target ethcmn.Address
+	var gasEstimate uint64
+	for _, att := range atts {
+		tx, err := r.ProcessAttestation(ctx, evm.NewCallDataTransactOpts(opts.From, r.EVMClient.GasLimit), att)
+		if err != nil {
+			if len(calls) == 0 {
+				return nil, 0, err
+			}
+			r.logger.Debug("couldn't add attestation to batch. relaying the batch without it", "nonce", att.GetNonce(), "err", err.Error())
+			break
+		}
+		target = *tx.To()
+
+		estimate, err := backend.EstimateGas(ctx, ethereum.CallMsg{
+			From: opts.From,
+			To:   &r.Batch.MulticallAddress,
+			Data: evm.EncodeMulticall(target, append(calls, tx.Data())),
+		})
+		if err != nil {
+			if len(calls) == 0 {
+				return nil, 0, err
+			}
+			r.logger.Debug("couldn't estimate the batch gas with the attestation. relaying the batch without it", "nonce", att.GetNonce(), "err", err.Error())
+			break
+		}
+		if len(calls) != 0 && r.Batch.GasCap != 0 && estimate > r.Batch.GasCap {
+			r.logger.Debug("batch gas cap reached", "nonce", att.GetNonce(), "estimate", estimate, "gas_cap", r.Batch.GasCap)
+			break
+		}
+		calls = append(calls, tx.Data())
+		gasEstimate = estimate
+	}
+
+	// adding a margin to the estimated gas as the state might change before the transaction is included
+	gasLimit := gasEstimate + gasEstimate/5
+	if r.Batch.GasCap != 0 && gasLimit > r.Batch.GasCap && gasEstimate <= r.Batch.GasCap {
+		gasLimit = r.Batch.GasCap
+	}
+	opts.GasLimit = gasLimit
+
+	r.logger.Info(
+		"relaying batch",
+		"first_nonce", atts[0].GetNonce(),
+		"last_nonce", atts[len(calls)-1].GetNonce(),
+		"gas_limit", gasLimit,
+	)
+	tx, err := r.EVMClient.SubmitMulticall(opts, backend, r.Batch.MulticallAddress, target, calls)
+	if err != nil {
+		return nil, 0, err
+	}
+	return tx, len(calls), nil
+}
+
+// relayBatch queries the attestations starting from the first nonce, up to the last nonce or
+// the max batch size, relays them in a single transaction and waits for it to be mined.
+// Returns the number of relayed attestations.
+func (r *Relayer) relayBatch(ctx context.Context, ethClient *ethclient.Client, firstNonce uint64, lastNonce uint64) (int, error) {
+	atts := make([]celestiatypes.AttestationRequestI, 0, r.Batch.MaxBatchSize)
+	for nonce := firstNonce; nonce <= lastNonce && uint64(len(atts)) < r.Batch.MaxBatchSize; nonce++ {
+		att, err := r.AppQuerier.QueryAttestationByNonce(ctx, nonce)
+		if err != nil {
+			return 0, err
+		}
+		if att == nil {
+			return 0, ErrAttestationNotFound
+		}
+		atts = append(atts, att)
+	}
+
+	opts, err := r.EVMClient.NewTransactionOpts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, count, err := r.ProcessBatch(ctx, opts, ethClient, atts)
+	if err != nil {
+		return 0, err
+	}
+	for _, att := range atts[:count] {
+		r.announceRelay(ctx, att.GetNonce(), tx.Hash())
+	}
+
+	receipt, err := r.waitForTransactionAndRetryIfNeeded(ctx, ethClient, opts, tx)
+	if err != nil {
+		r.Meters.Failures.Add(ctx, 1, r.Meters.Attributes())
+		return 0, err
+	}
+	if receipt.Status != coregethtypes.ReceiptStatusSuccessful {
+		r.logger.Error(
+			"batch transaction reverted",
+			"first_nonce", atts[0].GetNonce(),
+			"last_nonce", atts[count-1].GetNonce(),
+			"hash", receipt.TxHash.Hex(),
+		)
+		r.Meters.Failures.Add(ctx, 1, r.Meters.Attributes())
+		return 0, ErrTransactionReverted
+	}
+	r.recordEtherSpent(ctx, receipt, count)
+	return count, nil
+}
@@ -0,0 +1,46 @@
+package relayer_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/relayer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *RelayerTestSuite) TestProcessBatch() {
+	t := s.T()
+	_, err := s.Node.CelestiaNetwork.WaitForHeightWithTimeout(400, 30*time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	multicall, _, err := s.Relayer.EVMClient.DeployMulticall(s.Node.EVMChain.Auth, s.Node.EVMChain.Backend)
+	require.NoError(t, err)
+	s.Relayer.Batch = relayer.BatchConfig{MulticallAddress: multicall, MaxBatchSize: 10}
+	defer func() { s.Relayer.Batch = relayer.BatchConfig{} }()
+
+	lastNonce, err := s.Relayer.EVMClient.StateLastEventNonce(nil)
+	require.NoError(t, err)
+
+	atts := s.signDataCommitments(ctx, lastNonce+1, 3)
+
+	// copying the transaction options as the batch processing updates the gas limit
+	opts := *s.Node.EVMChain.Auth
+	tx, count, err := s.Relayer.ProcessBatch(ctx, &opts, s.Node.EVMChain.Backend, atts)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	receipt, err := s.Relayer.EVMClient.WaitForTransaction(ctx, s.Node.EVMChain.Backend, tx, 20*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), receipt.Status)
+
+	newLastNonce, err := s.Relayer.EVMClient.StateLastEventNonce(nil)
+	require.NoError(t, err)
+	assert.Equal(t, lastNonce+3, newLastNonce)
+
+	// the batch cannot be relayed again as the contract nonce has already been updated
+	_, count, err = s.Relayer.ProcessBatch(ctx, &opts, s.Node.EVMChain.Backend, atts)
+	require.Error(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,145 @@
+package relayer
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// Coordinator coordinates the relaying of attestations between multiple relayers via the P2P network.
+// For every nonce, a leader is deterministically chosen from the registered relayers, and is the only
+// one relaying the attestation. The other relayers wait for the wait time to elapse before relaying it
+// themselves, unless a relayer announced relaying it in the meantime.
+type Coordinator struct {
+	// Relayers the EVM addresses of the registered relayers, sorted.
+	Relayers []ethcmn.Address
+	// Self the EVM address of this relayer.
+	Self ethcmn.Address
+	// EVMChainID the ID of the EVM chain the attestations are relayed to.
+	EVMChainID uint64
+	// WaitTime the time to wait for the leader to relay an attestation before relaying it.
+	// Relay announcements older than the wait time are considered stale.
+	WaitTime time.Duration
+
+	// waitingNonce the nonce the relayer is waiting for the leader to relay.
+	waitingNonce uint64
+	// waitingSince the time at which the relayer started waiting for the leader to relay the waiting nonce.
+	waitingSince time.Time
+}
+
+// NewCoordinator creates a new Coordinator.
+// Returns an error if the relayer is not part of the registered relayers.
+func NewCoordinator(relayers []ethcmn.Address, self ethcmn.Address, evmChainID uint64, waitTime time.Duration) (*Coordinator, error) {
+	sorted := make([]ethcmn.Address, len(relayers))
+	copy(sorted, relayers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Bytes(), sorted[j].Bytes()) < 0
+	})
+
+	registered := false
+	for _, relayer := range sorted {
+		if relayer == self {
+			registered = true
+			break
+		}
+	}
+	if !registered {
+		return nil, ErrRelayerNotRegistered
+	}
+
+	return &Coordinator{
+		Relayers:   sorted,
+		Self:       self,
+		EVMChainID: evmChainID,
+		WaitTime:   waitTime,
+	}, nil
+}
+
+// Leader returns the relayer responsible for relaying the provided nonce.
+func (c *Coordinator) Leader(nonce uint64) ethcmn.Address {
+	return c.Relayers[nonce%uint64(len(c.Relayers))]
+}
+
+// IsLeader returns true if the relayer is responsible for relaying the provided nonce.
+func (c *Coordinator) IsLeader(nonce uint64) bool {
+	return c.Leader(nonce) == c.Self
+}
+
+// shouldRelayNonce returns true if the relayer should relay the provided nonce, i.e. no other relayer
+// announced relaying it, and either the relayer is the leader for it, or the leader didn't relay it
+// in the wait time.
+func (r *Relayer) shouldRelayNonce(ctx context.Context, nonce uint64) (bool, error) {
+	c := r.Coordinator
+	others := make([]string, 0, len(c.Relayers))
+	for _, relayer := range c.Relayers {
+		if relayer != c.Self {
+			others = append(others, relayer.Hex())
+		}
+	}
+	announcements, err := r.P2PQuerier.QueryRelayAnnouncements(ctx, nonce, c.EVMChainID, others)
+	if err != nil {
+		return false, err
+	}
+	for _, announcement := range announcements {
+		if time.Since(time.Unix(announcement.Timestamp, 0)) < c.WaitTime {
+			r.logger.Debug(
+				"attestation already being relayed by another relayer",
+				"nonce", nonce,
+				"relayer", announcement.RelayerAddress,
+				"tx_hash", announcement.TxHash,
+			)
+			return false, nil
+		}
+	}
+
+	if c.IsLeader(nonce) {
+		return true, nil
+	}
+
+	if c.waitingNonce != nonce {
+		c.waitingNonce = nonce
+		c.waitingSince = time.Now()
+	}
+	if time.Since(c.waitingSince) < c.WaitTime {
+		r.logger.Debug("waiting for the leader to relay attestation", "nonce", nonce, "leader", c.Leader(nonce).Hex())
+		return false, nil
+	}
+	r.logger.Info("leader didn't relay attestation in time. relaying it", "nonce", nonce, "leader", c.Leader(nonce).Hex())
+	return true, nil
+}
+
+// announceRelay announces to the P2P network that the relayer is relaying the provided nonce
+// using the provided transaction.
+// Failing to announce doesn't prevent relaying, so the errors are only logged.
+func (r *Relayer) announceRelay(ctx context.Context, nonce uint64, txHash ethcmn.Hash) {
+	if r.Coordinator == nil {
+		return
+	}
+	timestamp := time.Now().Unix()
+	signBytes := types.RelayAnnouncementSignBytes(nonce, r.Coordinator.EVMChainID, txHash, timestamp)
+	signature, err := r.EVMClient.Signer.SignDigest(ctx, signBytes.Bytes())
+	if err != nil {
+		r.logger.Error("failed to sign relay announcement", "nonce", nonce, "err", err.Error())
+		return
+	}
+	announcement := types.NewRelayAnnouncement(
+		nonce,
+		r.Coordinator.EVMChainID,
+		r.Coordinator.Self,
+		txHash,
+		timestamp,
+		ethcmn.Bytes2Hex(signature),
+	)
+	key := p2p.GetRelayAnnouncementKey(nonce, r.Coordinator.Self.Hex(), r.Coordinator.EVMChainID)
+	err = r.P2PQuerier.BlobstreamDHT.PutRelayAnnouncement(ctx, key, *announcement)
+	if err != nil {
+		r.logger.Error("failed to announce relaying attestation", "nonce", nonce, "err", err.Error())
+		return
+	}
+	r.logger.Debug("announced relaying attestation", "nonce", nonce, "tx_hash", txHash.Hex())
+}
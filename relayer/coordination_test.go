@@ -0,0 +1,41 @@
+package relayer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/relayer"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoordinatorLeader(t *testing.T) {
+	relayer1 := ethcmn.HexToAddress("0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488")
+	relayer2 := ethcmn.HexToAddress("0x91DEd26b5f38B065FC0204c7929Da1b2A21877Ad")
+	relayer3 := ethcmn.HexToAddress("0x3d22f0C38251ebdBE92e14BBF1bd2067F1C3e6BC")
+
+	// the leader rotation doesn't depend on the order in which the relayers are provided
+	c1, err := relayer.NewCoordinator([]ethcmn.Address{relayer1, relayer2, relayer3}, relayer1, 5, time.Minute)
+	require.NoError(t, err)
+	c2, err := relayer.NewCoordinator([]ethcmn.Address{relayer3, relayer1, relayer2}, relayer2, 5, time.Minute)
+	require.NoError(t, err)
+
+	expectedLeaders := []ethcmn.Address{relayer3, relayer2, relayer1}
+	for nonce := uint64(0); nonce < 9; nonce++ {
+		assert.Equal(t, expectedLeaders[nonce%3], c1.Leader(nonce))
+		assert.Equal(t, c1.Leader(nonce), c2.Leader(nonce))
+		assert.Equal(t, c1.Leader(nonce) == relayer1, c1.IsLeader(nonce))
+		assert.Equal(t, c2.Leader(nonce) == relayer2, c2.IsLeader(nonce))
+	}
+}
+
+func TestNewCoordinatorNotRegistered(t *testing.T) {
+	_, err := relayer.NewCoordinator(
+		[]ethcmn.Address{ethcmn.HexToAddress("0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488")},
+		ethcmn.HexToAddress("0x91DEd26b5f38B065FC0204c7929Da1b2A21877Ad"),
+		5,
+		time.Minute,
+	)
+	assert.ErrorIs(t, err, relayer.ErrRelayerNotRegistered)
+}
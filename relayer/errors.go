@@ -10,4 +10,8 @@ var (
 	ErrAttestationNotFound                 = errors.New("attestation not found")
 	ErrValidatorSetMismatch                = errors.New("p2p validator set is different from the trusted contract one")
 	ErrTransactionStillPending             = errors.New("evm transaction still pending")
+	ErrEmptyBatch                          = errors.New("empty batch")
+	ErrTransactionReverted                 = errors.New("evm transaction reverted")
+	ErrRelayerNotRegistered                = errors.New("relayer is not part of the registered relayers")
+	ErrRelayLoopStalled                    = errors.New("relay loop stalled")
 )
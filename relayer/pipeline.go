@@ -0,0 +1,404 @@
+package relayer
+
+import (
+	"context"
+	stderrors "errors"
+	"math/big"
+	"sync"
+	"time"
+
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	coregethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// maxPendingTxRetries the maximum number of times a pending transaction is sped up
+// before the relayer gives up on it.
+const maxPendingTxRetries = 10
+
+// PendingTx an in-flight transaction relaying an attestation.
+type PendingTx struct {
+	// AttestationNonce the nonce of the relayed attestation.
+	AttestationNonce uint64
+	// Txs the submitted versions of the transaction, i.e. the original one followed
+	// by its speed ups. All of them have the same account nonce.
+	Txs []*coregethtypes.Transaction
+	// Opts the transaction options used to sign the transaction.
+	Opts *bind.TransactOpts
+	// SubmittedAt the time of submission of the original transaction.
+	SubmittedAt time.Time
+	// Retries the number of times the transaction was sped up.
+	Retries int
+}
+
+// Latest returns the latest submitted version of the pending transaction.
+func (ptx *PendingTx) Latest() *coregethtypes.Transaction {
+	return ptx.Txs[len(ptx.Txs)-1]
+}
+
+// AccountNonce returns the account nonce of the pending transaction.
+func (ptx *PendingTx) AccountNonce() uint64 {
+	return ptx.Txs[0].Nonce()
+}
+
+// PendingTxs the table of the in-flight transactions submitted by the relayer.
+// The transactions are ordered by account nonce, which is also the order
+// of the attestations they relay.
+type PendingTxs struct {
+	mutex sync.Mutex
+	txs   []*PendingTx
+}
+
+// NewPendingTxs creates an empty pending transactions table.
+func NewPendingTxs() *PendingTxs {
+	return &PendingTxs{}
+}
+
+// Len returns the number of in-flight transactions.
+func (p *PendingTxs) Len() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return len(p.txs)
+}
+
+// Add adds a pending transaction to the end of the table.
+func (p *PendingTxs) Add(ptx *PendingTx) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.txs = append(p.txs, ptx)
+}
+
+// Oldest returns the pending transaction with the lowest account nonce.
+// Returns nil if the table is empty.
+func (p *PendingTxs) Oldest() *PendingTx {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if len(p.txs) == 0 {
+		return nil
+	}
+	return p.txs[0]
+}
+
+// Newest returns the pending transaction with the highest account nonce.
+// Returns nil if the table is empty.
+func (p *PendingTxs) Newest() *PendingTx {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if len(p.txs) == 0 {
+		return nil
+	}
+	return p.txs[len(p.txs)-1]
+}
+
+// PopOldest removes the pending transaction with the lowest account nonce from the table.
+func (p *PendingTxs) PopOldest() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if len(p.txs) == 0 {
+		return
+	}
+	p.txs = p.txs[1:]
+}
+
+// All returns a copy of the pending transactions ordered by account nonce.
+func (p *PendingTxs) All() []*PendingTx {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	txs := make([]*PendingTx, len(p.txs))
+	copy(txs, p.txs)
+	return txs
+}
+
+// Reset removes all the pending transactions from the table.
+func (p *PendingTxs) Reset() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.txs = nil
+}
+
+// PruneRelayed removes the pending transactions relaying attestations that the contract already holds,
+// i.e. the ones that were mined, or that became stale because another relayer relayed their attestation.
+// Returns the number of removed transactions.
+func (p *PendingTxs) PruneRelayed(lastContractNonce uint64) int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	txs := make([]*PendingTx, 0, len(p.txs))
+	for _, ptx := range p.txs {
+		if ptx.AttestationNonce > lastContractNonce {
+			txs = append(txs, ptx)
+		}
+	}
+	pruned := len(p.txs) - len(txs)
+	p.txs = txs
+	return pruned
+}
+
+// NextAttestationNonce returns the nonce of the next attestation to relay, i.e. the one
+// following the last attestation in flight, or the one following the last contract nonce
+// if no transaction is in flight.
+func (p *PendingTxs) NextAttestationNonce(lastContractNonce uint64) uint64 {
+	newest := p.Newest()
+	if newest == nil || newest.AttestationNonce < lastContractNonce {
+		return lastContractNonce + 1
+	}
+	return newest.AttestationNonce + 1
+}
+
+// PipelineBackend the EVM backend used by the pipelined relayer.
+type PipelineBackend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+// ProcessPipeline submits the provided consecutive attestations, following the ones already in flight,
+// using consecutive account nonces. Then, waits for the oldest in-flight transaction:
+//   - if it's mined successfully, it's removed from the pending transactions table.
+//   - if it's not mined in the retry timeout, all the in-flight transactions are sped up as a group.
+//   - if it reverts, the subsequent in-flight transactions are cancelled as they will revert too,
+//     and the pending transactions table is reset so that relaying resumes from the contract state.
+//
+// Returns the number of attestations that were successfully relayed.
+func (r *Relayer) ProcessPipeline(
+	ctx context.Context,
+	opts *bind.TransactOpts,
+	backend PipelineBackend,
+	atts []celestiatypes.AttestationRequestI,
+) (int, error) {
+	for _, att := range atts {
+		err := r.submitToPipeline(ctx, opts, backend, att)
+		if isStaleNonceError(err) {
+			// another relayer relayed the attestation in the meantime. relaying resumes from
+			// the contract state.
+			r.logger.Info("attestation already relayed. skipping it", "nonce", att.GetNonce(), "reason", err.Error())
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	oldest := r.PendingTxs.Oldest()
+	if oldest == nil {
+		return 0, nil
+	}
+
+	receipt, err := r.waitForPendingTx(ctx, backend, oldest)
+	if err != nil {
+		if !stderrors.Is(err, context.DeadlineExceeded) {
+			return 0, err
+		}
+		if oldest.Retries >= maxPendingTxRetries {
+			// giving up on the in-flight transactions so that relaying resumes from the contract state
+			r.PendingTxs.Reset()
+			return 0, ErrTransactionStillPending
+		}
+		r.logger.Debug("pending transactions still not included. updating their gas price", "count", r.PendingTxs.Len(), "retry_number", oldest.Retries)
+		return 0, r.speedUpPendingTxs(ctx, backend)
+	}
+
+	if receipt.Status != coregethtypes.ReceiptStatusSuccessful {
+		r.logger.Error(
+			"pipelined transaction reverted. cancelling the subsequent in-flight transactions",
+			"nonce", oldest.AttestationNonce,
+			"hash", receipt.TxHash.Hex(),
+		)
+		r.Meters.Failures.Add(ctx, 1, r.Meters.Attributes())
+		r.rollbackPendingTxs(ctx, backend)
+		return 0, nil
+	}
+
+	r.logger.Info("pipelined transaction confirmed", "nonce", oldest.AttestationNonce, "hash", receipt.TxHash.Hex(), "block", receipt.BlockNumber.Uint64())
+	r.Meters.ProcessingTime.Record(ctx, time.Since(oldest.SubmittedAt).Seconds(), r.Meters.Attributes())
+	r.recordEtherSpent(ctx, receipt, 1)
+	r.PendingTxs.PopOldest()
+	return 1, nil
+}
+
+// submitToPipeline relays the provided attestation using the account nonce following
+// the in-flight transactions, and adds the transaction to the pending transactions table.
+func (r *Relayer) submitToPipeline(
+	ctx context.Context,
+	opts *bind.TransactOpts,
+	backend PipelineBackend,
+	att celestiatypes.AttestationRequestI,
+) error {
+	var accountNonce uint64
+	if newest := r.PendingTxs.Newest(); newest != nil {
+		accountNonce = newest.AccountNonce() + 1
+	} else {
+		nonce, err := backend.PendingNonceAt(ctx, opts.From)
+		if err != nil {
+			return err
+		}
+		accountNonce = nonce
+	}
+
+	txOpts := *opts
+	txOpts.Nonce = new(big.Int).SetUint64(accountNonce)
+	tx, err := r.ProcessAttestation(ctx, &txOpts, att)
+	if err != nil {
+		return err
+	}
+	r.logger.Debug("submitted pipelined transaction", "nonce", att.GetNonce(), "account_nonce", accountNonce, "hash", tx.Hash().Hex())
+	r.announceRelay(ctx, att.GetNonce(), tx.Hash())
+
+	r.PendingTxs.Add(&PendingTx{
+		AttestationNonce: att.GetNonce(),
+		Txs:              []*coregethtypes.Transaction{tx},
+		Opts:             &txOpts,
+		SubmittedAt:      time.Now(),
+	})
+	return nil
+}
+
+// waitForPendingTx waits for any of the versions of the pending transaction to be mined.
+// Returns context.DeadlineExceeded if none of them is mined in the retry timeout.
+func (r *Relayer) waitForPendingTx(ctx context.Context, backend PipelineBackend, ptx *PendingTx) (*coregethtypes.Receipt, error) {
+	r.RecordHeartbeat(time.Now())
+	ctx, cancel := context.WithTimeout(ctx, r.EVMClient.GasPolicy.RetryTimeout(r.RetryTimeout, ptx.Retries))
+	defer cancel()
+
+	queryTicker := time.NewTicker(time.Second)
+	defer queryTicker.Stop()
+	for {
+		for _, tx := range ptx.Txs {
+			receipt, err := backend.TransactionReceipt(ctx, tx.Hash())
+			if err == nil {
+				return receipt, nil
+			}
+			if !stderrors.Is(err, ethereum.NotFound) {
+				r.logger.Debug("failed to query transaction receipt", "hash", tx.Hash().Hex(), "err", err.Error())
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-queryTicker.C:
+		}
+	}
+}
+
+// speedUpPendingTxs updates the gas price of all the in-flight transactions, so that
+// they're replaced together and the later ones don't get stuck behind a cheaper one.
+func (r *Relayer) speedUpPendingTxs(ctx context.Context, backend PipelineBackend) error {
+	for _, ptx := range r.PendingTxs.All() {
+		ptx.Retries++
+		signedTx, err := speedUpTransaction(ctx, backend, r.EVMClient.GasPolicy, ptx.Opts, ptx.Latest())
+		if err != nil {
+			return err
+		}
+		if signedTx == nil {
+			// the gas price cannot be increased, e.g. the cap is reached
+			continue
+		}
+		err = backend.SendTransaction(ctx, signedTx)
+		if err != nil {
+			r.logger.Debug("response of sending speed up transaction", "hash", signedTx.Hash().Hex(), "resp", err.Error())
+			continue
+		}
+		r.logger.Info("submitted speed up transaction", "nonce", ptx.AttestationNonce, "hash", signedTx.Hash().Hex(), "new_gas_price", signedTx.GasPrice().Uint64())
+		r.Meters.SpeedUps.Add(ctx, 1, r.Meters.Attributes())
+		ptx.Txs = append(ptx.Txs, signedTx)
+	}
+	return nil
+}
+
+// rollbackPendingTxs replaces the in-flight transactions following the oldest one with
+// self transfers, then resets the pending transactions table.
+// The replaced transactions would otherwise revert as they relay attestations that
+// don't follow the contract nonce anymore.
+// If a transaction cannot be replaced, e.g. it was already mined, it's left as is.
+func (r *Relayer) rollbackPendingTxs(ctx context.Context, backend PipelineBackend) {
+	defer r.PendingTxs.Reset()
+	ptxs := r.PendingTxs.All()
+	if len(ptxs) < 2 {
+		return
+	}
+	for _, ptx := range ptxs[1:] {
+		cancelTx, err := createCancelTransaction(ctx, backend, r.EVMClient.GasPolicy, ptx.Opts, ptx.Latest())
+		if err != nil {
+			r.logger.Error("failed to create cancel transaction", "nonce", ptx.AttestationNonce, "err", err.Error())
+			continue
+		}
+		err = backend.SendTransaction(ctx, cancelTx)
+		if err != nil {
+			r.logger.Debug("failed to cancel pipelined transaction", "nonce", ptx.AttestationNonce, "account_nonce", ptx.AccountNonce(), "err", err.Error())
+			continue
+		}
+		r.logger.Info("cancelled pipelined transaction", "nonce", ptx.AttestationNonce, "account_nonce", ptx.AccountNonce(), "hash", cancelTx.Hash().Hex())
+	}
+}
+
+// createCancelTransaction creates and signs a zero value transfer to the sender, replacing the
+// provided transaction. The gas price is bumped following the gas policy.
+func createCancelTransaction(
+	ctx context.Context,
+	backend bind.ContractBackend,
+	gasPolicy evm.GasPolicy,
+	opts *bind.TransactOpts,
+	tx *coregethtypes.Transaction,
+) (*coregethtypes.Transaction, error) {
+	var rawTx *coregethtypes.Transaction
+	var err error
+	if tx.Type() == coregethtypes.DynamicFeeTxType {
+		rawTx, err = createSpeededUpDynamicTransaction(ctx, backend, gasPolicy, tx)
+	} else {
+		rawTx, err = createSpeededUpLegacyTransaction(ctx, backend, gasPolicy, tx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	to := opts.From
+	var cancelTx *coregethtypes.Transaction
+	if tx.Type() == coregethtypes.DynamicFeeTxType {
+		cancelTx = coregethtypes.NewTx(&coregethtypes.DynamicFeeTx{
+			ChainID:   rawTx.ChainId(),
+			Nonce:     rawTx.Nonce(),
+			GasTipCap: rawTx.GasTipCap(),
+			GasFeeCap: rawTx.GasFeeCap(),
+			Gas:       params.TxGas,
+			To:        &to,
+			Value:     big.NewInt(0),
+		})
+	} else {
+		cancelTx = coregethtypes.NewTx(&coregethtypes.LegacyTx{
+			Nonce:    rawTx.Nonce(),
+			GasPrice: rawTx.GasPrice(),
+			Gas:      params.TxGas,
+			To:       &to,
+			Value:    big.NewInt(0),
+		})
+	}
+	return opts.Signer(opts.From, cancelTx)
+}
+
+// relayPipelined queries the attestations following the in-flight ones, up to the latest nonce or
+// the max number of in-flight transactions, and processes them using the pipeline.
+// Returns the number of relayed attestations.
+func (r *Relayer) relayPipelined(ctx context.Context, ethClient *ethclient.Client, lastContractNonce uint64, latestNonce uint64) (int, error) {
+	atts := make([]celestiatypes.AttestationRequestI, 0)
+	nonce := r.PendingTxs.NextAttestationNonce(lastContractNonce)
+	for ; nonce <= latestNonce && uint64(r.PendingTxs.Len()+len(atts)) < r.MaxInFlight; nonce++ {
+		att, err := r.AppQuerier.QueryAttestationByNonce(ctx, nonce)
+		if err != nil {
+			return 0, err
+		}
+		if att == nil {
+			return 0, ErrAttestationNotFound
+		}
+		atts = append(atts, att)
+	}
+
+	opts, err := r.EVMClient.NewTransactionOpts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.ProcessPipeline(ctx, opts, ethClient, atts)
+}
@@ -0,0 +1,88 @@
+package relayer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/orchestrator-relayer/relayer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *RelayerTestSuite) TestProcessPipeline() {
+	t := s.T()
+	_, err := s.Node.CelestiaNetwork.WaitForHeightWithTimeout(400, 30*time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	s.Relayer.MaxInFlight = 3
+	defer func() {
+		s.Relayer.MaxInFlight = 1
+		s.Relayer.PendingTxs.Reset()
+	}()
+
+	lastNonce, err := s.Relayer.EVMClient.StateLastEventNonce(nil)
+	require.NoError(t, err)
+	atts := s.signDataCommitments(ctx, lastNonce+1, 3)
+
+	opts := *s.Node.EVMChain.Auth
+	relayed, err := s.Relayer.ProcessPipeline(ctx, &opts, s.Node.EVMChain.Backend, atts)
+	require.NoError(t, err)
+	assert.Equal(t, 1, relayed)
+	assert.Equal(t, 2, s.Relayer.PendingTxs.Len())
+
+	for s.Relayer.PendingTxs.Len() != 0 {
+		count, err := s.Relayer.ProcessPipeline(ctx, &opts, s.Node.EVMChain.Backend, nil)
+		require.NoError(t, err)
+		relayed += count
+	}
+	assert.Equal(t, 3, relayed)
+
+	newLastNonce, err := s.Relayer.EVMClient.StateLastEventNonce(nil)
+	require.NoError(t, err)
+	assert.Equal(t, lastNonce+3, newLastNonce)
+}
+
+func (s *RelayerTestSuite) TestProcessPipelineRollback() {
+	t := s.T()
+	_, err := s.Node.CelestiaNetwork.WaitForHeightWithTimeout(400, 30*time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	s.Relayer.MaxInFlight = 2
+	defer func() {
+		s.Relayer.MaxInFlight = 1
+		s.Relayer.PendingTxs.Reset()
+	}()
+
+	lastNonce, err := s.Relayer.EVMClient.StateLastEventNonce(nil)
+	require.NoError(t, err)
+	// skipping the nonce following the contract one so that the first transaction reverts
+	atts := s.signDataCommitments(ctx, lastNonce+2, 2)
+
+	opts := *s.Node.EVMChain.Auth
+	relayed, err := s.Relayer.ProcessPipeline(ctx, &opts, s.Node.EVMChain.Backend, atts)
+	require.NoError(t, err)
+	assert.Equal(t, 0, relayed)
+	assert.Equal(t, 0, s.Relayer.PendingTxs.Len())
+	assert.Equal(t, lastNonce+1, s.Relayer.PendingTxs.NextAttestationNonce(lastNonce))
+
+	newLastNonce, err := s.Relayer.EVMClient.StateLastEventNonce(nil)
+	require.NoError(t, err)
+	assert.Equal(t, lastNonce, newLastNonce)
+}
+
+func TestPendingTxsPruneRelayed(t *testing.T) {
+	pendingTxs := relayer.NewPendingTxs()
+	for nonce := uint64(5); nonce <= 8; nonce++ {
+		pendingTxs.Add(&relayer.PendingTx{AttestationNonce: nonce})
+	}
+
+	assert.Equal(t, 2, pendingTxs.PruneRelayed(6))
+	require.Equal(t, 2, pendingTxs.Len())
+	assert.Equal(t, uint64(7), pendingTxs.Oldest().AttestationNonce)
+
+	assert.Equal(t, 2, pendingTxs.PruneRelayed(10))
+	assert.Equal(t, 0, pendingTxs.Len())
+}
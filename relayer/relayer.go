@@ -8,12 +8,11 @@ import (
 	"fmt"
 	"math/big"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/celestiaorg/orchestrator-relayer/telemetry"
 
-	"github.com/ethereum/go-ethereum/params"
-
 	"github.com/ethereum/go-ethereum/ethclient"
 
 	"github.com/ipfs/go-datastore"
@@ -37,6 +36,11 @@ import (
 	tmlog "github.com/tendermint/tendermint/libs/log"
 )
 
+// HeartbeatTimeout the maximum time since the last heartbeat of the relay loop for it to be
+// considered alive, on top of the backup relayer and transaction waits. It is longer than the
+// 30 minutes the relayer waits for the confirms of an attestation.
+const HeartbeatTimeout = 45 * time.Minute
+
 type Relayer struct {
 	TmQuerier             *rpc.TmQuerier
 	AppQuerier            *rpc.AppQuerier
@@ -49,6 +53,20 @@ type Relayer struct {
 	IsBackupRelayer       bool
 	BackupRelayerWaitTime time.Duration
 	Meters                *telemetry.RelayerMeters
+	Batch                 BatchConfig
+	MaxInFlight           uint64
+	PendingTxs            *PendingTxs
+	// Coordinator coordinates relaying with the other relayers. If nil, the relayer
+	// doesn't coordinate with other relayers.
+	Coordinator *Coordinator
+	// LowBalanceThreshold the balance, in ether, under which the relayer warns that its
+	// account needs to be funded. If 0, the relayer doesn't warn.
+	LowBalanceThreshold float64
+
+	heartbeatMu sync.Mutex
+	// heartbeat the last time the relay loop made progress.
+	// Zero if the relayer didn't start yet.
+	heartbeat time.Time
 }
 
 func NewRelayer(
@@ -63,6 +81,9 @@ func NewRelayer(
 	isBackupRelayer bool,
 	backupRelayerWaitTime time.Duration,
 	meters *telemetry.RelayerMeters,
+	batch BatchConfig,
+	maxInFlight uint64,
+	coordinator *Coordinator,
 ) *Relayer {
 	return &Relayer{
 		TmQuerier:             tmQuerier,
@@ -76,6 +97,10 @@ func NewRelayer(
 		IsBackupRelayer:       isBackupRelayer,
 		BackupRelayerWaitTime: backupRelayerWaitTime,
 		Meters:                meters,
+		Batch:                 batch,
+		MaxInFlight:           maxInFlight,
+		PendingTxs:            NewPendingTxs(),
+		Coordinator:           coordinator,
 	}
 }
 
@@ -89,6 +114,8 @@ func (r *Relayer) Start(ctx context.Context) error {
 
 	backupRelayerShouldRelay := false
 	processFunc := func() error {
+		r.RecordHeartbeat(time.Now())
+		r.checkBalance(ctx, ethClient)
 		// this function will relay attestations as long as there are confirms. And, after the contract is
 		// up-to-date with the chain, it will stop.
 		for {
@@ -96,6 +123,7 @@ func (r *Relayer) Start(ctx context.Context) error {
 			case <-ctx.Done():
 				return ctx.Err()
 			default:
+				r.RecordHeartbeat(time.Now())
 				lastContractNonce, err := r.EVMClient.StateLastEventNonce(&bind.CallOpts{})
 				if err != nil {
 					return err
@@ -106,8 +134,18 @@ func (r *Relayer) Start(ctx context.Context) error {
 					return err
 				}
 
+				if latestNonce > lastContractNonce {
+					r.Meters.RecordNoncesLag(latestNonce - lastContractNonce)
+				} else {
+					r.Meters.RecordNoncesLag(0)
+				}
+
 				// If the contract has already the last version, no need to relay anything
 				if lastContractNonce >= latestNonce {
+					// the in-flight transactions can only be relaying attestations the contract already holds
+					if pruned := r.PendingTxs.PruneRelayed(lastContractNonce); pruned != 0 {
+						r.logger.Debug("removed relayed pending transactions", "count", pruned)
+					}
 					r.logger.Debug("waiting for new nonce", "current_contract_nonce", lastContractNonce)
 					return nil
 				}
@@ -123,8 +161,57 @@ func (r *Relayer) Start(ctx context.Context) error {
 					}
 				}
 
+				if r.Coordinator != nil && r.PendingTxs.Len() == 0 {
+					shouldRelay, err := r.shouldRelayNonce(ctx, lastContractNonce+1)
+					if err != nil {
+						return err
+					}
+					if !shouldRelay {
+						return nil
+					}
+				}
+
+				shouldRelay, err := r.shouldRelay(ctx, ethClient, latestNonce-lastContractNonce)
+				if err != nil {
+					return err
+				}
+				if !shouldRelay {
+					return nil
+				}
+
 				start := time.Now()
 
+				if r.Batch.Enabled() && latestNonce-lastContractNonce > 1 {
+					count, err := r.relayBatch(ctx, ethClient, lastContractNonce+1, latestNonce)
+					if err != nil {
+						return err
+					}
+
+					r.Meters.ProcessingTime.Record(ctx, time.Since(start).Seconds(), r.Meters.Attributes())
+					r.Meters.ProcessedNonces.Add(ctx, int64(count), r.Meters.Attributes())
+
+					if r.IsBackupRelayer {
+						backupRelayerShouldRelay = false
+					}
+					continue
+				}
+
+				if r.MaxInFlight > 1 {
+					count, err := r.relayPipelined(ctx, ethClient, lastContractNonce, latestNonce)
+					if err != nil {
+						return err
+					}
+
+					r.Meters.ProcessedNonces.Add(ctx, int64(count), r.Meters.Attributes())
+
+					if r.IsBackupRelayer && r.PendingTxs.Len() == 0 {
+						// the backup relayer gets back to the pending state once all the in-flight
+						// transactions are mined.
+						backupRelayerShouldRelay = false
+					}
+					continue
+				}
+
 				att, err := r.AppQuerier.QueryAttestationByNonce(ctx, lastContractNonce+1)
 				if err != nil {
 					return err
@@ -139,18 +226,25 @@ func (r *Relayer) Start(ctx context.Context) error {
 				}
 
 				tx, err := r.ProcessAttestation(ctx, opts, att)
+				if isStaleNonceError(err) {
+					// another relayer relayed the attestation in the meantime
+					r.logger.Info("attestation already relayed. skipping it", "nonce", att.GetNonce(), "reason", err.Error())
+					continue
+				}
 				if err != nil {
 					return err
 				}
+				r.announceRelay(ctx, att.GetNonce(), tx.Hash())
 
-				err = r.waitForTransactionAndRetryIfNeeded(ctx, ethClient, opts, tx)
+				receipt, err := r.waitForTransactionAndRetryIfNeeded(ctx, ethClient, opts, tx)
 				if err != nil {
-					r.Meters.Failures.Add(ctx, 1)
+					r.Meters.Failures.Add(ctx, 1, r.Meters.Attributes())
 					return err
 				}
 
-				r.Meters.ProcessingTime.Record(ctx, time.Since(start).Seconds())
-				r.Meters.ProcessedNonces.Add(ctx, 1)
+				r.recordEtherSpent(ctx, receipt, 1)
+				r.Meters.ProcessingTime.Record(ctx, time.Since(start).Seconds(), r.Meters.Attributes())
+				r.Meters.ProcessedNonces.Add(ctx, 1, r.Meters.Attributes())
 
 				if r.IsBackupRelayer {
 					// if the transaction was mined correctly, the relayer gets back to the pending
@@ -184,7 +278,92 @@ func (r *Relayer) Start(ctx context.Context) error {
 	}
 }
 
-func (r *Relayer) ProcessAttestation(ctx context.Context, opts *bind.TransactOpts, attI celestiatypes.AttestationRequestI) (*coregethtypes.Transaction, error) {
+// RecordHeartbeat records that the relay loop made progress at the provided time.
+func (r *Relayer) RecordHeartbeat(at time.Time) {
+	r.heartbeatMu.Lock()
+	defer r.heartbeatMu.Unlock()
+	r.heartbeat = at
+}
+
+// CheckHeartbeat returns an error if the relayer started but didn't record a heartbeat during
+// the last HeartbeatTimeout, extended by the backup relayer wait time and the longest transaction
+// wait, i.e. the relay loop is stuck or stopped.
+func (r *Relayer) CheckHeartbeat(now time.Time) error {
+	r.heartbeatMu.Lock()
+	defer r.heartbeatMu.Unlock()
+	if r.heartbeat.IsZero() {
+		return nil
+	}
+	timeout := HeartbeatTimeout + r.BackupRelayerWaitTime
+	if r.EVMClient != nil {
+		timeout += r.EVMClient.GasPolicy.RetryTimeout(r.RetryTimeout, maxPendingTxRetries)
+	}
+	if elapsed := now.Sub(r.heartbeat); elapsed > timeout {
+		return fmt.Errorf("%w: last heartbeat %s ago", ErrRelayLoopStalled, elapsed.Round(time.Second))
+	}
+	return nil
+}
+
+// checkBalance records the balance of the relayer account, and warns if it's lower than
+// the low balance threshold.
+// Failing to query the balance doesn't prevent relaying.
+func (r *Relayer) checkBalance(ctx context.Context, ethClient *ethclient.Client) {
+	balance, err := ethClient.BalanceAt(ctx, r.EVMClient.Signer.Address(), nil)
+	if err != nil {
+		r.logger.Debug("failed to query the relayer account balance", "err", err.Error())
+		return
+	}
+	ether := evm.WeiToEther(balance)
+	r.Meters.RecordBalance(ether)
+	if ether < r.LowBalanceThreshold {
+		r.logger.Error(
+			"relayer account balance is low. fund it to keep relaying attestations",
+			"account", r.EVMClient.Signer.Address().Hex(),
+			"balance_ether", ether,
+			"threshold_ether", r.LowBalanceThreshold,
+		)
+	}
+}
+
+// recordEtherSpent records the fees paid by the provided receipt transaction, divided equally
+// between the attestations it relays.
+func (r *Relayer) recordEtherSpent(ctx context.Context, receipt *coregethtypes.Receipt, attestationsCount int) {
+	if receipt == nil || receipt.EffectiveGasPrice == nil || attestationsCount == 0 {
+		return
+	}
+	fees := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	spent := evm.WeiToEther(fees) / float64(attestationsCount)
+	for i := 0; i < attestationsCount; i++ {
+		r.Meters.EtherSpent.Record(ctx, spent, r.Meters.Attributes())
+	}
+}
+
+// shouldRelay checks the current base fee against the gas policy to decide whether
+// to relay the pending attestations or to wait for the base fee to go down.
+func (r *Relayer) shouldRelay(ctx context.Context, ethClient *ethclient.Client, noncesBehind uint64) (bool, error) {
+	if r.EVMClient.GasPolicy.MaxBaseFee == 0 {
+		return true, nil
+	}
+	head, err := ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	if r.EVMClient.GasPolicy.ShouldRelay(head.BaseFee, noncesBehind) {
+		return true, nil
+	}
+	r.logger.Info(
+		"base fee is above the max base fee. waiting for it to go down before relaying",
+		"base_fee", head.BaseFee.String(),
+		"max_base_fee_gwei", r.EVMClient.GasPolicy.MaxBaseFee,
+		"nonces_behind", noncesBehind,
+	)
+	return false, nil
+}
+
+func (r *Relayer) ProcessAttestation(ctx context.Context, opts *bind.TransactOpts, attI celestiatypes.AttestationRequestI) (_ *coregethtypes.Transaction, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "Relayer.ProcessAttestation", telemetry.Nonce(attI.GetNonce()))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	previousValset, err := r.AppQuerier.QueryLastValsetBeforeNonce(ctx, attI.GetNonce())
 	if err != nil {
 		r.logger.Debug("failed to query the last valset before nonce (probably pruned). recovering via falling back to the P2P network", "err", err.Error())
@@ -208,15 +387,31 @@ func (r *Relayer) ProcessAttestation(ctx context.Context, opts *bind.TransactOpt
 		if err != nil {
 			return nil, err
 		}
+		confirmsStart := time.Now()
 		confirms, err := r.P2PQuerier.QueryTwoThirdsValsetConfirms(ctx, 30*time.Minute, 10*time.Second, att.Nonce, *previousValset, signBytes.Hex())
 		if err != nil {
 			return nil, err
 		}
+		r.RecordHeartbeat(time.Now())
+		r.Meters.ConfirmsCollectionTime.Record(ctx, time.Since(confirmsStart).Seconds(), r.Meters.Attributes())
 		err = r.SaveValsetSignaturesToStore(ctx, *att, confirms)
 		if err != nil {
 			return nil, err
 		}
 		tx, err := r.UpdateValidatorSet(ctx, opts, *att, att.TwoThirdsThreshold(), confirms)
+		if shouldRefetchSignatures(err) {
+			r.logger.Info("valset update simulation failed. re-fetching the signatures from the P2P network", "nonce", att.Nonce, "reason", err.Error())
+			confirms, err = r.P2PQuerier.WithoutSignatureStore().QueryTwoThirdsValsetConfirms(ctx, 30*time.Minute, 10*time.Second, att.Nonce, *previousValset, signBytes.Hex())
+			if err != nil {
+				return nil, err
+			}
+			r.RecordHeartbeat(time.Now())
+			err = r.SaveValsetSignaturesToStore(ctx, *att, confirms)
+			if err != nil {
+				return nil, err
+			}
+			tx, err = r.UpdateValidatorSet(ctx, opts, *att, att.TwoThirdsThreshold(), confirms)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -227,15 +422,31 @@ func (r *Relayer) ProcessAttestation(ctx context.Context, opts *bind.TransactOpt
 			return nil, err
 		}
 		dataRootHash := types.DataCommitmentTupleRootSignBytes(big.NewInt(int64(att.Nonce)), commitment)
+		confirmsStart := time.Now()
 		confirms, err := r.P2PQuerier.QueryTwoThirdsDataCommitmentConfirms(ctx, 30*time.Minute, 10*time.Second, *previousValset, att.Nonce, dataRootHash.Hex())
 		if err != nil {
 			return nil, err
 		}
+		r.RecordHeartbeat(time.Now())
+		r.Meters.ConfirmsCollectionTime.Record(ctx, time.Since(confirmsStart).Seconds(), r.Meters.Attributes())
 		err = r.SaveDataCommitmentSignaturesToStore(ctx, *att, dataRootHash.String(), confirms)
 		if err != nil {
 			return nil, err
 		}
-		tx, err := r.SubmitDataRootTupleRoot(opts, *att, *previousValset, commitment.String(), confirms)
+		tx, err := r.SubmitDataRootTupleRoot(ctx, opts, *att, *previousValset, commitment.String(), confirms)
+		if shouldRefetchSignatures(err) {
+			r.logger.Info("data commitment submission simulation failed. re-fetching the signatures from the P2P network", "nonce", att.Nonce, "reason", err.Error())
+			confirms, err = r.P2PQuerier.WithoutSignatureStore().QueryTwoThirdsDataCommitmentConfirms(ctx, 30*time.Minute, 10*time.Second, *previousValset, att.Nonce, dataRootHash.Hex())
+			if err != nil {
+				return nil, err
+			}
+			r.RecordHeartbeat(time.Now())
+			err = r.SaveDataCommitmentSignaturesToStore(ctx, *att, dataRootHash.String(), confirms)
+			if err != nil {
+				return nil, err
+			}
+			tx, err = r.SubmitDataRootTupleRoot(ctx, opts, *att, *previousValset, commitment.String(), confirms)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -284,7 +495,16 @@ func (r *Relayer) UpdateValidatorSet(
 	valset celestiatypes.Valset,
 	newThreshold uint64,
 	confirms []types.ValsetConfirm,
-) (*coregethtypes.Transaction, error) {
+) (_ *coregethtypes.Transaction, err error) {
+	traceParents := make([]string, len(confirms))
+	for i, c := range confirms {
+		traceParents[i] = c.TraceParent
+	}
+	// linking the span to the orchestrators ones that signed the confirms.
+	ctx, span := telemetry.StartSpanWithLinks(ctx, "Relayer.UpdateValidatorSet", telemetry.LinksFromTraceParents(traceParents), telemetry.Nonce(valset.Nonce))
+	defer func() { telemetry.EndSpan(span, err) }()
+	opts = withContext(ctx, opts)
+
 	var currentValset celestiatypes.Valset
 	if valset.Nonce == 1 {
 		currentValset = valset
@@ -307,6 +527,21 @@ func (r *Relayer) UpdateValidatorSet(
 		return nil, err
 	}
 
+	if r.shouldSimulate(opts) {
+		err = r.EVMClient.SimulateUpdateValidatorSet(
+			ctx,
+			opts.From,
+			valset.Nonce,
+			newThreshold,
+			currentValset,
+			valset,
+			sigs,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	tx, err := r.EVMClient.UpdateValidatorSet(
 		opts,
 		valset.Nonce,
@@ -318,16 +553,27 @@ func (r *Relayer) UpdateValidatorSet(
 	if err != nil {
 		return nil, err
 	}
+	r.Meters.CollectedVotingPower.Record(ctx, signedPowerRatio(sigsMap, currentValset), r.Meters.Attributes())
 	return tx, nil
 }
 
 func (r *Relayer) SubmitDataRootTupleRoot(
+	ctx context.Context,
 	opts *bind.TransactOpts,
 	dataCommitment celestiatypes.DataCommitment,
 	currentValset celestiatypes.Valset,
 	commitment string,
 	confirms []types.DataCommitmentConfirm,
-) (*coregethtypes.Transaction, error) {
+) (_ *coregethtypes.Transaction, err error) {
+	traceParents := make([]string, len(confirms))
+	for i, c := range confirms {
+		traceParents[i] = c.TraceParent
+	}
+	// linking the span to the orchestrators ones that signed the confirms.
+	ctx, span := telemetry.StartSpanWithLinks(ctx, "Relayer.SubmitDataRootTupleRoot", telemetry.LinksFromTraceParents(traceParents), telemetry.Nonce(dataCommitment.Nonce))
+	defer func() { telemetry.EndSpan(span, err) }()
+	opts = withContext(ctx, opts)
+
 	sigsMap := make(map[string]string)
 	// to fetch the signatures easily by eth address
 	for _, c := range confirms {
@@ -345,6 +591,20 @@ func (r *Relayer) SubmitDataRootTupleRoot(
 		dataCommitment.EndBlock,
 	))
 
+	if r.shouldSimulate(opts) {
+		err = r.EVMClient.SimulateSubmitDataRootTupleRoot(
+			ctx,
+			opts.From,
+			ethcmn.HexToHash(commitment),
+			dataCommitment.Nonce,
+			currentValset,
+			sigs,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	tx, err := r.EVMClient.SubmitDataRootTupleRoot(
 		opts,
 		ethcmn.HexToHash(commitment),
@@ -355,9 +615,52 @@ func (r *Relayer) SubmitDataRootTupleRoot(
 	if err != nil {
 		return nil, err
 	}
+	r.Meters.CollectedVotingPower.Record(ctx, signedPowerRatio(sigsMap, currentValset), r.Meters.Attributes())
 	return tx, nil
 }
 
+// signedPowerRatio returns the ratio of the valset power whose signatures are provided.
+func signedPowerRatio(signatures map[string]string, valset celestiatypes.Valset) float64 {
+	var signedPower, totalPower uint64
+	for _, val := range valset.Members {
+		totalPower += val.Power
+		if _, has := signatures[val.EvmAddress]; has {
+			signedPower += val.Power
+		}
+	}
+	if totalPower == 0 {
+		return 0
+	}
+	return float64(signedPower) / float64(totalPower)
+}
+
+// withContext returns a copy of the transaction options using the provided context.
+func withContext(ctx context.Context, opts *bind.TransactOpts) *bind.TransactOpts {
+	optsCopy := *opts
+	optsCopy.Context = ctx
+	return &optsCopy
+}
+
+// shouldSimulate returns true if the transaction should be simulated against the pending block
+// before being broadcast.
+// Transactions that are only used to build call data, or that follow in-flight ones, are not
+// simulated as they depend on state changes that are not applied yet.
+func (r *Relayer) shouldSimulate(opts *bind.TransactOpts) bool {
+	return !opts.NoSend && r.PendingTxs.Len() == 0
+}
+
+// isStaleNonceError returns true if the error means that the attestation nonce is not the one
+// expected by the contract, i.e. it was already relayed.
+func isStaleNonceError(err error) bool {
+	return stderrors.Is(err, evm.ErrInvalidDataRootTupleRootNonce) || stderrors.Is(err, evm.ErrInvalidValidatorSetNonce)
+}
+
+// shouldRefetchSignatures returns true if the error means that the provided signatures
+// are not enough to relay the attestation.
+func shouldRefetchSignatures(err error) bool {
+	return stderrors.Is(err, evm.ErrInsufficientVotingPower) || stderrors.Is(err, evm.ErrInvalidSignature)
+}
+
 func (r *Relayer) SaveValsetSignaturesToStore(ctx context.Context, att celestiatypes.Valset, confirms []types.ValsetConfirm) error {
 	batch, err := r.SignatureStore.Batch(ctx)
 	if err != nil {
@@ -414,98 +717,94 @@ func (r *Relayer) SaveDataCommitmentSignaturesToStore(ctx context.Context, att c
 
 // waitForTransactionAndRetryIfNeeded waits for transaction to be mined. If it's not mined in the provided timeout, it will
 // attempt to speed it up via updating the gas price.
-func (r *Relayer) waitForTransactionAndRetryIfNeeded(ctx context.Context, ethClient *ethclient.Client, opts *bind.TransactOpts, tx *coregethtypes.Transaction) error {
+func (r *Relayer) waitForTransactionAndRetryIfNeeded(ctx context.Context, ethClient *ethclient.Client, opts *bind.TransactOpts, tx *coregethtypes.Transaction) (*coregethtypes.Receipt, error) {
 	r.logger.Debug("submitted transaction", "hash", tx.Hash().Hex(), "gas_price", tx.GasPrice().Uint64())
 	newTx := tx
 	for i := 0; i < 10; i++ {
-		_, err := r.EVMClient.WaitForTransaction(ctx, ethClient, newTx, r.RetryTimeout)
+		r.RecordHeartbeat(time.Now())
+		receipt, err := r.EVMClient.WaitForTransaction(ctx, ethClient, newTx, r.EVMClient.GasPolicy.RetryTimeout(r.RetryTimeout, i))
 		if err != nil {
 			if stderrors.Is(err, context.DeadlineExceeded) {
-				var rawTx *coregethtypes.Transaction
-				if tx.GasPrice() != nil {
-					rawTx, err = createSpeededUpLegacyTransaction(ctx, ethClient, newTx)
-					if err != nil {
-						return err
-					}
-					if rawTx.GasPrice().Cmp(newTx.GasPrice()) <= 0 {
-						// no need to resend the transaction if the suggested gas price is lower than the original one
-						continue
-					}
-				} else if tx.GasTipCap() != nil && tx.GasFeeCap() != nil {
-					rawTx, err = createSpeededUpDynamicTransaction(ctx, ethClient, newTx)
-					if err != nil {
-						return err
-					}
-					if rawTx.GasFeeCap().Cmp(newTx.GasFeeCap()) <= 0 {
-						// no need to resend the transaction if the suggested gas price is lower than the original one
-						continue
-					}
-				} else {
-					// Only query for basefee if gasPrice not specified
-					if head, errHead := ethClient.HeaderByNumber(ctx, nil); errHead != nil {
-						return errHead
-					} else if head.BaseFee != nil {
-						rawTx, err = createSpeededUpDynamicTransaction(ctx, ethClient, newTx)
-						if err != nil {
-							return err
-						}
-						if rawTx.GasFeeCap().Cmp(newTx.GasFeeCap()) <= 0 {
-							// no need to resend the transaction if the suggested gas price is lower than the original one
-							continue
-						}
-					} else {
-						// Chain is not London ready -> use legacy transaction
-						rawTx, err = createSpeededUpLegacyTransaction(ctx, ethClient, newTx)
-						if err != nil {
-							return err
-						}
-						if rawTx.GasPrice().Cmp(newTx.GasPrice()) <= 0 {
-							// no need to resend the transaction if the suggested gas price is lower than the original one
-							continue
-						}
-					}
-				}
-				r.logger.Debug("transaction still not included. updating the gas price", "retry_number", i)
-				signedTx, err := opts.Signer(opts.From, rawTx)
+				signedTx, err := speedUpTransaction(ctx, ethClient, r.EVMClient.GasPolicy, opts, newTx)
 				if err != nil {
-					return err
+					return nil, err
+				}
+				if signedTx == nil {
+					// no need to resend the transaction if the gas price cannot be increased
+					r.logger.Debug("gas price cap reached. waiting for the transaction to be included", "retry_number", i)
+					continue
 				}
+				r.logger.Debug("transaction still not included. updating the gas price", "retry_number", i)
 				err = ethClient.SendTransaction(ctx, signedTx)
 				r.logger.Info("submitted speed up transaction", "hash", signedTx.Hash().Hex(), "new_gas_price", signedTx.GasPrice().Uint64())
 				if err != nil {
 					r.logger.Debug("response of sending speed up transaction", "resp", err.Error())
+				} else {
+					r.Meters.SpeedUps.Add(ctx, 1, r.Meters.Attributes())
 				}
 			} else {
-				return err
+				return nil, err
 			}
 		} else {
-			return nil
+			return receipt, nil
 		}
 	}
-	return ErrTransactionStillPending
+	return nil, ErrTransactionStillPending
 }
 
-// createSpeededUpDynamicTransaction update the EIP1559 dynamic transaction with the current gas price.
-func createSpeededUpDynamicTransaction(ctx context.Context, ethClient *ethclient.Client, newTx *coregethtypes.Transaction) (*coregethtypes.Transaction, error) {
+// speedUpTransaction creates and signs a copy of the provided transaction with the gas price
+// defined by the gas policy.
+// Returns nil if the new gas price is not higher than the transaction one, e.g. if the cap is reached.
+func speedUpTransaction(
+	ctx context.Context,
+	backend bind.ContractBackend,
+	gasPolicy evm.GasPolicy,
+	opts *bind.TransactOpts,
+	tx *coregethtypes.Transaction,
+) (*coregethtypes.Transaction, error) {
+	var rawTx *coregethtypes.Transaction
+	var err error
+	if tx.Type() == coregethtypes.DynamicFeeTxType {
+		rawTx, err = createSpeededUpDynamicTransaction(ctx, backend, gasPolicy, tx)
+		if err != nil {
+			return nil, err
+		}
+		if rawTx.GasFeeCap().Cmp(tx.GasFeeCap()) <= 0 {
+			return nil, nil
+		}
+	} else {
+		rawTx, err = createSpeededUpLegacyTransaction(ctx, backend, gasPolicy, tx)
+		if err != nil {
+			return nil, err
+		}
+		if rawTx.GasPrice().Cmp(tx.GasPrice()) <= 0 {
+			return nil, nil
+		}
+	}
+	return opts.Signer(opts.From, rawTx)
+}
+
+// createSpeededUpDynamicTransaction update the EIP1559 dynamic transaction with the current gas price
+// bumped following the gas policy.
+func createSpeededUpDynamicTransaction(
+	ctx context.Context,
+	backend bind.ContractBackend,
+	gasPolicy evm.GasPolicy,
+	newTx *coregethtypes.Transaction,
+) (*coregethtypes.Transaction, error) {
 	// Estimate TipCap
-	gasTipCap, err := ethClient.SuggestGasTipCap(ctx)
+	gasTipCap, err := backend.SuggestGasTipCap(ctx)
 	if err != nil {
 		return nil, err
 	}
-	lastKnownHeader, err := ethClient.HeaderByNumber(ctx, nil)
+	lastKnownHeader, err := backend.HeaderByNumber(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
-	// Estimate FeeCap
-	gasFeeCap := new(big.Int).Add(
-		gasTipCap,
-		// the DefaultElasticityMultiplier is used to define the wiggle room for the gas
-		// in EIP1559
-		new(big.Int).Mul(lastKnownHeader.BaseFee, big.NewInt(params.DefaultElasticityMultiplier)),
-	)
-	if gasFeeCap.Cmp(gasTipCap) < 0 {
-		return nil, fmt.Errorf("maxFeePerGas (%v) < maxPriorityFeePerGas (%v)", gasFeeCap, gasTipCap)
+	if lastKnownHeader.BaseFee == nil {
+		return nil, fmt.Errorf("cannot speed up dynamic transaction: chain doesn't support EIP-1559")
 	}
+	gasTipCap, gasFeeCap := gasPolicy.SpeedUpDynamicFees(newTx.GasTipCap(), newTx.GasFeeCap(), gasTipCap, lastKnownHeader.BaseFee)
 
 	dynamicTransaction := toDynamicTransaction(newTx)
 	dynamicTransaction.GasTipCap = gasTipCap
@@ -513,14 +812,20 @@ func createSpeededUpDynamicTransaction(ctx context.Context, ethClient *ethclient
 	return coregethtypes.NewTx(dynamicTransaction), nil
 }
 
-// createSpeededUpLegacyTransaction update the legacy transaction with the new gas price.
-func createSpeededUpLegacyTransaction(ctx context.Context, ethClient *ethclient.Client, newTx *coregethtypes.Transaction) (tx *coregethtypes.Transaction, err error) {
-	newGasPrice, err := ethClient.SuggestGasPrice(ctx)
+// createSpeededUpLegacyTransaction update the legacy transaction with the new gas price
+// bumped following the gas policy.
+func createSpeededUpLegacyTransaction(
+	ctx context.Context,
+	backend bind.ContractBackend,
+	gasPolicy evm.GasPolicy,
+	newTx *coregethtypes.Transaction,
+) (tx *coregethtypes.Transaction, err error) {
+	newGasPrice, err := backend.SuggestGasPrice(ctx)
 	if err != nil {
 		return nil, err
 	}
 	legacyTx := toLegacyTransaction(newTx)
-	legacyTx.GasPrice = newGasPrice
+	legacyTx.GasPrice = gasPolicy.SpeedUpGasPrice(newTx.GasPrice(), newGasPrice)
 	return coregethtypes.NewTx(legacyTx), nil
 }
 
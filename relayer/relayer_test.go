@@ -10,6 +10,8 @@ import (
 	"github.com/celestiaorg/celestia-app/app"
 	"github.com/celestiaorg/celestia-app/app/encoding"
 	"github.com/celestiaorg/celestia-app/test/util/testnode"
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/celestiaorg/orchestrator-relayer/relayer"
 	blobstreamtesting "github.com/celestiaorg/orchestrator-relayer/testing"
 
 	"github.com/celestiaorg/orchestrator-relayer/p2p"
@@ -49,12 +51,35 @@ func (s *RelayerTestSuite) TestProcessAttestation() {
 	assert.Equal(t, att.Nonce, lastNonce)
 
 	// check if the relayed data commitment confirm is saved to relayer store
-	key := datastore.NewKey(p2p.GetDataCommitmentConfirmKey(att.Nonce, s.Orchestrator.EvmAccount.Address.Hex(), dataRootTupleRoot.Hex()))
+	key := datastore.NewKey(p2p.GetDataCommitmentConfirmKey(att.Nonce, s.Orchestrator.EvmSigner.Address().Hex(), dataRootTupleRoot.Hex()))
 	has, err := s.Relayer.SignatureStore.Has(ctx, key)
 	require.NoError(t, err)
 	assert.True(t, has)
 }
 
+func (s *RelayerTestSuite) TestProcessAttestationStaleNonce() {
+	t := s.T()
+	ctx := context.Background()
+	lastNonce, err := s.Relayer.EVMClient.StateLastEventNonce(nil)
+	require.NoError(t, err)
+	att := s.signDataCommitments(ctx, lastNonce+1, 1)[0]
+
+	tx, err := s.Relayer.ProcessAttestation(ctx, s.Node.EVMChain.Auth, att)
+	require.NoError(t, err)
+	receipt, err := s.Relayer.EVMClient.WaitForTransaction(ctx, s.Node.EVMChain.Backend, tx, 20*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), receipt.Status)
+
+	// relaying the same attestation again should fail during the simulation without broadcasting anything
+	accountNonce, err := s.Node.EVMChain.Backend.PendingNonceAt(ctx, s.Node.EVMChain.Auth.From)
+	require.NoError(t, err)
+	_, err = s.Relayer.ProcessAttestation(ctx, s.Node.EVMChain.Auth, att)
+	assert.ErrorIs(t, err, evm.ErrInvalidDataRootTupleRootNonce)
+	newAccountNonce, err := s.Node.EVMChain.Backend.PendingNonceAt(ctx, s.Node.EVMChain.Auth.From)
+	require.NoError(t, err)
+	assert.Equal(t, accountNonce, newAccountNonce)
+}
+
 func TestUseValsetFromP2P(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -155,3 +180,17 @@ func (s *RelayerTestSuite) TestQueryValsetFromP2P() {
 
 	assert.True(t, bytes.Equal(appVSHash.Bytes(), p2pVSHash.Bytes()))
 }
+
+func TestRelayerCheckHeartbeat(t *testing.T) {
+	r := &relayer.Relayer{BackupRelayerWaitTime: time.Minute}
+	now := time.Now()
+
+	// the relay loop is considered alive until it starts
+	assert.NoError(t, r.CheckHeartbeat(now))
+
+	r.RecordHeartbeat(now)
+	// the confirms wait shouldn't make the relayer look stalled
+	assert.NoError(t, r.CheckHeartbeat(now.Add(30*time.Minute)))
+	assert.NoError(t, r.CheckHeartbeat(now.Add(relayer.HeartbeatTimeout+time.Minute)))
+	assert.ErrorIs(t, r.CheckHeartbeat(now.Add(relayer.HeartbeatTimeout+time.Minute+time.Second)), relayer.ErrRelayLoopStalled)
+}
@@ -0,0 +1,126 @@
+package relayer
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+const (
+	// ValsetSignatureType the type of the signatures over valsets.
+	ValsetSignatureType = "valset"
+	// DataCommitmentSignatureType the type of the signatures over data commitments.
+	DataCommitmentSignatureType = "data_commitment"
+)
+
+// StoredSignature a confirm saved to the signature store when relaying an attestation.
+type StoredSignature struct {
+	// Type either ValsetSignatureType or DataCommitmentSignatureType.
+	Type  string `json:"type"`
+	Nonce uint64 `json:"nonce"`
+	// EvmAddress the EVM address of the orchestrator that signed the attestation.
+	EvmAddress string `json:"evm_address"`
+	// Digest the valset sign bytes, or the data root tuple root, that was signed.
+	Digest    string `json:"digest"`
+	Signature string `json:"signature"`
+}
+
+// SignaturesFilter filters the signatures read from the signature store.
+type SignaturesFilter struct {
+	// FromNonce the first nonce to include. If 0, the signatures are not filtered by their first nonce.
+	FromNonce uint64
+	// ToNonce the last nonce to include. If 0, the signatures are not filtered by their last nonce.
+	ToNonce uint64
+	// EvmAddress if not empty, only the signatures of this orchestrator are included.
+	// The addresses are compared case-insensitively.
+	EvmAddress string
+}
+
+// matches returns true if the signature is selected by the filter.
+func (f SignaturesFilter) matches(nonce uint64, evmAddress string) bool {
+	if f.FromNonce != 0 && nonce < f.FromNonce {
+		return false
+	}
+	if f.ToNonce != 0 && nonce > f.ToNonce {
+		return false
+	}
+	return f.EvmAddress == "" || strings.EqualFold(f.EvmAddress, evmAddress)
+}
+
+// QuerySignatures reads the signatures selected by the filter from the signature store.
+// The signatures are sorted by nonce, then by EVM address.
+func QuerySignatures(ctx context.Context, sigStore datastore.Read, filter SignaturesFilter) ([]StoredSignature, error) {
+	// the datastore prefixes only match whole key segments, so the namespaces are iterated and
+	// the nonces filtered after parsing the keys.
+	prefixes := []string{"/" + p2p.ValsetConfirmNamespace, "/" + p2p.DataCommitmentConfirmNamespace}
+	signatures := make([]StoredSignature, 0)
+	for _, prefix := range prefixes {
+		results, err := sigStore.Query(ctx, query.Query{Prefix: prefix})
+		if err != nil {
+			return nil, err
+		}
+		for result := range results.Next() {
+			if result.Error != nil {
+				_ = results.Close()
+				return nil, result.Error
+			}
+			signature, err := parseStoredSignature(result.Entry)
+			if err != nil {
+				_ = results.Close()
+				return nil, err
+			}
+			if filter.matches(signature.Nonce, signature.EvmAddress) {
+				signatures = append(signatures, signature)
+			}
+		}
+		err = results.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(signatures, func(i, j int) bool {
+		if signatures[i].Nonce != signatures[j].Nonce {
+			return signatures[i].Nonce < signatures[j].Nonce
+		}
+		return signatures[i].EvmAddress < signatures[j].EvmAddress
+	})
+	return signatures, nil
+}
+
+// parseStoredSignature parses a signature store entry.
+func parseStoredSignature(entry query.Entry) (StoredSignature, error) {
+	namespace, nonce, evmAddress, digest, err := p2p.ParseKey(entry.Key)
+	if err != nil {
+		return StoredSignature{}, err
+	}
+	signature := StoredSignature{
+		Nonce:      nonce,
+		EvmAddress: evmAddress,
+		Digest:     digest,
+	}
+	switch namespace {
+	case p2p.ValsetConfirmNamespace:
+		confirm, err := types.UnmarshalValsetConfirm(entry.Value)
+		if err != nil {
+			return StoredSignature{}, err
+		}
+		signature.Type = ValsetSignatureType
+		signature.Signature = confirm.Signature
+	case p2p.DataCommitmentConfirmNamespace:
+		confirm, err := types.UnmarshalDataCommitmentConfirm(entry.Value)
+		if err != nil {
+			return StoredSignature{}, err
+		}
+		signature.Type = DataCommitmentSignatureType
+		signature.Signature = confirm.Signature
+	default:
+		return StoredSignature{}, p2p.ErrInvalidConfirmNamespace
+	}
+	return signature, nil
+}
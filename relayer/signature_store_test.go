@@ -0,0 +1,87 @@
+package relayer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/p2p"
+	"github.com/celestiaorg/orchestrator-relayer/relayer"
+	blobstreamtypes "github.com/celestiaorg/orchestrator-relayer/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuerySignatures(t *testing.T) {
+	ctx := context.Background()
+	sigStore := datastore.NewMapDatastore()
+
+	putDataCommitmentConfirm := func(nonce uint64, evmAddr string, signature string) {
+		value, err := blobstreamtypes.MarshalDataCommitmentConfirm(blobstreamtypes.DataCommitmentConfirm{Signature: signature, EthAddress: evmAddr})
+		require.NoError(t, err)
+		require.NoError(t, sigStore.Put(ctx, datastore.NewKey(p2p.GetDataCommitmentConfirmKey(nonce, evmAddr, "0xroot")), value))
+	}
+	putDataCommitmentConfirm(2, "0xB", "sig2b")
+	putDataCommitmentConfirm(2, "0xA", "sig2a")
+	putDataCommitmentConfirm(16, "0xA", "sig16a")
+	putDataCommitmentConfirm(20, "0xA", "sig20a")
+	value, err := blobstreamtypes.MarshalValsetConfirm(blobstreamtypes.ValsetConfirm{Signature: "sig1a", EthAddress: "0xA"})
+	require.NoError(t, err)
+	require.NoError(t, sigStore.Put(ctx, datastore.NewKey(p2p.GetValsetConfirmKey(1, "0xA", "0xsignbytes")), value))
+	// other namespaces are ignored
+	require.NoError(t, sigStore.Put(ctx, datastore.NewKey(p2p.GetRelayAnnouncementKey(1, "0xA", 5)), []byte("{}")))
+
+	tests := []struct {
+		name     string
+		filter   relayer.SignaturesFilter
+		expected []string
+	}{
+		{
+			name:     "all signatures sorted by nonce then address",
+			filter:   relayer.SignaturesFilter{},
+			expected: []string{"sig1a", "sig2a", "sig2b", "sig16a", "sig20a"},
+		},
+		{
+			name:     "nonces range",
+			filter:   relayer.SignaturesFilter{FromNonce: 2, ToNonce: 16},
+			expected: []string{"sig2a", "sig2b", "sig16a"},
+		},
+		{
+			name:     "single nonce",
+			filter:   relayer.SignaturesFilter{FromNonce: 2, ToNonce: 2},
+			expected: []string{"sig2a", "sig2b"},
+		},
+		{
+			name:     "evm address",
+			filter:   relayer.SignaturesFilter{EvmAddress: "0xb"},
+			expected: []string{"sig2b"},
+		},
+		{
+			name:     "no match",
+			filter:   relayer.SignaturesFilter{FromNonce: 21},
+			expected: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signatures, err := relayer.QuerySignatures(ctx, sigStore, tt.filter)
+			require.NoError(t, err)
+			actual := make([]string, len(signatures))
+			for i, signature := range signatures {
+				actual[i] = signature.Signature
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+
+	signatures, err := relayer.QuerySignatures(ctx, sigStore, relayer.SignaturesFilter{FromNonce: 1, ToNonce: 1})
+	require.NoError(t, err)
+	require.Len(t, signatures, 1)
+	assert.Equal(t, relayer.StoredSignature{
+		Type:       relayer.ValsetSignatureType,
+		Nonce:      1,
+		EvmAddress: "0xA",
+		Digest:     "0xsignbytes",
+		Signature:  "sig1a",
+	}, signatures[0])
+}
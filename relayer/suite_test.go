@@ -2,10 +2,13 @@ package relayer_test
 
 import (
 	"context"
+	"math/big"
 	"testing"
 	"time"
 
+	"github.com/celestiaorg/celestia-app/x/qgb/types"
 	"github.com/celestiaorg/orchestrator-relayer/orchestrator"
+	blobstreamtypes "github.com/celestiaorg/orchestrator-relayer/types"
 
 	"github.com/celestiaorg/orchestrator-relayer/relayer"
 	blobstreamtesting "github.com/celestiaorg/orchestrator-relayer/testing"
@@ -42,6 +45,23 @@ func (s *RelayerTestSuite) TearDownSuite() {
 	s.Node.Close()
 }
 
+// signDataCommitments creates the provided number of consecutive data commitments starting
+// from the first nonce, and signs them using the test orchestrator.
+func (s *RelayerTestSuite) signDataCommitments(ctx context.Context, firstNonce uint64, count uint64) []types.AttestationRequestI {
+	t := s.T()
+	atts := make([]types.AttestationRequestI, 0, count)
+	for i := uint64(0); i < count; i++ {
+		att := types.NewDataCommitment(firstNonce+i, 100*(i+1), 100*(i+2), time.Now())
+		commitment, err := s.Orchestrator.TmQuerier.QueryCommitment(ctx, att.BeginBlock, att.EndBlock)
+		require.NoError(t, err)
+		dataRootTupleRoot := blobstreamtypes.DataCommitmentTupleRootSignBytes(big.NewInt(int64(att.Nonce)), commitment)
+		err = s.Orchestrator.ProcessDataCommitmentEvent(ctx, *att, dataRootTupleRoot)
+		require.NoError(t, err)
+		atts = append(atts, att)
+	}
+	return atts
+}
+
 func TestRelayer(t *testing.T) {
 	suite.Run(t, new(RelayerTestSuite))
 }
@@ -17,6 +17,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
 	"github.com/celestiaorg/orchestrator-relayer/types"
 
 	"github.com/celestiaorg/celestia-app/app/encoding"
@@ -65,7 +66,10 @@ func (aq *AppQuerier) Stop() error {
 }
 
 // QueryAttestationByNonce query an attestation by nonce from the state machine.
-func (aq *AppQuerier) QueryAttestationByNonce(ctx context.Context, nonce uint64) (celestiatypes.AttestationRequestI, error) {
+func (aq *AppQuerier) QueryAttestationByNonce(ctx context.Context, nonce uint64) (_ celestiatypes.AttestationRequestI, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "AppQuerier.QueryAttestationByNonce", telemetry.Nonce(nonce))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	queryClient := celestiatypes.NewQueryClient(aq.clientConn)
 
 	atResp, err := queryClient.AttestationRequestByNonce(
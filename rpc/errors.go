@@ -5,4 +5,5 @@ import "errors"
 var (
 	ErrCouldntReachSpecifiedHeight = errors.New("couldn't reach specified height")
 	ErrNotFound                    = errors.New("not found")
+	ErrNodeCatchingUp              = errors.New("node is catching up")
 )
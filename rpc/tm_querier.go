@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
 	"github.com/tendermint/tendermint/libs/bytes"
 	tmlog "github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/rpc/client"
 	"github.com/tendermint/tendermint/rpc/client/http"
 	coretypes "github.com/tendermint/tendermint/rpc/core/types"
 	"github.com/tendermint/tendermint/types"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // TmQuerier queries tendermint for commitments and events.
@@ -56,7 +58,15 @@ func (tq *TmQuerier) WithClientConn(trpc client.Client) {
 	tq.clientConn = trpc
 }
 
-func (tq *TmQuerier) QueryCommitment(ctx context.Context, beginBlock uint64, endBlock uint64) (bytes.HexBytes, error) {
+func (tq *TmQuerier) QueryCommitment(ctx context.Context, beginBlock uint64, endBlock uint64) (_ bytes.HexBytes, err error) {
+	ctx, span := telemetry.StartSpan(
+		ctx,
+		"TmQuerier.QueryCommitment",
+		attribute.Int64("begin_block", int64(beginBlock)),
+		attribute.Int64("end_block", int64(endBlock)),
+	)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	dcResp, err := tq.clientConn.DataCommitment(ctx, beginBlock, endBlock)
 	if err != nil {
 		return nil, err
@@ -138,6 +148,15 @@ func (tq *TmQuerier) IsRunning(ctx context.Context) bool {
 	return err == nil
 }
 
+// IsCatchingUp returns true if the node is still syncing with the network.
+func (tq *TmQuerier) IsCatchingUp(ctx context.Context) (bool, error) {
+	status, err := tq.clientConn.Status(ctx)
+	if err != nil {
+		return false, err
+	}
+	return status.SyncInfo.CatchingUp, nil
+}
+
 func (tq *TmQuerier) Reconnect() error {
 	_ = tq.clientConn.Stop()
 	newConnection, err := http.New(tq.tendermintRPC, "/websocket")
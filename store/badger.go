@@ -6,14 +6,16 @@ import (
 )
 
 // DefaultBadgerOptions creates the default options for badger.
-// For our purposes, we don't want the store to perform any garbage collection or
+// For our purposes, we don't want the store to perform any periodic garbage collection or
 // expire newly added keys after a certain period, because:
 // 1. the data in the store will be light.
 // 2. we want to keep the data, i.e. confirms, for the longest time possible to be able
 // to retrieve them if needed.
+// The garbage collection, using the discard ratio, is only run after pruning the entries
+// outside the retention window.
 func DefaultBadgerOptions(path string) *badger.Options {
 	return &badger.Options{
-		GcDiscardRatio: 0,
+		GcDiscardRatio: 0.5,
 		GcInterval:     0,
 		GcSleep:        0,
 		TTL:            0,
@@ -0,0 +1,181 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	// HealthzEndpoint the endpoint reporting whether the process is alive. It only runs the
+	// liveness checks, and failing it means that the process should be restarted.
+	HealthzEndpoint = "/healthz"
+	// ReadyzEndpoint the endpoint reporting whether the process is ready to do its job. It runs
+	// both the liveness and readiness checks.
+	ReadyzEndpoint = "/readyz"
+
+	// healthCheckTimeout the maximum time a single health check can take.
+	healthCheckTimeout = 5 * time.Second
+	// healthCheckOK the status reported for the passing health checks.
+	healthCheckOK = "ok"
+)
+
+// HealthConfig defines the configuration options for the health endpoints.
+type HealthConfig struct {
+	Enable     bool   `mapstructure:"enable" json:"enable"`
+	ListenAddr string `mapstructure:"listen-addr" json:"listen-addr"`
+}
+
+// HealthCheck checks the state of a component. Returns an error if the component is unhealthy.
+type HealthCheck func(ctx context.Context) error
+
+// HealthStatus the response of the health endpoints.
+type HealthStatus struct {
+	Healthy bool `json:"healthy"`
+	// Checks the result of every check, indexed by name: "ok" if it passed, the error otherwise.
+	Checks map[string]string `json:"checks"`
+}
+
+type healthCheckResult struct {
+	name string
+	err  error
+}
+
+// HealthServer serves the health and readiness endpoints, to be used as liveness and readiness probes.
+type HealthServer struct {
+	logger tmlog.Logger
+	server *http.Server
+
+	mu              sync.Mutex
+	livenessChecks  map[string]HealthCheck
+	readinessChecks map[string]HealthCheck
+}
+
+// NewHealthServer creates a new health server listening on the provided address.
+func NewHealthServer(logger tmlog.Logger, listenAddr string) *HealthServer {
+	s := &HealthServer{
+		logger:          logger,
+		livenessChecks:  make(map[string]HealthCheck),
+		readinessChecks: make(map[string]HealthCheck),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(HealthzEndpoint, s.handleHealthz)
+	mux.HandleFunc(ReadyzEndpoint, s.handleReadyz)
+	s.server = &http.Server{
+		Addr:              listenAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s
+}
+
+// AddLivenessCheck adds a check to both the health and readiness endpoints.
+// Adding a check with an existing name replaces it.
+func (s *HealthServer) AddLivenessCheck(name string, check HealthCheck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.livenessChecks[name] = check
+}
+
+// AddReadinessCheck adds a check to the readiness endpoint.
+// Adding a check with an existing name replaces it.
+func (s *HealthServer) AddReadinessCheck(name string, check HealthCheck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readinessChecks[name] = check
+}
+
+// Handler returns the health endpoints handler.
+func (s *HealthServer) Handler() http.Handler {
+	return s.server.Handler
+}
+
+// Start starts listening on the health server address and serves the requests in the background.
+func (s *HealthServer) Start() error {
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("health server stopped", "err", err.Error())
+		}
+	}()
+	s.logger.Info("health server started", "listen_addr", listener.Addr().String())
+	return nil
+}
+
+// Stop gracefully shuts down the health server.
+func (s *HealthServer) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+// Check runs the liveness checks, along with the readiness ones if includeReadiness is set.
+func (s *HealthServer) Check(ctx context.Context, includeReadiness bool) HealthStatus {
+	s.mu.Lock()
+	checks := make(map[string]HealthCheck, len(s.livenessChecks)+len(s.readinessChecks))
+	for name, check := range s.livenessChecks {
+		checks[name] = check
+	}
+	if includeReadiness {
+		for name, check := range s.readinessChecks {
+			checks[name] = check
+		}
+	}
+	s.mu.Unlock()
+
+	status := HealthStatus{
+		Healthy: true,
+		Checks:  make(map[string]string, len(checks)),
+	}
+	results := make(chan healthCheckResult, len(checks))
+	for name, check := range checks {
+		go func(name string, check HealthCheck) {
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+			results <- healthCheckResult{name: name, err: check(checkCtx)}
+		}(name, check)
+	}
+	for range checks {
+		result := <-results
+		if result.err != nil {
+			status.Healthy = false
+			status.Checks[result.name] = result.err.Error()
+		} else {
+			status.Checks[result.name] = healthCheckOK
+		}
+	}
+	return status
+}
+
+func (s *HealthServer) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	s.handle(w, r, false)
+}
+
+func (s *HealthServer) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	s.handle(w, r, true)
+}
+
+func (s *HealthServer) handle(w http.ResponseWriter, r *http.Request, includeReadiness bool) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	status := s.Check(r.Context(), includeReadiness)
+	code := http.StatusOK
+	if !status.Healthy {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(status)
+}
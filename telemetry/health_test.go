@@ -0,0 +1,47 @@
+package telemetry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+func TestHealthServer(t *testing.T) {
+	healthServer := telemetry.NewHealthServer(tmlog.NewNopLogger(), "localhost:0")
+	healthServer.AddLivenessCheck("live", func(ctx context.Context) error { return nil })
+	healthServer.AddReadinessCheck("ready", func(ctx context.Context) error { return errors.New("not ready") })
+	handler := healthServer.Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, telemetry.HealthzEndpoint, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var status telemetry.HealthStatus
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+	assert.True(t, status.Healthy)
+	assert.Equal(t, map[string]string{"live": "ok"}, status.Checks)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, telemetry.ReadyzEndpoint, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+	assert.False(t, status.Healthy)
+	assert.Equal(t, map[string]string{"live": "ok", "ready": "not ready"}, status.Checks)
+
+	// adding a check with an existing name replaces it
+	healthServer.AddReadinessCheck("ready", func(ctx context.Context) error { return nil })
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, telemetry.ReadyzEndpoint, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, telemetry.HealthzEndpoint, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	tmlog "github.com/tendermint/tendermint/libs/log"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 
@@ -24,6 +27,10 @@ const (
 	globalMetricsNamespace        = "blobstream"
 )
 
+// EVMChainIDAttribute the attribute used to label the relayer measurements with
+// the target EVM chain ID.
+const EVMChainIDAttribute = "evm_chain_id"
+
 // Config defines the configuration options for blobstream telemetry.
 type Config struct {
 	Metrics     bool   `mapstructure:"metrics" json:"metrics"`
@@ -39,6 +46,7 @@ type OrchestratorMeters struct {
 	FailedNonces      metric.Int64Counter
 	ReprocessedNonces metric.Int64Counter
 	ProcessingTime    metric.Float64Histogram
+	MissedSignatures  metric.Int64Counter
 }
 
 func InitOrchestratorMeters() (*OrchestratorMeters, error) {
@@ -66,11 +74,18 @@ func InitOrchestratorMeters() (*OrchestratorMeters, error) {
 		return nil, err
 	}
 
+	missedSignatures, err := meter.Int64Counter("orchestrator_missed_signatures_counter",
+		metric.WithDescription("the count of the signatures of the orchestrator that couldn't be retrieved from the P2P network peers"))
+	if err != nil {
+		return nil, err
+	}
+
 	return &OrchestratorMeters{
 		ProcessedNonces:   processedNonces,
 		FailedNonces:      failedNonces,
 		ReprocessedNonces: reprocessedNonces,
 		ProcessingTime:    processingTime,
+		MissedSignatures:  missedSignatures,
 	}, nil
 }
 
@@ -78,6 +93,69 @@ type RelayerMeters struct {
 	ProcessedNonces metric.Int64Counter
 	Failures        metric.Int64Counter
 	ProcessingTime  metric.Float64Histogram
+	SpeedUps        metric.Int64Counter
+	// EtherSpent the fees paid, in ether, to relay an attestation.
+	EtherSpent             metric.Float64Histogram
+	ConfirmsCollectionTime metric.Float64Histogram
+	// CollectedVotingPower the ratio of the valset power that signed the submitted attestations.
+	CollectedVotingPower metric.Float64Histogram
+	// gauges the last values of the observable gauges, shared between the relayer meters
+	// of the different EVM chains.
+	gauges *relayerGauges
+	// attributes the attributes added to all the relayer measurements.
+	attributes attribute.Set
+}
+
+// relayerGauges holds the last recorded values of the relayer gauges, per EVM chain.
+type relayerGauges struct {
+	mu     sync.Mutex
+	values map[attribute.Distinct]*relayerGaugesValues
+}
+
+type relayerGaugesValues struct {
+	attributes attribute.Set
+	// noncesLag nil if not recorded yet.
+	noncesLag *int64
+	// balance nil if not recorded yet.
+	balance *float64
+}
+
+// get returns the gauges values labelled with the provided attributes.
+// Should be called with the lock held.
+func (g *relayerGauges) get(attributes attribute.Set) *relayerGaugesValues {
+	values, has := g.values[attributes.Equivalent()]
+	if !has {
+		values = &relayerGaugesValues{attributes: attributes}
+		g.values[attributes.Equivalent()] = values
+	}
+	return values
+}
+
+// RecordNoncesLag records the number of nonces the contract is behind the Celestia latest nonce.
+func (m *RelayerMeters) RecordNoncesLag(lag uint64) {
+	m.gauges.mu.Lock()
+	defer m.gauges.mu.Unlock()
+	value := int64(lag)
+	m.gauges.get(m.attributes).noncesLag = &value
+}
+
+// RecordBalance records the balance, in ether, of the relayer account.
+func (m *RelayerMeters) RecordBalance(balance float64) {
+	m.gauges.mu.Lock()
+	defer m.gauges.mu.Unlock()
+	m.gauges.get(m.attributes).balance = &balance
+}
+
+// WithChainID returns a copy of the relayer meters that labels the measurements
+// with the provided EVM chain ID.
+func (m RelayerMeters) WithChainID(chainID uint64) *RelayerMeters {
+	m.attributes = attribute.NewSet(attribute.String(EVMChainIDAttribute, strconv.FormatUint(chainID, 10)))
+	return &m
+}
+
+// Attributes returns the measurement option adding the relayer attributes to a measurement.
+func (m *RelayerMeters) Attributes() metric.MeasurementOption {
+	return metric.WithAttributeSet(m.attributes)
 }
 
 func InitRelayerMeters() (*RelayerMeters, error) {
@@ -99,10 +177,143 @@ func InitRelayerMeters() (*RelayerMeters, error) {
 		return nil, err
 	}
 
+	speedUps, err := meter.Int64Counter("relayer_speed_ups_counter",
+		metric.WithDescription("the count of the transactions that were replaced by the relayer with a higher gas price"))
+	if err != nil {
+		return nil, err
+	}
+
+	etherSpent, err := meter.Float64Histogram("relayer_ether_spent",
+		metric.WithDescription("the fees, in ether, paid by the relayer to relay an attestation"))
+	if err != nil {
+		return nil, err
+	}
+
+	confirmsCollectionTime, err := meter.Float64Histogram("relayer_confirms_collection_time",
+		metric.WithDescription("the time it takes for the relayer to collect 2/3 of the confirms of an attestation from the P2P network"))
+	if err != nil {
+		return nil, err
+	}
+
+	collectedVotingPower, err := meter.Float64Histogram("relayer_collected_voting_power",
+		metric.WithDescription("the ratio of the validator set power that signed the attestations submitted by the relayer"))
+	if err != nil {
+		return nil, err
+	}
+
+	gauges := &relayerGauges{values: make(map[attribute.Distinct]*relayerGaugesValues)}
+	_, err = meter.Int64ObservableGauge("relayer_nonces_lag",
+		metric.WithDescription("the number of nonces the contract is behind the Celestia latest attestation nonce"),
+		metric.WithInt64Callback(func(_ context.Context, observer metric.Int64Observer) error {
+			gauges.mu.Lock()
+			defer gauges.mu.Unlock()
+			for _, values := range gauges.values {
+				if values.noncesLag != nil {
+					observer.Observe(*values.noncesLag, metric.WithAttributeSet(values.attributes))
+				}
+			}
+			return nil
+		}))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = meter.Float64ObservableGauge("relayer_account_balance",
+		metric.WithDescription("the balance, in ether, of the relayer account"),
+		metric.WithFloat64Callback(func(_ context.Context, observer metric.Float64Observer) error {
+			gauges.mu.Lock()
+			defer gauges.mu.Unlock()
+			for _, values := range gauges.values {
+				if values.balance != nil {
+					observer.Observe(*values.balance, metric.WithAttributeSet(values.attributes))
+				}
+			}
+			return nil
+		}))
+	if err != nil {
+		return nil, err
+	}
+
 	return &RelayerMeters{
-		ProcessedNonces: processedNonces,
-		Failures:        failedNonces,
-		ProcessingTime:  processingTime,
+		ProcessedNonces:        processedNonces,
+		Failures:               failedNonces,
+		ProcessingTime:         processingTime,
+		SpeedUps:               speedUps,
+		EtherSpent:             etherSpent,
+		ConfirmsCollectionTime: confirmsCollectionTime,
+		CollectedVotingPower:   collectedVotingPower,
+		gauges:                 gauges,
+	}, nil
+}
+
+// StoreAttribute the attribute used to label the store measurements with the store name.
+const StoreAttribute = "store"
+
+type StoreMeters struct {
+	// PrunedEntries the count of the entries deleted from the stores by the retention policy.
+	PrunedEntries metric.Int64Counter
+	// sizes the last recorded sizes, in bytes, of the stores referenced by their names.
+	sizes   map[string]int64
+	sizesMu sync.Mutex
+}
+
+// RecordSize records the size, in bytes, of the provided store.
+func (m *StoreMeters) RecordSize(store string, size uint64) {
+	m.sizesMu.Lock()
+	defer m.sizesMu.Unlock()
+	m.sizes[store] = int64(size)
+}
+
+func InitStoreMeters() (*StoreMeters, error) {
+	prunedEntries, err := meter.Int64Counter("store_pruned_entries_counter",
+		metric.WithDescription("the count of the entries deleted from the stores by the retention policy"))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &StoreMeters{
+		PrunedEntries: prunedEntries,
+		sizes:         make(map[string]int64),
+	}
+	_, err = meter.Int64ObservableGauge("store_size",
+		metric.WithDescription("the size, in bytes, of the store on disk"),
+		metric.WithInt64Callback(func(_ context.Context, observer metric.Int64Observer) error {
+			m.sizesMu.Lock()
+			defer m.sizesMu.Unlock()
+			for store, size := range m.sizes {
+				observer.Observe(size, metric.WithAttributes(attribute.String(StoreAttribute, store)))
+			}
+			return nil
+		}))
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type PeerMeters struct {
+	// InvalidRecords the count of the invalid DHT records returned by the peers.
+	InvalidRecords metric.Int64Counter
+	// BannedPeers the count of the peers banned for returning too many invalid DHT records.
+	BannedPeers metric.Int64Counter
+}
+
+func InitPeerMeters() (*PeerMeters, error) {
+	invalidRecords, err := meter.Int64Counter("p2p_invalid_records_counter",
+		metric.WithDescription("the count of the invalid DHT records returned by the peers"))
+	if err != nil {
+		return nil, err
+	}
+
+	bannedPeers, err := meter.Int64Counter("p2p_banned_peers_counter",
+		metric.WithDescription("the count of the peers banned for returning too many invalid DHT records"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PeerMeters{
+		InvalidRecords: invalidRecords,
+		BannedPeers:    bannedPeers,
 	}, nil
 }
 
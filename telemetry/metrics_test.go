@@ -0,0 +1,58 @@
+package telemetry_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRelayerMetersGauges(t *testing.T) {
+	reader := sdk.NewManualReader()
+	provider := sdk.NewMeterProvider(sdk.WithReader(reader))
+	otel.SetMeterProvider(provider)
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	meters, err := telemetry.InitRelayerMeters()
+	require.NoError(t, err)
+
+	chain1Meters := meters.WithChainID(1)
+	chain2Meters := meters.WithChainID(2)
+	chain1Meters.RecordNoncesLag(5)
+	chain1Meters.RecordNoncesLag(3)
+	chain2Meters.RecordNoncesLag(0)
+	chain1Meters.RecordBalance(1.5)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	lags := make(map[string]int64)
+	balances := make(map[string]float64)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch m.Name {
+			case "relayer_nonces_lag":
+				for _, dp := range m.Data.(metricdata.Gauge[int64]).DataPoints {
+					chainID, _ := dp.Attributes.Value(attribute.Key(telemetry.EVMChainIDAttribute))
+					lags[chainID.AsString()] = dp.Value
+				}
+			case "relayer_account_balance":
+				for _, dp := range m.Data.(metricdata.Gauge[float64]).DataPoints {
+					chainID, _ := dp.Attributes.Value(attribute.Key(telemetry.EVMChainIDAttribute))
+					balances[chainID.AsString()] = dp.Value
+				}
+			}
+		}
+	}
+
+	// only the last recorded values are observed
+	assert.Equal(t, map[string]int64{"1": 3, "2": 0}, lags)
+	// the balance of the second chain wasn't recorded
+	assert.Equal(t, map[string]float64{"1": 1.5}, balances)
+}
@@ -0,0 +1,86 @@
+package telemetry
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const monitorMetricsNamespace = "blobstream_monitor"
+
+// MonitorValidatorLabels the labels used to identify a validator in the monitor metrics.
+var MonitorValidatorLabels = []string{"evm_address", "moniker", "valop_address"}
+
+// MonitorMetrics the Prometheus gauges exported by the signing liveness monitor.
+type MonitorMetrics struct {
+	// LastSignedNonce the last nonce signed by a validator.
+	LastSignedNonce *prometheus.GaugeVec
+	// MissedNonces the number of nonces missed by a validator since the monitor started.
+	MissedNonces *prometheus.GaugeVec
+	// ParticipationRate the ratio of the nonces signed by a validator over a window of
+	// recent nonces.
+	ParticipationRate *prometheus.GaugeVec
+	// LastCheckedNonce the last nonce whose confirms were checked.
+	LastCheckedNonce prometheus.Gauge
+	// SignedPower the cumulative voting power that signed the last checked nonce.
+	SignedPower prometheus.Gauge
+	// TotalPower the total voting power of the valset that should sign the last checked nonce.
+	TotalPower prometheus.Gauge
+	// MajorityThreshold the voting power needed for the last checked nonce to be relayed.
+	MajorityThreshold prometheus.Gauge
+}
+
+// NewMonitorMetrics creates the monitor metrics and registers them in the provided registerer.
+func NewMonitorMetrics(registerer prometheus.Registerer) (*MonitorMetrics, error) {
+	m := &MonitorMetrics{
+		LastSignedNonce: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: monitorMetricsNamespace,
+			Name:      "validator_last_signed_nonce",
+			Help:      "the last attestation nonce signed by the validator",
+		}, MonitorValidatorLabels),
+		MissedNonces: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: monitorMetricsNamespace,
+			Name:      "validator_missed_nonces",
+			Help:      "the number of attestation nonces missed by the validator since the monitor started",
+		}, MonitorValidatorLabels),
+		ParticipationRate: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: monitorMetricsNamespace,
+			Name:      "validator_participation_rate",
+			Help:      "the ratio of the attestation nonces signed by the validator over the last window nonces",
+		}, append(append([]string{}, MonitorValidatorLabels...), "window")),
+		LastCheckedNonce: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: monitorMetricsNamespace,
+			Name:      "last_checked_nonce",
+			Help:      "the last attestation nonce whose confirms were checked",
+		}),
+		SignedPower: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: monitorMetricsNamespace,
+			Name:      "signed_power",
+			Help:      "the cumulative voting power that signed the last checked attestation nonce",
+		}),
+		TotalPower: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: monitorMetricsNamespace,
+			Name:      "total_power",
+			Help:      "the total voting power of the valset that should sign the last checked attestation nonce",
+		}),
+		MajorityThreshold: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: monitorMetricsNamespace,
+			Name:      "majority_threshold",
+			Help:      "the voting power needed for the last checked attestation nonce to be relayed",
+		}),
+	}
+
+	collectors := []prometheus.Collector{
+		m.LastSignedNonce,
+		m.MissedNonces,
+		m.ParticipationRate,
+		m.LastCheckedNonce,
+		m.SignedPower,
+		m.TotalPower,
+		m.MajorityThreshold,
+	}
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,168 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.11.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// OTLPTracesExporter exports the traces to an OTLP collector over HTTP.
+	OTLPTracesExporter = "otlp"
+	// StdoutTracesExporter writes the traces to the standard output.
+	StdoutTracesExporter = "stdout"
+
+	// NonceAttribute the attribute used to label the spans with the attestation nonce.
+	NonceAttribute = "nonce"
+
+	traceParentHeader = "traceparent"
+)
+
+// TracingConfig defines the configuration options for blobstream tracing.
+type TracingConfig struct {
+	Enable bool `mapstructure:"enable" json:"enable"`
+	// Exporter either "otlp" or "stdout".
+	Exporter string `mapstructure:"exporter" json:"exporter"`
+	Endpoint string `mapstructure:"endpoint" json:"endpoint"`
+	TLS      bool   `mapstructure:"tls" json:"tls"`
+}
+
+// ValidateTracesExporter checks that the provided traces exporter is supported.
+func ValidateTracesExporter(exporter string) error {
+	switch exporter {
+	case OTLPTracesExporter, StdoutTracesExporter:
+		return nil
+	default:
+		return fmt.Errorf("unsupported traces exporter %q, expected %q or %q", exporter, OTLPTracesExporter, StdoutTracesExporter)
+	}
+}
+
+var tracer = otel.Tracer(globalMetricsNamespace)
+
+// StartTracing sets up the global tracer provider to export the traces using the configured exporter.
+// Returns a function that flushes the remaining spans and stops the provider.
+func StartTracing(
+	ctx context.Context,
+	logger tmlog.Logger,
+	serviceName string,
+	instanceID string,
+	config TracingConfig,
+) (func() error, error) {
+	var exporter sdktrace.SpanExporter
+	switch config.Exporter {
+	case OTLPTracesExporter:
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(config.Endpoint),
+			otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
+		}
+		if !config.TLS {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exp, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+		exporter = exp
+	case StdoutTracesExporter:
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, err
+		}
+		exporter = exp
+	default:
+		return nil, ValidateTracesExporter(config.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(
+			resource.NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.ServiceNamespaceKey.String(globalMetricsNamespace),
+				semconv.ServiceNameKey.String(serviceName),
+				semconv.ServiceInstanceIDKey.String(instanceID),
+			),
+		),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	logger.Info("global tracer setup", "exporter", config.Exporter, "service_name_key", serviceName, "service_instance_id_key", instanceID)
+
+	return func() error {
+		return provider.Shutdown(ctx)
+	}, nil
+}
+
+// StartSpan starts a new span, child of the span in the provided context if any.
+// The returned span should be ended using EndSpan.
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// StartSpanWithLinks similar to StartSpan but links the span to the provided span contexts,
+// e.g. to the spans of the orchestrators that signed the confirms used by the span.
+func StartSpanWithLinks(ctx context.Context, name string, links []trace.Link, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attributes...), trace.WithLinks(links...))
+}
+
+// EndSpan records the provided error, if any, and ends the span.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Nonce returns the attribute labelling a span with the provided attestation nonce.
+func Nonce(nonce uint64) attribute.KeyValue {
+	return attribute.String(NonceAttribute, strconv.FormatUint(nonce, 10))
+}
+
+// TraceParent returns the W3C trace context of the span in the provided context.
+// Returns an empty string if there is no recording span in the context.
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier[traceParentHeader]
+}
+
+// SpanContextFromTraceParent parses a W3C trace context returned by TraceParent.
+// Returns false if the trace parent is empty or invalid.
+func SpanContextFromTraceParent(traceParent string) (trace.SpanContext, bool) {
+	if traceParent == "" {
+		return trace.SpanContext{}, false
+	}
+	ctx := propagation.TraceContext{}.Extract(
+		context.Background(),
+		propagation.MapCarrier{traceParentHeader: traceParent},
+	)
+	spanContext := trace.SpanContextFromContext(ctx)
+	return spanContext, spanContext.IsValid()
+}
+
+// LinksFromTraceParents returns the links to the valid provided W3C trace contexts.
+func LinksFromTraceParents(traceParents []string) []trace.Link {
+	links := make([]trace.Link, 0, len(traceParents))
+	for _, traceParent := range traceParents {
+		spanContext, ok := SpanContextFromTraceParent(traceParent)
+		if !ok {
+			continue
+		}
+		links = append(links, trace.Link{SpanContext: spanContext})
+	}
+	return links
+}
@@ -0,0 +1,55 @@
+package telemetry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTraceParentPropagation(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	// no span in the context
+	assert.Empty(t, telemetry.TraceParent(context.Background()))
+
+	ctx, orchSpan := telemetry.StartSpan(context.Background(), "sign", telemetry.Nonce(10))
+	traceParent := telemetry.TraceParent(ctx)
+	require.NotEmpty(t, traceParent)
+	telemetry.EndSpan(orchSpan, nil)
+
+	spanContext, ok := telemetry.SpanContextFromTraceParent(traceParent)
+	require.True(t, ok)
+	assert.Equal(t, orchSpan.SpanContext().TraceID(), spanContext.TraceID())
+	assert.Equal(t, orchSpan.SpanContext().SpanID(), spanContext.SpanID())
+
+	_, ok = telemetry.SpanContextFromTraceParent("")
+	assert.False(t, ok)
+	_, ok = telemetry.SpanContextFromTraceParent("invalid")
+	assert.False(t, ok)
+
+	// the invalid trace parents are skipped
+	links := telemetry.LinksFromTraceParents([]string{traceParent, "", "invalid"})
+	require.Len(t, links, 1)
+
+	_, relaySpan := telemetry.StartSpanWithLinks(context.Background(), "relay", links, telemetry.Nonce(10))
+	telemetry.EndSpan(relaySpan, errors.New("failed"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "relay", spans[1].Name())
+	require.Len(t, spans[1].Links(), 1)
+	assert.Equal(t, spanContext.SpanID(), spans[1].Links()[0].SpanContext.SpanID())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "failed", spans[1].Status().Description)
+}
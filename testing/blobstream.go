@@ -7,9 +7,10 @@ import (
 	"github.com/celestiaorg/orchestrator-relayer/telemetry"
 
 	"github.com/celestiaorg/orchestrator-relayer/store"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
 	badger "github.com/ipfs/go-ds-badger2"
 
-	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 
 	"github.com/stretchr/testify/require"
@@ -46,22 +47,22 @@ func NewRelayer(
 	require.NoError(t, err)
 	err = ks.Unlock(acc, "123")
 	require.NoError(t, err)
-	evmClient := NewEVMClient(ks, &acc)
+	evmClient := NewEVMClient(evm.NewKeystoreSigner(ks, acc))
 	retrier := helpers.NewRetrier(logger, 3, 500*time.Millisecond)
 	tempDir := t.TempDir()
 	sigStore, err := badger.NewDatastore(tempDir, store.DefaultBadgerOptions(tempDir))
 	require.NoError(t, err)
 	meters, err := telemetry.InitRelayerMeters()
 	require.NoError(t, err)
-	r := relayer.NewRelayer(tmQuerier, appQuerier, p2pQuerier, evmClient, logger, retrier, sigStore, 30*time.Second, false, 0, meters)
+	r := relayer.NewRelayer(tmQuerier, appQuerier, p2pQuerier, evmClient, logger, retrier, sigStore, 30*time.Second, false, 0, meters, relayer.BatchConfig{}, 1, nil)
 	return r
 }
 
-func NewEVMClient(ks *keystore.KeyStore, acc *accounts.Account) *evm.Client {
+func NewEVMClient(signer evm.Signer) *evm.Client {
 	logger := tmlog.NewNopLogger()
 	// specifying an empty RPC endpoint as we will not be testing the methods that require it.
 	// the simulated backend doesn't provide an RPC endpoint.
-	return evm.NewClient(logger, nil, ks, acc, "", 100000000)
+	return evm.NewClient(logger, nil, signer, "", 100000000, evm.DefaultGasPolicy())
 }
 
 func NewOrchestrator(
@@ -77,7 +78,7 @@ func NewOrchestrator(
 	tmQuerier := rpc.NewTmQuerier(node.CelestiaNetwork.RPCAddr, logger)
 	tmQuerier.WithClientConn(node.CelestiaNetwork.Client)
 	p2pQuerier := p2p.NewQuerier(node.DHTNetwork.DHTs[0], logger)
-	broadcaster := orchestrator.NewBroadcaster(node.DHTNetwork.DHTs[0])
+	broadcaster := orchestrator.NewBroadcaster(node.DHTNetwork.DHTs[0], nil, logger)
 	retrier := helpers.NewRetrier(logger, 3, 500*time.Millisecond)
 	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
 	acc, err := ks.ImportECDSA(NodeEVMPrivateKey, "123")
@@ -86,6 +87,21 @@ func NewOrchestrator(
 	require.NoError(t, err)
 	meters, err := telemetry.InitOrchestratorMeters()
 	require.NoError(t, err)
-	orch := orchestrator.New(logger, appQuerier, tmQuerier, p2pQuerier, broadcaster, retrier, ks, &acc, meters)
+	dataStore := dssync.MutexWrap(ds.NewMapDatastore())
+	checkpoint, err := orchestrator.NewCheckpoint(node.Context, dataStore)
+	require.NoError(t, err)
+	slashingProtection := orchestrator.NewSlashingProtection(dataStore)
+	orch := orchestrator.New(
+		logger,
+		appQuerier,
+		tmQuerier,
+		p2pQuerier,
+		broadcaster,
+		retrier,
+		evm.NewKeystoreSigner(ks, acc),
+		meters,
+		checkpoint,
+		slashingProtection,
+	)
 	return orch
 }
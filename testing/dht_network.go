@@ -65,7 +65,7 @@ func NewTestDHT(ctx context.Context, bootstrappers []peer.AddrInfo) (host.Host,
 		panic(err)
 	}
 	dataStore := dssync.MutexWrap(ds.NewMapDatastore())
-	dht, err := p2p.NewBlobstreamDHT(ctx, h, dataStore, bootstrappers, tmlog.NewNopLogger())
+	dht, err := p2p.NewBlobstreamDHT(ctx, h, dataStore, bootstrappers, nil, tmlog.NewNopLogger())
 	if err != nil {
 		panic(err)
 	}
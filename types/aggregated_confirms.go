@@ -0,0 +1,171 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// AggregatedSignature the signature of an orchestrator, as held in an aggregated confirms record.
+type AggregatedSignature struct {
+	// Hex `0x` encoded Ethereum address of the orchestrator that signed the digest.
+	EthAddress string `json:"eth_address"`
+	// Signature over the digest.
+	Signature string `json:"signature"`
+}
+
+// AggregatedConfirms holds all the signatures collected for an attestation nonce, so that
+// they can be retrieved from the DHT in a single lookup instead of one per valset member.
+// Any peer can merge the signatures it knows of into the record and re-publish it.
+type AggregatedConfirms struct {
+	// Universal nonce of the signed attestation.
+	Nonce uint64 `json:"nonce"`
+	// Hex `0x` encoded digest signed by the orchestrators, i.e. the valset sign bytes or
+	// the data root tuple root.
+	Digest string `json:"digest"`
+	// Signatures the signatures over the digest, ordered by EVM address.
+	Signatures []AggregatedSignature `json:"signatures"`
+}
+
+// NewAggregatedConfirms creates a new aggregated confirms record without signatures.
+func NewAggregatedConfirms(nonce uint64, digest string) *AggregatedConfirms {
+	return &AggregatedConfirms{
+		Nonce:      nonce,
+		Digest:     digest,
+		Signatures: make([]AggregatedSignature, 0),
+	}
+}
+
+// Add adds the signature of the provided EVM address to the record.
+// Returns false if the record already holds a signature from the address.
+func (ac *AggregatedConfirms) Add(ethAddress string, signature string) bool {
+	if ac.Has(ethAddress) {
+		return false
+	}
+	ac.Signatures = append(ac.Signatures, AggregatedSignature{
+		EthAddress: ethAddress,
+		Signature:  signature,
+	})
+	sort.Slice(ac.Signatures, func(i, j int) bool {
+		return strings.ToLower(ac.Signatures[i].EthAddress) < strings.ToLower(ac.Signatures[j].EthAddress)
+	})
+	return true
+}
+
+// Has returns true if the record holds a signature from the provided EVM address.
+func (ac *AggregatedConfirms) Has(ethAddress string) bool {
+	for _, signature := range ac.Signatures {
+		if strings.EqualFold(signature.EthAddress, ethAddress) {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge adds the signatures of the other record that are missing from this one.
+// Returns the number of added signatures.
+// Expects both records to be for the same nonce and digest.
+func (ac *AggregatedConfirms) Merge(other AggregatedConfirms) int {
+	added := 0
+	for _, signature := range other.Signatures {
+		if ac.Add(signature.EthAddress, signature.Signature) {
+			added++
+		}
+	}
+	return added
+}
+
+// MarshalAggregatedConfirms Encodes an aggregated confirms record to Json bytes.
+func MarshalAggregatedConfirms(ac AggregatedConfirms) ([]byte, error) {
+	encoded, err := json.Marshal(ac)
+	if err != nil {
+		return nil, err
+	}
+	return encoded, nil
+}
+
+// EncodeAggregatedConfirms Encodes an aggregated confirms record using the provided record format.
+func EncodeAggregatedConfirms(ac AggregatedConfirms, format RecordFormat) ([]byte, error) {
+	switch format {
+	case RecordFormatJSON:
+		return MarshalAggregatedConfirms(ac)
+	case RecordFormatBinaryV1:
+		digest, err := decodeHexBytes(ac.Digest)
+		if err != nil {
+			return nil, err
+		}
+		encoded := []byte{byte(RecordFormatBinaryV1)}
+		encoded = appendVarintField(encoded, 1, ac.Nonce)
+		encoded = appendBytesField(encoded, 2, digest)
+		for _, signature := range ac.Signatures {
+			bSignature, err := decodeHexBytes(signature.Signature)
+			if err != nil {
+				return nil, err
+			}
+			var encodedSignature []byte
+			encodedSignature = appendStringField(encodedSignature, 1, signature.EthAddress)
+			encodedSignature = appendBytesField(encodedSignature, 2, bSignature)
+			encoded = protowire.AppendTag(encoded, 3, protowire.BytesType)
+			encoded = protowire.AppendBytes(encoded, encodedSignature)
+		}
+		return encoded, nil
+	default:
+		return nil, ErrUnknownRecordFormat
+	}
+}
+
+// UnmarshalAggregatedConfirms Decodes an aggregated confirms record from Json or binary bytes,
+// depending on their format.
+func UnmarshalAggregatedConfirms(encoded []byte) (AggregatedConfirms, error) {
+	format, err := DetectRecordFormat(encoded)
+	if err != nil {
+		return AggregatedConfirms{}, err
+	}
+	var aggregatedConfirms AggregatedConfirms
+	if format == RecordFormatJSON {
+		err := json.Unmarshal(encoded, &aggregatedConfirms)
+		if err != nil {
+			return AggregatedConfirms{}, err
+		}
+		return aggregatedConfirms, nil
+	}
+	aggregatedConfirms.Signatures = make([]AggregatedSignature, 0)
+	err = consumeFields(
+		encoded[1:],
+		func(num protowire.Number, value []byte) error {
+			switch num {
+			case 2:
+				aggregatedConfirms.Digest = "0x" + hex.EncodeToString(value)
+			case 3:
+				var signature AggregatedSignature
+				err := consumeFields(value, func(num protowire.Number, value []byte) error {
+					switch num {
+					case 1:
+						signature.EthAddress = string(value)
+					case 2:
+						signature.Signature = hex.EncodeToString(value)
+					}
+					return nil
+				}, nil)
+				if err != nil {
+					return err
+				}
+				aggregatedConfirms.Signatures = append(aggregatedConfirms.Signatures, signature)
+			}
+			return nil
+		},
+		func(num protowire.Number, value uint64) error {
+			if num == 1 {
+				aggregatedConfirms.Nonce = value
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return AggregatedConfirms{}, err
+	}
+	return aggregatedConfirms, nil
+}
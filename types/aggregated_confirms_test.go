@@ -0,0 +1,52 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregatedConfirmsMerge(t *testing.T) {
+	ac := types.NewAggregatedConfirms(10, "0x1234")
+	assert.True(t, ac.Add("0xB", "signature_b"))
+	assert.True(t, ac.Add("0xa", "signature_a"))
+	// the signatures are unique per EVM address
+	assert.False(t, ac.Add("0xA", "other_signature"))
+
+	other := types.NewAggregatedConfirms(10, "0x1234")
+	other.Add("0xa", "signature_a")
+	other.Add("0xc", "signature_c")
+
+	assert.Equal(t, 1, ac.Merge(*other))
+	assert.Equal(t, []types.AggregatedSignature{
+		{EthAddress: "0xa", Signature: "signature_a"},
+		{EthAddress: "0xB", Signature: "signature_b"},
+		{EthAddress: "0xc", Signature: "signature_c"},
+	}, ac.Signatures)
+	assert.True(t, ac.Has("0xb"))
+	assert.False(t, ac.Has("0xd"))
+}
+
+func TestEncodeAggregatedConfirms(t *testing.T) {
+	ac := types.NewAggregatedConfirms(10, "0x1234")
+	ac.Add("0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488", testSignature)
+	ac.Add("0x91DEd26b5f38B065FC0204c7929Da1b2A21877Ad", testSignature)
+
+	encoded, err := types.EncodeAggregatedConfirms(*ac, types.RecordFormatJSON)
+	require.NoError(t, err)
+	decoded, err := types.UnmarshalAggregatedConfirms(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, *ac, decoded)
+
+	encoded, err = types.EncodeAggregatedConfirms(*ac, types.RecordFormatBinaryV1)
+	require.NoError(t, err)
+	decoded, err = types.UnmarshalAggregatedConfirms(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, *ac, decoded)
+
+	// the binary format only supports hex digests
+	_, err = types.EncodeAggregatedConfirms(*types.NewAggregatedConfirms(10, "digest"), types.RecordFormatBinaryV1)
+	assert.Error(t, err)
+}
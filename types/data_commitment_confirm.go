@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -8,6 +9,7 @@ import (
 	"github.com/celestiaorg/celestia-app/x/qgb/types"
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
 // DataCommitmentConfirm describes a data commitment for a set of blocks.
@@ -18,6 +20,9 @@ type DataCommitmentConfirm struct {
 	// Hex `0x` encoded Ethereum public key that will be used by this validator on
 	// Ethereum.
 	EthAddress string
+	// W3C trace context of the orchestrator span that signed the confirm. Empty
+	// if tracing is disabled. It is not covered by the signature.
+	TraceParent string `json:",omitempty"`
 }
 
 // NewDataCommitmentConfirm creates a new NewDataCommitmentConfirm.
@@ -40,10 +45,52 @@ func MarshalDataCommitmentConfirm(dcc DataCommitmentConfirm) ([]byte, error) {
 	return encoded, nil
 }
 
-// UnmarshalDataCommitmentConfirm Decodes a data commitment confirm from Json bytes.
+// EncodeDataCommitmentConfirm Encodes a data commitment confirm using the provided record format.
+func EncodeDataCommitmentConfirm(dcc DataCommitmentConfirm, format RecordFormat) ([]byte, error) {
+	switch format {
+	case RecordFormatJSON:
+		return MarshalDataCommitmentConfirm(dcc)
+	case RecordFormatBinaryV1:
+		signature, err := decodeHexBytes(dcc.Signature)
+		if err != nil {
+			return nil, err
+		}
+		encoded := []byte{byte(RecordFormatBinaryV1)}
+		encoded = appendBytesField(encoded, 1, signature)
+		encoded = appendStringField(encoded, 2, dcc.EthAddress)
+		encoded = appendStringField(encoded, 3, dcc.TraceParent)
+		return encoded, nil
+	default:
+		return nil, ErrUnknownRecordFormat
+	}
+}
+
+// UnmarshalDataCommitmentConfirm Decodes a data commitment confirm from Json or binary bytes,
+// depending on their format.
 func UnmarshalDataCommitmentConfirm(encoded []byte) (DataCommitmentConfirm, error) {
+	format, err := DetectRecordFormat(encoded)
+	if err != nil {
+		return DataCommitmentConfirm{}, err
+	}
 	var dataCommitmentConfirm DataCommitmentConfirm
-	err := json.Unmarshal(encoded, &dataCommitmentConfirm)
+	if format == RecordFormatJSON {
+		err := json.Unmarshal(encoded, &dataCommitmentConfirm)
+		if err != nil {
+			return DataCommitmentConfirm{}, err
+		}
+		return dataCommitmentConfirm, nil
+	}
+	err = consumeFields(encoded[1:], func(num protowire.Number, value []byte) error {
+		switch num {
+		case 1:
+			dataCommitmentConfirm.Signature = hex.EncodeToString(value)
+		case 2:
+			dataCommitmentConfirm.EthAddress = string(value)
+		case 3:
+			dataCommitmentConfirm.TraceParent = string(value)
+		}
+		return nil
+	}, nil)
 	if err != nil {
 		return DataCommitmentConfirm{}, err
 	}
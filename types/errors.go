@@ -12,4 +12,7 @@ var (
 	ErrAttestationNotFound                 = errors.New("attestation not found")
 	ErrUnmarshalValset                     = errors.New("couldn't unmarshal valset")
 	ErrAttestationNotValsetRequest         = errors.New("attestation is not a valset request")
+	ErrEmptyRecord                         = errors.New("empty record")
+	ErrUnknownRecordFormat                 = errors.New("unknown record format")
+	ErrNotUint256                          = errors.New("value not fitting in a uint256")
 )
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/celestiaorg/celestia-app/x/qgb/types"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
 // LatestValset a replica of the types.Valset to omit marshalling `time` as it bears different results on different machines.
@@ -44,10 +45,82 @@ func MarshalLatestValset(lv LatestValset) ([]byte, error) {
 	return encoded, nil
 }
 
-// UnmarshalLatestValset Decodes a valset from Json bytes.
+// EncodeLatestValset Encodes a valset using the provided record format.
+func EncodeLatestValset(lv LatestValset, format RecordFormat) ([]byte, error) {
+	switch format {
+	case RecordFormatJSON:
+		return MarshalLatestValset(lv)
+	case RecordFormatBinaryV1:
+		encoded := []byte{byte(RecordFormatBinaryV1)}
+		encoded = appendVarintField(encoded, 1, lv.Nonce)
+		encoded = appendVarintField(encoded, 2, lv.Height)
+		for _, member := range lv.Members {
+			var encodedMember []byte
+			encodedMember = appendVarintField(encodedMember, 1, member.Power)
+			encodedMember = appendStringField(encodedMember, 2, member.EvmAddress)
+			// the members are always appended, even if empty, so that their count is preserved.
+			encoded = protowire.AppendTag(encoded, 3, protowire.BytesType)
+			encoded = protowire.AppendBytes(encoded, encodedMember)
+		}
+		return encoded, nil
+	default:
+		return nil, ErrUnknownRecordFormat
+	}
+}
+
+// UnmarshalLatestValset Decodes a valset from Json or binary bytes, depending on their format.
 func UnmarshalLatestValset(encoded []byte) (LatestValset, error) {
+	format, err := DetectRecordFormat(encoded)
+	if err != nil {
+		return LatestValset{}, err
+	}
 	var valset LatestValset
-	err := json.Unmarshal(encoded, &valset)
+	if format == RecordFormatJSON {
+		err := json.Unmarshal(encoded, &valset)
+		if err != nil {
+			return LatestValset{}, err
+		}
+		return valset, nil
+	}
+	valset.Members = make([]types.BridgeValidator, 0)
+	err = consumeFields(
+		encoded[1:],
+		func(num protowire.Number, value []byte) error {
+			if num != 3 {
+				return nil
+			}
+			var member types.BridgeValidator
+			err := consumeFields(
+				value,
+				func(num protowire.Number, value []byte) error {
+					if num == 2 {
+						member.EvmAddress = string(value)
+					}
+					return nil
+				},
+				func(num protowire.Number, value uint64) error {
+					if num == 1 {
+						member.Power = value
+					}
+					return nil
+				},
+			)
+			if err != nil {
+				return err
+			}
+			valset.Members = append(valset.Members, member)
+			return nil
+		},
+		func(num protowire.Number, value uint64) error {
+			switch num {
+			case 1:
+				valset.Nonce = value
+			case 2:
+				valset.Height = value
+			}
+			return nil
+		},
+	)
 	if err != nil {
 		return LatestValset{}, err
 	}
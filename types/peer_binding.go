@@ -0,0 +1,60 @@
+package types
+
+import (
+	"encoding/json"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PeerBindingDomainSeparator the domain separator used when signing peer bindings,
+// so that their signatures cannot be confused with attestation signatures.
+const PeerBindingDomainSeparator = "blobstreamPeerBinding"
+
+// PeerBinding binds a P2P peer to an EVM address. It's sent during the P2P handshake by the
+// orchestrators to prove that their P2P key belongs to a valset member.
+type PeerBinding struct {
+	// PeerID the ID of the P2P peer, i.e. the encoding of its public key.
+	PeerID string
+	// Hex `0x` encoded EVM address bound to the peer.
+	EVMAddress string
+	// Signature over the PeerBindingSignBytes using the EVM account.
+	Signature string
+}
+
+// NewPeerBinding creates a new PeerBinding.
+func NewPeerBinding(peerID string, evmAddress ethcmn.Address, signature string) *PeerBinding {
+	return &PeerBinding{
+		PeerID:     peerID,
+		EVMAddress: evmAddress.Hex(),
+		Signature:  signature,
+	}
+}
+
+// MarshalPeerBinding Encodes a peer binding to Json bytes.
+func MarshalPeerBinding(pb PeerBinding) ([]byte, error) {
+	encoded, err := json.Marshal(pb)
+	if err != nil {
+		return nil, err
+	}
+	return encoded, nil
+}
+
+// UnmarshalPeerBinding Decodes a peer binding from Json bytes.
+func UnmarshalPeerBinding(encoded []byte) (PeerBinding, error) {
+	var peerBinding PeerBinding
+	err := json.Unmarshal(encoded, &peerBinding)
+	if err != nil {
+		return PeerBinding{}, err
+	}
+	return peerBinding, nil
+}
+
+// PeerBindingSignBytes takes the peer ID and produces the digest to be signed by the EVM account
+// bound to it.
+func PeerBindingSignBytes(peerID string) ethcmn.Hash {
+	return crypto.Keccak256Hash(
+		[]byte(PeerBindingDomainSeparator),
+		[]byte(peerID),
+	)
+}
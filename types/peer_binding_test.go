@@ -0,0 +1,25 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalPeerBinding(t *testing.T) {
+	binding := types.PeerBinding{
+		PeerID:     "peer_id",
+		EVMAddress: "evm_address",
+		Signature:  "signature",
+	}
+
+	jsonData, err := types.MarshalPeerBinding(binding)
+	assert.NoError(t, err)
+	expectedJSON := `{"PeerID":"peer_id","EVMAddress":"evm_address","Signature":"signature"}`
+	assert.Equal(t, expectedJSON, string(jsonData))
+
+	decoded, err := types.UnmarshalPeerBinding(jsonData)
+	assert.NoError(t, err)
+	assert.Equal(t, binding, decoded)
+}
@@ -0,0 +1,108 @@
+package types
+
+import (
+	"encoding/hex"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// RecordFormat the format used to encode the records put to the DHT.
+type RecordFormat byte
+
+const (
+	// RecordFormatJSON the legacy JSON encoding. The JSON encoded records are not prefixed
+	// with a format byte, and are identified by their first character.
+	RecordFormatJSON RecordFormat = 0
+	// RecordFormatBinaryV1 the protobuf encoding, prefixed with its format byte.
+	// The signatures are encoded as raw bytes instead of hex strings.
+	RecordFormatBinaryV1 RecordFormat = 1
+)
+
+// DetectRecordFormat returns the format of the encoded record.
+func DetectRecordFormat(encoded []byte) (RecordFormat, error) {
+	if len(encoded) == 0 {
+		return 0, ErrEmptyRecord
+	}
+	switch {
+	case encoded[0] == '{':
+		return RecordFormatJSON, nil
+	case RecordFormat(encoded[0]) == RecordFormatBinaryV1:
+		return RecordFormatBinaryV1, nil
+	default:
+		return 0, ErrUnknownRecordFormat
+	}
+}
+
+// appendStringField appends the string protobuf field if it's not empty.
+func appendStringField(b []byte, num protowire.Number, value string) []byte {
+	if value == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, value)
+}
+
+// appendBytesField appends the bytes protobuf field if it's not empty.
+func appendBytesField(b []byte, num protowire.Number, value []byte) []byte {
+	if len(value) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, value)
+}
+
+// appendVarintField appends the varint protobuf field if it's not zero.
+func appendVarintField(b []byte, num protowire.Number, value uint64) []byte {
+	if value == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, value)
+}
+
+// consumeFields decodes the protobuf fields, calling the provided function with the value of
+// every bytes field, or varint field, it's interested in. The unknown fields are skipped.
+func consumeFields(b []byte, onBytes func(protowire.Number, []byte) error, onVarint func(protowire.Number, uint64) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch {
+		case typ == protowire.BytesType && onBytes != nil:
+			value, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			if err := onBytes(num, value); err != nil {
+				return err
+			}
+			b = b[n:]
+		case typ == protowire.VarintType && onVarint != nil:
+			value, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			if err := onVarint(num, value); err != nil {
+				return err
+			}
+			b = b[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+		}
+	}
+	return nil
+}
+
+// decodeHexBytes decodes the hex string, e.g. a signature, with or without its `0x` prefix, to raw bytes.
+func decodeHexBytes(value string) ([]byte, error) {
+	if len(value) > 2 && value[:2] == "0x" {
+		value = value[2:]
+	}
+	return hex.DecodeString(value)
+}
@@ -0,0 +1,115 @@
+package types_test
+
+import (
+	"testing"
+
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSignature = "0b8bfd8dd7d7c0b4bd6f1e4a1d1e9c0c2c3de6a1e2bd3c0d8f7e43c1e1b8a0a47cd4d8d0d21e2c3f4a5b6c7d8e9f0a1b2c3d4e5f60718293a4b5c6d7e8f90a1b1c"
+
+func TestDetectRecordFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoded  []byte
+		expected types.RecordFormat
+		wantErr  error
+	}{
+		{
+			name:     "legacy json record",
+			encoded:  []byte(`{"EthAddress":"eth_address","Signature":"signature"}`),
+			expected: types.RecordFormatJSON,
+		},
+		{
+			name:     "binary record",
+			encoded:  []byte{byte(types.RecordFormatBinaryV1), 0x0a, 0x00},
+			expected: types.RecordFormatBinaryV1,
+		},
+		{
+			name:    "empty record",
+			encoded: []byte{},
+			wantErr: types.ErrEmptyRecord,
+		},
+		{
+			name:    "unknown format",
+			encoded: []byte{0x7f, 0x0a, 0x00},
+			wantErr: types.ErrUnknownRecordFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := types.DetectRecordFormat(tt.encoded)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, format)
+			}
+		})
+	}
+}
+
+func TestEncodeConfirmsBinary(t *testing.T) {
+	dcc := types.DataCommitmentConfirm{
+		Signature:   testSignature,
+		EthAddress:  "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488",
+		TraceParent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+	}
+	encoded, err := types.EncodeDataCommitmentConfirm(dcc, types.RecordFormatBinaryV1)
+	require.NoError(t, err)
+	legacy, err := types.MarshalDataCommitmentConfirm(dcc)
+	require.NoError(t, err)
+	assert.Less(t, len(encoded), len(legacy))
+	decodedDcc, err := types.UnmarshalDataCommitmentConfirm(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, dcc, decodedDcc)
+
+	vc := types.ValsetConfirm{
+		EthAddress: "0x966e6f22781EF6a6A82BBB4DB3df8E225DfD9488",
+		Signature:  "0x" + testSignature,
+	}
+	encoded, err = types.EncodeValsetConfirm(vc, types.RecordFormatBinaryV1)
+	require.NoError(t, err)
+	decodedVc, err := types.UnmarshalValsetConfirm(encoded)
+	require.NoError(t, err)
+	// the signatures are decoded without their 0x prefix
+	assert.Equal(t, testSignature, decodedVc.Signature)
+	assert.Equal(t, vc.EthAddress, decodedVc.EthAddress)
+
+	// the binary format only supports hex signatures
+	_, err = types.EncodeValsetConfirm(types.ValsetConfirm{Signature: "signature"}, types.RecordFormatBinaryV1)
+	assert.Error(t, err)
+
+	_, err = types.EncodeDataCommitmentConfirm(dcc, types.RecordFormat(0x7f))
+	assert.ErrorIs(t, err, types.ErrUnknownRecordFormat)
+}
+
+func TestEncodeLatestValsetBinary(t *testing.T) {
+	valset := types.LatestValset{
+		Nonce:  10,
+		Height: 5,
+		Members: []celestiatypes.BridgeValidator{
+			{
+				Power:      100,
+				EvmAddress: "evm_addr1",
+			},
+			{
+				Power:      0,
+				EvmAddress: "",
+			},
+		},
+	}
+
+	encoded, err := types.EncodeLatestValset(valset, types.RecordFormatBinaryV1)
+	require.NoError(t, err)
+	decoded, err := types.UnmarshalLatestValset(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, valset, decoded)
+
+	// a truncated record is rejected
+	_, err = types.UnmarshalLatestValset(encoded[:len(encoded)-1])
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// RelayAnnouncementDomainSeparator the domain separator used when signing relay announcements,
+// so that their signatures cannot be confused with attestation signatures.
+const RelayAnnouncementDomainSeparator = "blobstreamRelayAnnouncement"
+
+// RelayAnnouncement describes an attestation being relayed by a relayer to an EVM chain.
+// It's used by relayers to avoid relaying attestations that are already in flight.
+type RelayAnnouncement struct {
+	// Nonce the nonce of the attestation being relayed.
+	Nonce uint64
+	// EVMChainID the ID of the EVM chain the attestation is relayed to.
+	EVMChainID uint64
+	// Hex `0x` encoded EVM address of the relayer account.
+	RelayerAddress string
+	// Hex `0x` encoded hash of the transaction relaying the attestation.
+	TxHash string
+	// Timestamp the unix time, in seconds, at which the transaction was broadcast.
+	Timestamp int64
+	// Signature over the RelayAnnouncementSignBytes using the relayer account.
+	Signature string
+}
+
+// NewRelayAnnouncement creates a new RelayAnnouncement.
+func NewRelayAnnouncement(
+	nonce uint64,
+	evmChainID uint64,
+	relayerAddress ethcmn.Address,
+	txHash ethcmn.Hash,
+	timestamp int64,
+	signature string,
+) *RelayAnnouncement {
+	return &RelayAnnouncement{
+		Nonce:          nonce,
+		EVMChainID:     evmChainID,
+		RelayerAddress: relayerAddress.Hex(),
+		TxHash:         txHash.Hex(),
+		Timestamp:      timestamp,
+		Signature:      signature,
+	}
+}
+
+// MarshalRelayAnnouncement Encodes a relay announcement to Json bytes.
+func MarshalRelayAnnouncement(ra RelayAnnouncement) ([]byte, error) {
+	encoded, err := json.Marshal(ra)
+	if err != nil {
+		return nil, err
+	}
+	return encoded, nil
+}
+
+// UnmarshalRelayAnnouncement Decodes a relay announcement from Json bytes.
+func UnmarshalRelayAnnouncement(encoded []byte) (RelayAnnouncement, error) {
+	var relayAnnouncement RelayAnnouncement
+	err := json.Unmarshal(encoded, &relayAnnouncement)
+	if err != nil {
+		return RelayAnnouncement{}, err
+	}
+	return relayAnnouncement, nil
+}
+
+// RelayAnnouncementSignBytes takes the relay announcement fields and produces the digest
+// to be signed by the relayer account.
+func RelayAnnouncementSignBytes(nonce uint64, evmChainID uint64, txHash ethcmn.Hash, timestamp int64) ethcmn.Hash {
+	return crypto.Keccak256Hash(
+		[]byte(RelayAnnouncementDomainSeparator),
+		ethcmn.LeftPadBytes(new(big.Int).SetUint64(nonce).Bytes(), 32),
+		ethcmn.LeftPadBytes(new(big.Int).SetUint64(evmChainID).Bytes(), 32),
+		txHash.Bytes(),
+		ethcmn.LeftPadBytes(big.NewInt(timestamp).Bytes(), 32),
+	)
+}
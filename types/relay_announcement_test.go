@@ -0,0 +1,28 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalRelayAnnouncement(t *testing.T) {
+	announcement := types.RelayAnnouncement{
+		Nonce:          10,
+		EVMChainID:     5,
+		RelayerAddress: "relayer_address",
+		TxHash:         "tx_hash",
+		Timestamp:      100,
+		Signature:      "signature",
+	}
+
+	jsonData, err := types.MarshalRelayAnnouncement(announcement)
+	assert.NoError(t, err)
+	expectedJSON := `{"Nonce":10,"EVMChainID":5,"RelayerAddress":"relayer_address","TxHash":"tx_hash","Timestamp":100,"Signature":"signature"}`
+	assert.Equal(t, expectedJSON, string(jsonData))
+
+	decoded, err := types.UnmarshalRelayAnnouncement(jsonData)
+	assert.NoError(t, err)
+	assert.Equal(t, announcement, decoded)
+}
@@ -1,9 +1,15 @@
 package types
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"math/big"
 
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
 // ValsetConfirm
@@ -21,6 +27,9 @@ type ValsetConfirm struct {
 	EthAddress string
 	// The `ValSet` message signature.
 	Signature string
+	// W3C trace context of the orchestrator span that signed the confirm. Empty
+	// if tracing is disabled. It is not covered by the signature.
+	TraceParent string `json:",omitempty"`
 }
 
 // NewValsetConfirm returns a new msgValSetConfirm.
@@ -43,10 +52,51 @@ func MarshalValsetConfirm(vs ValsetConfirm) ([]byte, error) {
 	return encoded, nil
 }
 
-// UnmarshalValsetConfirm Decodes a valset confirm from Json bytes.
+// EncodeValsetConfirm Encodes a valset confirm using the provided record format.
+func EncodeValsetConfirm(vs ValsetConfirm, format RecordFormat) ([]byte, error) {
+	switch format {
+	case RecordFormatJSON:
+		return MarshalValsetConfirm(vs)
+	case RecordFormatBinaryV1:
+		signature, err := decodeHexBytes(vs.Signature)
+		if err != nil {
+			return nil, err
+		}
+		encoded := []byte{byte(RecordFormatBinaryV1)}
+		encoded = appendStringField(encoded, 1, vs.EthAddress)
+		encoded = appendBytesField(encoded, 2, signature)
+		encoded = appendStringField(encoded, 3, vs.TraceParent)
+		return encoded, nil
+	default:
+		return nil, ErrUnknownRecordFormat
+	}
+}
+
+// UnmarshalValsetConfirm Decodes a valset confirm from Json or binary bytes, depending on their format.
 func UnmarshalValsetConfirm(encoded []byte) (ValsetConfirm, error) {
+	format, err := DetectRecordFormat(encoded)
+	if err != nil {
+		return ValsetConfirm{}, err
+	}
 	var valsetConfirm ValsetConfirm
-	err := json.Unmarshal(encoded, &valsetConfirm)
+	if format == RecordFormatJSON {
+		err := json.Unmarshal(encoded, &valsetConfirm)
+		if err != nil {
+			return ValsetConfirm{}, err
+		}
+		return valsetConfirm, nil
+	}
+	err = consumeFields(encoded[1:], func(num protowire.Number, value []byte) error {
+		switch num {
+		case 1:
+			valsetConfirm.EthAddress = string(value)
+		case 2:
+			valsetConfirm.Signature = hex.EncodeToString(value)
+		case 3:
+			valsetConfirm.TraceParent = string(value)
+		}
+		return nil
+	}, nil)
 	if err != nil {
 		return ValsetConfirm{}, err
 	}
@@ -59,3 +109,36 @@ func IsEmptyValsetConfirm(vs ValsetConfirm) bool {
 	return vs.EthAddress == emptyVsConfirm.EthAddress &&
 		vs.Signature == emptyVsConfirm.Signature
 }
+
+// ValsetCheckpoint computes the validator set checkpoint, as saved by the Blobstream contract,
+// from the valset nonce, its power threshold and its hash.
+// It mirrors `Valset.SignBytes` for when only the values emitted by the contract are known.
+// Returns an error if the values can't be encoded, e.g. a power threshold that doesn't fit in a uint256.
+func ValsetCheckpoint(nonce *big.Int, powerThreshold *big.Int, valsetHash common.Hash) (common.Hash, error) {
+	// the abi encoding silently wraps the out of range values, which would produce a checkpoint
+	// the contract never committed to.
+	if !isUint256(nonce) {
+		return common.Hash{}, fmt.Errorf("%w: nonce %v", ErrNotUint256, nonce)
+	}
+	if !isUint256(powerThreshold) {
+		return common.Hash{}, fmt.Errorf("%w: power threshold %v", ErrNotUint256, powerThreshold)
+	}
+	bytes, err := celestiatypes.InternalQGBabi.Pack(
+		"domainSeparateValidatorSetHash",
+		celestiatypes.VsDomainSeparator,
+		nonce,
+		powerThreshold,
+		valsetHash,
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// the first 4 bytes are the constant method name, and are discarded.
+	return crypto.Keccak256Hash(bytes[4:]), nil
+}
+
+// isUint256 returns true if the provided value is not nil and fits in a uint256.
+func isUint256(value *big.Int) bool {
+	return value != nil && value.Sign() >= 0 && value.BitLen() <= 256
+}
@@ -1,10 +1,13 @@
 package types_test
 
 import (
+	"math/big"
 	"testing"
 
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
 	"github.com/celestiaorg/orchestrator-relayer/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMarshalValsetConfirm(t *testing.T) {
@@ -30,3 +33,33 @@ func TestUnmarshalValsetConfirm(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, valsetConfirm, expectedValsetConfirm)
 }
+
+func TestValsetCheckpoint(t *testing.T) {
+	vs := celestiatypes.Valset{
+		Nonce: 10,
+		Members: []celestiatypes.BridgeValidator{
+			{Power: 100, EvmAddress: "0x9c2B12b5a07FC6D719Ed7646e5041A7E85758329"},
+			{Power: 50, EvmAddress: "0x7E0a1C3E6D1Bc0A2E2bF7b1bD1a0a3D5d4c1F2e3"},
+		},
+	}
+	expectedCheckpoint, err := vs.SignBytes()
+	require.NoError(t, err)
+	vsHash, err := vs.Hash()
+	require.NoError(t, err)
+
+	checkpoint, err := types.ValsetCheckpoint(
+		big.NewInt(int64(vs.Nonce)),
+		big.NewInt(int64(vs.TwoThirdsThreshold())),
+		vsHash,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, expectedCheckpoint, checkpoint)
+
+	// the values not fitting in a uint256 can't be encoded
+	_, err = types.ValsetCheckpoint(big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), 256), vsHash)
+	assert.ErrorIs(t, err, types.ErrNotUint256)
+	_, err = types.ValsetCheckpoint(big.NewInt(-1), big.NewInt(1), vsHash)
+	assert.ErrorIs(t, err, types.ErrNotUint256)
+	_, err = types.ValsetCheckpoint(big.NewInt(1), nil, vsHash)
+	assert.ErrorIs(t, err, types.ErrNotUint256)
+}
@@ -0,0 +1,225 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
+	"github.com/celestiaorg/orchestrator-relayer/evm"
+	"github.com/celestiaorg/orchestrator-relayer/rpc"
+	"github.com/celestiaorg/orchestrator-relayer/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	// DataRootTupleRootEventType the type of the results of the data root tuple root events.
+	DataRootTupleRootEventType = "DataRootTupleRootEvent"
+	// ValidatorSetUpdatedEventType the type of the results of the validator set updated events.
+	ValidatorSetUpdatedEventType = "ValidatorSetUpdatedEvent"
+)
+
+// Result the result of the verification of an event emitted by the Blobstream contract.
+type Result struct {
+	// Type the type of the event: DataRootTupleRootEvent or ValidatorSetUpdatedEvent.
+	Type  string `json:"type"`
+	Nonce uint64 `json:"nonce"`
+	// EVMBlock the EVM block where the event was emitted.
+	EVMBlock uint64 `json:"evm_block"`
+	TxHash   string `json:"tx_hash"`
+	// Expected the data root tuple root, or the valset checkpoint, computed from Celestia.
+	// Empty if it couldn't be computed.
+	Expected string `json:"expected,omitempty"`
+	// Actual the data root tuple root, or the valset checkpoint, committed to the contract.
+	Actual string `json:"actual"`
+	// Reason why the event couldn't be verified.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Report the result of the verification of the events emitted by the Blobstream contract
+// in a range of EVM blocks.
+type Report struct {
+	FromBlock uint64 `json:"from_block"`
+	ToBlock   uint64 `json:"to_block"`
+	// Verified the number of events matching the values computed from Celestia.
+	Verified int `json:"verified"`
+	// Mismatches the events not matching the values computed from Celestia.
+	Mismatches []Result `json:"mismatches"`
+	// Unverified the events whose expected values couldn't be computed from Celestia.
+	// For example, because their attestations were pruned from the Celestia state.
+	Unverified []Result `json:"unverified"`
+}
+
+// Verifier verifies that the events emitted by a deployed Blobstream contract commit to
+// the attestations created on Celestia.
+type Verifier struct {
+	Logger     tmlog.Logger
+	AppQuerier *rpc.AppQuerier
+	TmQuerier  *rpc.TmQuerier
+	EVMClient  *evm.Client
+}
+
+func New(
+	logger tmlog.Logger,
+	appQuerier *rpc.AppQuerier,
+	tmQuerier *rpc.TmQuerier,
+	evmClient *evm.Client,
+) *Verifier {
+	return &Verifier{
+		Logger:     logger,
+		AppQuerier: appQuerier,
+		TmQuerier:  tmQuerier,
+		EVMClient:  evmClient,
+	}
+}
+
+// VerifyContract verifies all the data root tuple root and validator set updated events emitted
+// by the Blobstream contract between the provided EVM blocks, both inclusive.
+// The events are queried in chunks of blockRange blocks, so that the EVM RPC limits are not exceeded.
+func (v *Verifier) VerifyContract(ctx context.Context, fromBlock uint64, toBlock uint64, blockRange uint64) (*Report, error) {
+	if blockRange == 0 {
+		return nil, fmt.Errorf("the block range should be strictly positive")
+	}
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("the from block %d is higher than the to block %d", fromBlock, toBlock)
+	}
+	report := &Report{
+		FromBlock:  fromBlock,
+		ToBlock:    toBlock,
+		Mismatches: make([]Result, 0),
+		Unverified: make([]Result, 0),
+	}
+	for start := fromBlock; start <= toBlock; start += blockRange {
+		end := start + blockRange - 1
+		if end > toBlock || end < start {
+			end = toBlock
+		}
+		v.Logger.Debug("verifying events", "from_block", start, "to_block", end)
+
+		dcEvents, err := v.EVMClient.FilterDataRootTupleRootEvents(ctx, start, &end)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range dcEvents {
+			result := v.VerifyDataRootTupleRoot(ctx, event.Nonce.Uint64(), event.DataRootTupleRoot)
+			result.EVMBlock = event.Raw.BlockNumber
+			result.TxHash = event.Raw.TxHash.Hex()
+			report.add(result)
+		}
+
+		vsEvents, err := v.EVMClient.FilterValidatorSetUpdatedEvents(ctx, start, &end)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range vsEvents {
+			result := v.VerifyValsetCheckpoint(ctx, event.Nonce.Uint64(), event.PowerThreshold, event.ValidatorSetHash)
+			result.EVMBlock = event.Raw.BlockNumber
+			result.TxHash = event.Raw.TxHash.Hex()
+			report.add(result)
+		}
+
+		if end == toBlock {
+			break
+		}
+	}
+	v.Logger.Info(
+		"verified contract events",
+		"from_block", fromBlock,
+		"to_block", toBlock,
+		"verified", report.Verified,
+		"mismatches", len(report.Mismatches),
+		"unverified", len(report.Unverified),
+	)
+	return report, nil
+}
+
+// VerifyDataRootTupleRoot verifies that the data root tuple root committed to the contract for
+// the provided nonce is the one computed from the Celestia data commitment.
+func (v *Verifier) VerifyDataRootTupleRoot(ctx context.Context, nonce uint64, dataRootTupleRoot [32]byte) Result {
+	result := Result{
+		Type:   DataRootTupleRootEventType,
+		Nonce:  nonce,
+		Actual: ethcmn.Hash(dataRootTupleRoot).Hex(),
+	}
+	att, reason := v.queryAttestation(ctx, nonce)
+	if att == nil {
+		result.Reason = reason
+		return result
+	}
+	dc, ok := att.(*celestiatypes.DataCommitment)
+	if !ok {
+		result.Reason = fmt.Sprintf("the attestation is not a data commitment: %T", att)
+		return result
+	}
+	commitment, err := v.TmQuerier.QueryCommitment(ctx, dc.BeginBlock, dc.EndBlock)
+	if err != nil {
+		result.Reason = fmt.Sprintf("couldn't query the data commitment: %s", err.Error())
+		return result
+	}
+	result.Expected = ethcmn.BytesToHash(commitment).Hex()
+	if result.Expected != result.Actual {
+		result.Reason = fmt.Sprintf("data root tuple root mismatch for blocks [%d, %d)", dc.BeginBlock, dc.EndBlock)
+	}
+	return result
+}
+
+// VerifyValsetCheckpoint verifies that the validator set checkpoint committed to the contract for
+// the provided nonce is the one computed from the Celestia valset.
+func (v *Verifier) VerifyValsetCheckpoint(ctx context.Context, nonce uint64, powerThreshold *big.Int, valsetHash [32]byte) Result {
+	result := Result{
+		Type:  ValidatorSetUpdatedEventType,
+		Nonce: nonce,
+	}
+	actual, err := types.ValsetCheckpoint(new(big.Int).SetUint64(nonce), powerThreshold, valsetHash)
+	if err != nil {
+		result.Reason = fmt.Sprintf("couldn't compute the contract valset checkpoint: %s", err.Error())
+		return result
+	}
+	result.Actual = actual.Hex()
+	att, reason := v.queryAttestation(ctx, nonce)
+	if att == nil {
+		result.Reason = reason
+		return result
+	}
+	vs, ok := att.(*celestiatypes.Valset)
+	if !ok {
+		result.Reason = fmt.Sprintf("the attestation is not a valset: %T", att)
+		return result
+	}
+	checkpoint, err := vs.SignBytes()
+	if err != nil {
+		result.Reason = fmt.Sprintf("couldn't compute the valset checkpoint: %s", err.Error())
+		return result
+	}
+	result.Expected = checkpoint.Hex()
+	if result.Expected != result.Actual {
+		result.Reason = "valset checkpoint mismatch"
+	}
+	return result
+}
+
+// queryAttestation queries the attestation corresponding to the provided nonce.
+// If it can't be found, a nil attestation is returned along with the reason.
+func (v *Verifier) queryAttestation(ctx context.Context, nonce uint64) (celestiatypes.AttestationRequestI, string) {
+	att, err := v.AppQuerier.QueryAttestationByNonce(ctx, nonce)
+	if err != nil {
+		return nil, fmt.Sprintf("couldn't query the attestation: %s", err.Error())
+	}
+	if att == nil {
+		return nil, "attestation not found in the Celestia state, it might have been pruned"
+	}
+	return att, ""
+}
+
+// add adds the result to the report depending on its outcome.
+func (r *Report) add(result Result) {
+	switch {
+	case result.Expected == "":
+		r.Unverified = append(r.Unverified, result)
+	case result.Expected != result.Actual:
+		r.Mismatches = append(r.Mismatches, result)
+	default:
+		r.Verified++
+	}
+}
@@ -0,0 +1,97 @@
+package verifier_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/test/util/testnode"
+	celestiatypes "github.com/celestiaorg/celestia-app/x/qgb/types"
+	blobstreamtesting "github.com/celestiaorg/orchestrator-relayer/testing"
+	"github.com/celestiaorg/orchestrator-relayer/verifier"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+func TestVerifyContract(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping verifier tests in short mode.")
+	}
+	ctx := context.Background()
+	codec := encoding.MakeConfig(app.ModuleEncodingRegisters...).Codec
+	node := blobstreamtesting.NewTestNode(
+		ctx,
+		t,
+		blobstreamtesting.CelestiaNetworkParams{
+			GenesisOpts: []testnode.GenesisOption{
+				testnode.ImmediateProposals(codec),
+				blobstreamtesting.SetDataCommitmentWindowParams(codec, celestiatypes.Params{DataCommitmentWindow: 101}),
+			},
+			TimeIotaMs:    1,
+			Pruning:       "default",
+			TimeoutCommit: 5 * time.Millisecond,
+		},
+	)
+	defer node.Close()
+	orch := blobstreamtesting.NewOrchestrator(t, node)
+	relayer := blobstreamtesting.NewRelayer(t, node)
+	go node.EVMChain.PeriodicCommit(ctx, time.Millisecond)
+
+	_, err := node.CelestiaNetwork.WaitForHeight(250)
+	require.NoError(t, err)
+
+	vs, err := relayer.AppQuerier.QueryLatestValset(ctx)
+	require.NoError(t, err)
+	_, _, _, err = relayer.EVMClient.DeployBlobstreamContract(node.EVMChain.Auth, node.EVMChain.Backend, *vs, vs.Nonce, true)
+	require.NoError(t, err)
+
+	// relaying the first data commitment
+	att, err := relayer.AppQuerier.QueryAttestationByNonce(ctx, vs.Nonce+1)
+	require.NoError(t, err)
+	require.NotNil(t, att)
+	dc, ok := att.(*celestiatypes.DataCommitment)
+	require.True(t, ok)
+	require.NoError(t, orch.Process(ctx, dc.Nonce))
+	tx, err := relayer.ProcessAttestation(ctx, node.EVMChain.Auth, dc)
+	require.NoError(t, err)
+	receipt, err := relayer.EVMClient.WaitForTransaction(ctx, node.EVMChain.Backend, tx, 20*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), receipt.Status)
+
+	v := verifier.New(tmlog.NewNopLogger(), relayer.AppQuerier, relayer.TmQuerier, relayer.EVMClient)
+
+	report, err := v.VerifyContract(ctx, 0, receipt.BlockNumber.Uint64(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Verified)
+	assert.Empty(t, report.Mismatches)
+	assert.Empty(t, report.Unverified)
+
+	// a data root tuple root that doesn't commit to the data commitment
+	result := v.VerifyDataRootTupleRoot(ctx, dc.Nonce, ethcmn.HexToHash("0x12345"))
+	assert.NotEqual(t, result.Expected, result.Actual)
+	assert.NotEmpty(t, result.Reason)
+
+	// the valset checkpoint
+	vsHash, err := vs.Hash()
+	require.NoError(t, err)
+	result = v.VerifyValsetCheckpoint(ctx, vs.Nonce, big.NewInt(int64(vs.TwoThirdsThreshold())), vsHash)
+	assert.Equal(t, result.Expected, result.Actual)
+	assert.Empty(t, result.Reason)
+	result = v.VerifyValsetCheckpoint(ctx, vs.Nonce, big.NewInt(1), vsHash)
+	assert.NotEqual(t, result.Expected, result.Actual)
+
+	// the nonce type doesn't match the event
+	result = v.VerifyValsetCheckpoint(ctx, dc.Nonce, big.NewInt(1), vsHash)
+	assert.Empty(t, result.Expected)
+	assert.NotEmpty(t, result.Reason)
+
+	// the contract checkpoint can't be computed from the event values
+	result = v.VerifyValsetCheckpoint(ctx, vs.Nonce, new(big.Int).Lsh(big.NewInt(1), 256), vsHash)
+	assert.Empty(t, result.Expected)
+	assert.NotEmpty(t, result.Reason)
+}